@@ -19,11 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Menu_CreateMenu_FullMethodName = "/api.console.administration.Menu/CreateMenu"
-	Menu_UpdateMenu_FullMethodName = "/api.console.administration.Menu/UpdateMenu"
-	Menu_DeleteMenu_FullMethodName = "/api.console.administration.Menu/DeleteMenu"
-	Menu_GetMenu_FullMethodName    = "/api.console.administration.Menu/GetMenu"
-	Menu_ListMenu_FullMethodName   = "/api.console.administration.Menu/ListMenu"
+	Menu_CreateMenu_FullMethodName   = "/api.console.administration.Menu/CreateMenu"
+	Menu_UpdateMenu_FullMethodName   = "/api.console.administration.Menu/UpdateMenu"
+	Menu_DeleteMenu_FullMethodName   = "/api.console.administration.Menu/DeleteMenu"
+	Menu_RepairMenus_FullMethodName  = "/api.console.administration.Menu/RepairMenus"
+	Menu_MoveMenu_FullMethodName     = "/api.console.administration.Menu/MoveMenu"
+	Menu_ReorderMenus_FullMethodName = "/api.console.administration.Menu/ReorderMenus"
+	Menu_GetMenuTree_FullMethodName  = "/api.console.administration.Menu/GetMenuTree"
+	Menu_GetMenu_FullMethodName      = "/api.console.administration.Menu/GetMenu"
+	Menu_ListMenu_FullMethodName     = "/api.console.administration.Menu/ListMenu"
 )
 
 // MenuClient is the client API for Menu service.
@@ -33,6 +37,14 @@ type MenuClient interface {
 	CreateMenu(ctx context.Context, in *CreateMenuRequest, opts ...grpc.CallOption) (*CreateMenuReply, error)
 	UpdateMenu(ctx context.Context, in *UpdateMenuRequest, opts ...grpc.CallOption) (*UpdateMenuReply, error)
 	DeleteMenu(ctx context.Context, in *DeleteMenuRequest, opts ...grpc.CallOption) (*DeleteMenuReply, error)
+	// 检查并修复上级不存在、循环引用以及关联权限已删除的菜单
+	RepairMenus(ctx context.Context, in *RepairMenusRequest, opts ...grpc.CallOption) (*RepairMenusReply, error)
+	// 移动菜单到新的上级菜单的指定位置, 同级菜单重新编号
+	MoveMenu(ctx context.Context, in *MoveMenuRequest, opts ...grpc.CallOption) (*MoveMenuReply, error)
+	// 按给定顺序重排同一上级下的全部菜单
+	ReorderMenus(ctx context.Context, in *ReorderMenusRequest, opts ...grpc.CallOption) (*ReorderMenusReply, error)
+	// 菜单树, 同级按 sort_by 排序
+	GetMenuTree(ctx context.Context, in *GetMenuTreeRequest, opts ...grpc.CallOption) (*GetMenuTreeReply, error)
 	GetMenu(ctx context.Context, in *GetMenuRequest, opts ...grpc.CallOption) (*GetMenuReply, error)
 	ListMenu(ctx context.Context, in *ListMenuRequest, opts ...grpc.CallOption) (*ListMenuReply, error)
 }
@@ -75,6 +87,46 @@ func (c *menuClient) DeleteMenu(ctx context.Context, in *DeleteMenuRequest, opts
 	return out, nil
 }
 
+func (c *menuClient) RepairMenus(ctx context.Context, in *RepairMenusRequest, opts ...grpc.CallOption) (*RepairMenusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RepairMenusReply)
+	err := c.cc.Invoke(ctx, Menu_RepairMenus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuClient) MoveMenu(ctx context.Context, in *MoveMenuRequest, opts ...grpc.CallOption) (*MoveMenuReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveMenuReply)
+	err := c.cc.Invoke(ctx, Menu_MoveMenu_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuClient) ReorderMenus(ctx context.Context, in *ReorderMenusRequest, opts ...grpc.CallOption) (*ReorderMenusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderMenusReply)
+	err := c.cc.Invoke(ctx, Menu_ReorderMenus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuClient) GetMenuTree(ctx context.Context, in *GetMenuTreeRequest, opts ...grpc.CallOption) (*GetMenuTreeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMenuTreeReply)
+	err := c.cc.Invoke(ctx, Menu_GetMenuTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *menuClient) GetMenu(ctx context.Context, in *GetMenuRequest, opts ...grpc.CallOption) (*GetMenuReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetMenuReply)
@@ -102,6 +154,14 @@ type MenuServer interface {
 	CreateMenu(context.Context, *CreateMenuRequest) (*CreateMenuReply, error)
 	UpdateMenu(context.Context, *UpdateMenuRequest) (*UpdateMenuReply, error)
 	DeleteMenu(context.Context, *DeleteMenuRequest) (*DeleteMenuReply, error)
+	// 检查并修复上级不存在、循环引用以及关联权限已删除的菜单
+	RepairMenus(context.Context, *RepairMenusRequest) (*RepairMenusReply, error)
+	// 移动菜单到新的上级菜单的指定位置, 同级菜单重新编号
+	MoveMenu(context.Context, *MoveMenuRequest) (*MoveMenuReply, error)
+	// 按给定顺序重排同一上级下的全部菜单
+	ReorderMenus(context.Context, *ReorderMenusRequest) (*ReorderMenusReply, error)
+	// 菜单树, 同级按 sort_by 排序
+	GetMenuTree(context.Context, *GetMenuTreeRequest) (*GetMenuTreeReply, error)
 	GetMenu(context.Context, *GetMenuRequest) (*GetMenuReply, error)
 	ListMenu(context.Context, *ListMenuRequest) (*ListMenuReply, error)
 	mustEmbedUnimplementedMenuServer()
@@ -123,6 +183,18 @@ func (UnimplementedMenuServer) UpdateMenu(context.Context, *UpdateMenuRequest) (
 func (UnimplementedMenuServer) DeleteMenu(context.Context, *DeleteMenuRequest) (*DeleteMenuReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMenu not implemented")
 }
+func (UnimplementedMenuServer) RepairMenus(context.Context, *RepairMenusRequest) (*RepairMenusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairMenus not implemented")
+}
+func (UnimplementedMenuServer) MoveMenu(context.Context, *MoveMenuRequest) (*MoveMenuReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveMenu not implemented")
+}
+func (UnimplementedMenuServer) ReorderMenus(context.Context, *ReorderMenusRequest) (*ReorderMenusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderMenus not implemented")
+}
+func (UnimplementedMenuServer) GetMenuTree(context.Context, *GetMenuTreeRequest) (*GetMenuTreeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMenuTree not implemented")
+}
 func (UnimplementedMenuServer) GetMenu(context.Context, *GetMenuRequest) (*GetMenuReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMenu not implemented")
 }
@@ -204,6 +276,78 @@ func _Menu_DeleteMenu_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Menu_RepairMenus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairMenusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServer).RepairMenus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Menu_RepairMenus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServer).RepairMenus(ctx, req.(*RepairMenusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Menu_MoveMenu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveMenuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServer).MoveMenu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Menu_MoveMenu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServer).MoveMenu(ctx, req.(*MoveMenuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Menu_ReorderMenus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderMenusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServer).ReorderMenus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Menu_ReorderMenus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServer).ReorderMenus(ctx, req.(*ReorderMenusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Menu_GetMenuTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMenuTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServer).GetMenuTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Menu_GetMenuTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServer).GetMenuTree(ctx, req.(*GetMenuTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Menu_GetMenu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMenuRequest)
 	if err := dec(in); err != nil {
@@ -259,6 +403,22 @@ var Menu_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMenu",
 			Handler:    _Menu_DeleteMenu_Handler,
 		},
+		{
+			MethodName: "RepairMenus",
+			Handler:    _Menu_RepairMenus_Handler,
+		},
+		{
+			MethodName: "MoveMenu",
+			Handler:    _Menu_MoveMenu_Handler,
+		},
+		{
+			MethodName: "ReorderMenus",
+			Handler:    _Menu_ReorderMenus_Handler,
+		},
+		{
+			MethodName: "GetMenuTree",
+			Handler:    _Menu_GetMenuTree_Handler,
+		},
 		{
 			MethodName: "GetMenu",
 			Handler:    _Menu_GetMenu_Handler,
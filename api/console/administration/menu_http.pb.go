@@ -22,14 +22,26 @@ const _ = http.SupportPackageIsVersion1
 const OperationMenuCreateMenu = "/api.console.administration.Menu/CreateMenu"
 const OperationMenuDeleteMenu = "/api.console.administration.Menu/DeleteMenu"
 const OperationMenuGetMenu = "/api.console.administration.Menu/GetMenu"
+const OperationMenuGetMenuTree = "/api.console.administration.Menu/GetMenuTree"
 const OperationMenuListMenu = "/api.console.administration.Menu/ListMenu"
+const OperationMenuMoveMenu = "/api.console.administration.Menu/MoveMenu"
+const OperationMenuReorderMenus = "/api.console.administration.Menu/ReorderMenus"
+const OperationMenuRepairMenus = "/api.console.administration.Menu/RepairMenus"
 const OperationMenuUpdateMenu = "/api.console.administration.Menu/UpdateMenu"
 
 type MenuHTTPServer interface {
 	CreateMenu(context.Context, *CreateMenuRequest) (*CreateMenuReply, error)
 	DeleteMenu(context.Context, *DeleteMenuRequest) (*DeleteMenuReply, error)
 	GetMenu(context.Context, *GetMenuRequest) (*GetMenuReply, error)
+	// GetMenuTree 菜单树, 同级按 sort_by 排序
+	GetMenuTree(context.Context, *GetMenuTreeRequest) (*GetMenuTreeReply, error)
 	ListMenu(context.Context, *ListMenuRequest) (*ListMenuReply, error)
+	// MoveMenu 移动菜单到新的上级菜单的指定位置, 同级菜单重新编号
+	MoveMenu(context.Context, *MoveMenuRequest) (*MoveMenuReply, error)
+	// ReorderMenus 按给定顺序重排同一上级下的全部菜单
+	ReorderMenus(context.Context, *ReorderMenusRequest) (*ReorderMenusReply, error)
+	// RepairMenus 检查并修复上级不存在、循环引用以及关联权限已删除的菜单
+	RepairMenus(context.Context, *RepairMenusRequest) (*RepairMenusReply, error)
 	UpdateMenu(context.Context, *UpdateMenuRequest) (*UpdateMenuReply, error)
 }
 
@@ -38,6 +50,10 @@ func RegisterMenuHTTPServer(s *http.Server, srv MenuHTTPServer) {
 	r.POST("/api/console/menu", _Menu_CreateMenu0_HTTP_Handler(srv))
 	r.PUT("/api/console/menu/{uid}", _Menu_UpdateMenu0_HTTP_Handler(srv))
 	r.DELETE("/api/console/menu/{uid}", _Menu_DeleteMenu0_HTTP_Handler(srv))
+	r.POST("/api/console/menu/repair", _Menu_RepairMenus0_HTTP_Handler(srv))
+	r.POST("/api/console/menu/{uid}/move", _Menu_MoveMenu0_HTTP_Handler(srv))
+	r.POST("/api/console/menu/reorder", _Menu_ReorderMenus0_HTTP_Handler(srv))
+	r.GET("/api/console/menu/tree", _Menu_GetMenuTree0_HTTP_Handler(srv))
 	r.GET("/api/console/menu/{uid}", _Menu_GetMenu0_HTTP_Handler(srv))
 	r.GET("/api/console/menu", _Menu_ListMenu0_HTTP_Handler(srv))
 }
@@ -111,6 +127,94 @@ func _Menu_DeleteMenu0_HTTP_Handler(srv MenuHTTPServer) func(ctx http.Context) e
 	}
 }
 
+func _Menu_RepairMenus0_HTTP_Handler(srv MenuHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RepairMenusRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationMenuRepairMenus)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RepairMenus(ctx, req.(*RepairMenusRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RepairMenusReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Menu_MoveMenu0_HTTP_Handler(srv MenuHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in MoveMenuRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationMenuMoveMenu)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.MoveMenu(ctx, req.(*MoveMenuRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*MoveMenuReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Menu_ReorderMenus0_HTTP_Handler(srv MenuHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ReorderMenusRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationMenuReorderMenus)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ReorderMenus(ctx, req.(*ReorderMenusRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ReorderMenusReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Menu_GetMenuTree0_HTTP_Handler(srv MenuHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetMenuTreeRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationMenuGetMenuTree)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetMenuTree(ctx, req.(*GetMenuTreeRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetMenuTreeReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _Menu_GetMenu0_HTTP_Handler(srv MenuHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in GetMenuRequest
@@ -156,7 +260,11 @@ type MenuHTTPClient interface {
 	CreateMenu(ctx context.Context, req *CreateMenuRequest, opts ...http.CallOption) (rsp *CreateMenuReply, err error)
 	DeleteMenu(ctx context.Context, req *DeleteMenuRequest, opts ...http.CallOption) (rsp *DeleteMenuReply, err error)
 	GetMenu(ctx context.Context, req *GetMenuRequest, opts ...http.CallOption) (rsp *GetMenuReply, err error)
+	GetMenuTree(ctx context.Context, req *GetMenuTreeRequest, opts ...http.CallOption) (rsp *GetMenuTreeReply, err error)
 	ListMenu(ctx context.Context, req *ListMenuRequest, opts ...http.CallOption) (rsp *ListMenuReply, err error)
+	MoveMenu(ctx context.Context, req *MoveMenuRequest, opts ...http.CallOption) (rsp *MoveMenuReply, err error)
+	ReorderMenus(ctx context.Context, req *ReorderMenusRequest, opts ...http.CallOption) (rsp *ReorderMenusReply, err error)
+	RepairMenus(ctx context.Context, req *RepairMenusRequest, opts ...http.CallOption) (rsp *RepairMenusReply, err error)
 	UpdateMenu(ctx context.Context, req *UpdateMenuRequest, opts ...http.CallOption) (rsp *UpdateMenuReply, err error)
 }
 
@@ -207,6 +315,19 @@ func (c *MenuHTTPClientImpl) GetMenu(ctx context.Context, in *GetMenuRequest, op
 	return &out, nil
 }
 
+func (c *MenuHTTPClientImpl) GetMenuTree(ctx context.Context, in *GetMenuTreeRequest, opts ...http.CallOption) (*GetMenuTreeReply, error) {
+	var out GetMenuTreeReply
+	pattern := "/api/console/menu/tree"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationMenuGetMenuTree))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *MenuHTTPClientImpl) ListMenu(ctx context.Context, in *ListMenuRequest, opts ...http.CallOption) (*ListMenuReply, error) {
 	var out ListMenuReply
 	pattern := "/api/console/menu"
@@ -220,6 +341,45 @@ func (c *MenuHTTPClientImpl) ListMenu(ctx context.Context, in *ListMenuRequest,
 	return &out, nil
 }
 
+func (c *MenuHTTPClientImpl) MoveMenu(ctx context.Context, in *MoveMenuRequest, opts ...http.CallOption) (*MoveMenuReply, error) {
+	var out MoveMenuReply
+	pattern := "/api/console/menu/{uid}/move"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationMenuMoveMenu))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *MenuHTTPClientImpl) ReorderMenus(ctx context.Context, in *ReorderMenusRequest, opts ...http.CallOption) (*ReorderMenusReply, error) {
+	var out ReorderMenusReply
+	pattern := "/api/console/menu/reorder"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationMenuReorderMenus))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *MenuHTTPClientImpl) RepairMenus(ctx context.Context, in *RepairMenusRequest, opts ...http.CallOption) (*RepairMenusReply, error) {
+	var out RepairMenusReply
+	pattern := "/api/console/menu/repair"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationMenuRepairMenus))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *MenuHTTPClientImpl) UpdateMenu(ctx context.Context, in *UpdateMenuRequest, opts ...http.CallOption) (*UpdateMenuReply, error) {
 	var out UpdateMenuReply
 	pattern := "/api/console/menu/{uid}"
@@ -19,14 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Role_CreateRole_FullMethodName       = "/api.console.administration.Role/CreateRole"
-	Role_UpdateRole_FullMethodName       = "/api.console.administration.Role/UpdateRole"
-	Role_DeleteRole_FullMethodName       = "/api.console.administration.Role/DeleteRole"
-	Role_GetRole_FullMethodName          = "/api.console.administration.Role/GetRole"
-	Role_ListRole_FullMethodName         = "/api.console.administration.Role/ListRole"
-	Role_BindPermission_FullMethodName   = "/api.console.administration.Role/BindPermission"
-	Role_UnbindPermission_FullMethodName = "/api.console.administration.Role/UnbindPermission"
-	Role_GetAll_FullMethodName           = "/api.console.administration.Role/GetAll"
+	Role_CreateRole_FullMethodName                  = "/api.console.administration.Role/CreateRole"
+	Role_UpdateRole_FullMethodName                  = "/api.console.administration.Role/UpdateRole"
+	Role_DeleteRole_FullMethodName                  = "/api.console.administration.Role/DeleteRole"
+	Role_GetRole_FullMethodName                     = "/api.console.administration.Role/GetRole"
+	Role_ListRole_FullMethodName                    = "/api.console.administration.Role/ListRole"
+	Role_BindPermission_FullMethodName              = "/api.console.administration.Role/BindPermission"
+	Role_UnbindPermission_FullMethodName            = "/api.console.administration.Role/UnbindPermission"
+	Role_GetAll_FullMethodName                      = "/api.console.administration.Role/GetAll"
+	Role_SetRolePermissions_FullMethodName          = "/api.console.administration.Role/SetRolePermissions"
+	Role_CloneRole_FullMethodName                   = "/api.console.administration.Role/CloneRole"
+	Role_CreateRoleConstraint_FullMethodName        = "/api.console.administration.Role/CreateRoleConstraint"
+	Role_DeleteRoleConstraint_FullMethodName        = "/api.console.administration.Role/DeleteRoleConstraint"
+	Role_ListRoleConstraint_FullMethodName          = "/api.console.administration.Role/ListRoleConstraint"
+	Role_ListRoleConstraintViolation_FullMethodName = "/api.console.administration.Role/ListRoleConstraintViolation"
 )
 
 // RoleClient is the client API for Role service.
@@ -41,6 +47,16 @@ type RoleClient interface {
 	BindPermission(ctx context.Context, in *BindPermissionRequest, opts ...grpc.CallOption) (*BindPermissionReply, error)
 	UnbindPermission(ctx context.Context, in *UnbindPermissionRequest, opts ...grpc.CallOption) (*UnbindPermissionReply, error)
 	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllReply, error)
+	// 全量设置角色权限, 未出现在请求中的权限将被解绑
+	SetRolePermissions(ctx context.Context, in *SetRolePermissionsRequest, opts ...grpc.CallOption) (*SetRolePermissionsReply, error)
+	// 克隆角色 (包含角色绑定的全部权限)
+	CloneRole(ctx context.Context, in *CloneRoleRequest, opts ...grpc.CallOption) (*CloneRoleReply, error)
+	// 创建角色互斥约束, 同一用户不能同时持有互斥的两个角色
+	CreateRoleConstraint(ctx context.Context, in *CreateRoleConstraintRequest, opts ...grpc.CallOption) (*CreateRoleConstraintReply, error)
+	DeleteRoleConstraint(ctx context.Context, in *DeleteRoleConstraintRequest, opts ...grpc.CallOption) (*DeleteRoleConstraintReply, error)
+	ListRoleConstraint(ctx context.Context, in *ListRoleConstraintRequest, opts ...grpc.CallOption) (*ListRoleConstraintReply, error)
+	// 违反互斥约束的存量用户报告
+	ListRoleConstraintViolation(ctx context.Context, in *ListRoleConstraintViolationRequest, opts ...grpc.CallOption) (*ListRoleConstraintViolationReply, error)
 }
 
 type roleClient struct {
@@ -131,6 +147,66 @@ func (c *roleClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *roleClient) SetRolePermissions(ctx context.Context, in *SetRolePermissionsRequest, opts ...grpc.CallOption) (*SetRolePermissionsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRolePermissionsReply)
+	err := c.cc.Invoke(ctx, Role_SetRolePermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) CloneRole(ctx context.Context, in *CloneRoleRequest, opts ...grpc.CallOption) (*CloneRoleReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneRoleReply)
+	err := c.cc.Invoke(ctx, Role_CloneRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) CreateRoleConstraint(ctx context.Context, in *CreateRoleConstraintRequest, opts ...grpc.CallOption) (*CreateRoleConstraintReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRoleConstraintReply)
+	err := c.cc.Invoke(ctx, Role_CreateRoleConstraint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) DeleteRoleConstraint(ctx context.Context, in *DeleteRoleConstraintRequest, opts ...grpc.CallOption) (*DeleteRoleConstraintReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRoleConstraintReply)
+	err := c.cc.Invoke(ctx, Role_DeleteRoleConstraint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) ListRoleConstraint(ctx context.Context, in *ListRoleConstraintRequest, opts ...grpc.CallOption) (*ListRoleConstraintReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleConstraintReply)
+	err := c.cc.Invoke(ctx, Role_ListRoleConstraint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) ListRoleConstraintViolation(ctx context.Context, in *ListRoleConstraintViolationRequest, opts ...grpc.CallOption) (*ListRoleConstraintViolationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleConstraintViolationReply)
+	err := c.cc.Invoke(ctx, Role_ListRoleConstraintViolation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoleServer is the server API for Role service.
 // All implementations must embed UnimplementedRoleServer
 // for forward compatibility.
@@ -143,6 +219,16 @@ type RoleServer interface {
 	BindPermission(context.Context, *BindPermissionRequest) (*BindPermissionReply, error)
 	UnbindPermission(context.Context, *UnbindPermissionRequest) (*UnbindPermissionReply, error)
 	GetAll(context.Context, *GetAllRequest) (*GetAllReply, error)
+	// 全量设置角色权限, 未出现在请求中的权限将被解绑
+	SetRolePermissions(context.Context, *SetRolePermissionsRequest) (*SetRolePermissionsReply, error)
+	// 克隆角色 (包含角色绑定的全部权限)
+	CloneRole(context.Context, *CloneRoleRequest) (*CloneRoleReply, error)
+	// 创建角色互斥约束, 同一用户不能同时持有互斥的两个角色
+	CreateRoleConstraint(context.Context, *CreateRoleConstraintRequest) (*CreateRoleConstraintReply, error)
+	DeleteRoleConstraint(context.Context, *DeleteRoleConstraintRequest) (*DeleteRoleConstraintReply, error)
+	ListRoleConstraint(context.Context, *ListRoleConstraintRequest) (*ListRoleConstraintReply, error)
+	// 违反互斥约束的存量用户报告
+	ListRoleConstraintViolation(context.Context, *ListRoleConstraintViolationRequest) (*ListRoleConstraintViolationReply, error)
 	mustEmbedUnimplementedRoleServer()
 }
 
@@ -177,6 +263,24 @@ func (UnimplementedRoleServer) UnbindPermission(context.Context, *UnbindPermissi
 func (UnimplementedRoleServer) GetAll(context.Context, *GetAllRequest) (*GetAllReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
 }
+func (UnimplementedRoleServer) SetRolePermissions(context.Context, *SetRolePermissionsRequest) (*SetRolePermissionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRolePermissions not implemented")
+}
+func (UnimplementedRoleServer) CloneRole(context.Context, *CloneRoleRequest) (*CloneRoleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneRole not implemented")
+}
+func (UnimplementedRoleServer) CreateRoleConstraint(context.Context, *CreateRoleConstraintRequest) (*CreateRoleConstraintReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoleConstraint not implemented")
+}
+func (UnimplementedRoleServer) DeleteRoleConstraint(context.Context, *DeleteRoleConstraintRequest) (*DeleteRoleConstraintReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoleConstraint not implemented")
+}
+func (UnimplementedRoleServer) ListRoleConstraint(context.Context, *ListRoleConstraintRequest) (*ListRoleConstraintReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleConstraint not implemented")
+}
+func (UnimplementedRoleServer) ListRoleConstraintViolation(context.Context, *ListRoleConstraintViolationRequest) (*ListRoleConstraintViolationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleConstraintViolation not implemented")
+}
 func (UnimplementedRoleServer) mustEmbedUnimplementedRoleServer() {}
 func (UnimplementedRoleServer) testEmbeddedByValue()              {}
 
@@ -342,6 +446,114 @@ func _Role_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Role_SetRolePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRolePermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).SetRolePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Role_SetRolePermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).SetRolePermissions(ctx, req.(*SetRolePermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_CloneRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).CloneRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Role_CloneRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).CloneRole(ctx, req.(*CloneRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_CreateRoleConstraint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleConstraintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).CreateRoleConstraint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Role_CreateRoleConstraint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).CreateRoleConstraint(ctx, req.(*CreateRoleConstraintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_DeleteRoleConstraint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleConstraintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).DeleteRoleConstraint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Role_DeleteRoleConstraint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).DeleteRoleConstraint(ctx, req.(*DeleteRoleConstraintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_ListRoleConstraint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleConstraintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).ListRoleConstraint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Role_ListRoleConstraint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).ListRoleConstraint(ctx, req.(*ListRoleConstraintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_ListRoleConstraintViolation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleConstraintViolationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).ListRoleConstraintViolation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Role_ListRoleConstraintViolation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).ListRoleConstraintViolation(ctx, req.(*ListRoleConstraintViolationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Role_ServiceDesc is the grpc.ServiceDesc for Role service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -381,6 +593,30 @@ var Role_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAll",
 			Handler:    _Role_GetAll_Handler,
 		},
+		{
+			MethodName: "SetRolePermissions",
+			Handler:    _Role_SetRolePermissions_Handler,
+		},
+		{
+			MethodName: "CloneRole",
+			Handler:    _Role_CloneRole_Handler,
+		},
+		{
+			MethodName: "CreateRoleConstraint",
+			Handler:    _Role_CreateRoleConstraint_Handler,
+		},
+		{
+			MethodName: "DeleteRoleConstraint",
+			Handler:    _Role_DeleteRoleConstraint_Handler,
+		},
+		{
+			MethodName: "ListRoleConstraint",
+			Handler:    _Role_ListRoleConstraint_Handler,
+		},
+		{
+			MethodName: "ListRoleConstraintViolation",
+			Handler:    _Role_ListRoleConstraintViolation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "console/administration/role.proto",
@@ -20,21 +20,37 @@ var _ = binding.EncodeURL
 const _ = http.SupportPackageIsVersion1
 
 const OperationRoleBindPermission = "/api.console.administration.Role/BindPermission"
+const OperationRoleCloneRole = "/api.console.administration.Role/CloneRole"
 const OperationRoleCreateRole = "/api.console.administration.Role/CreateRole"
+const OperationRoleCreateRoleConstraint = "/api.console.administration.Role/CreateRoleConstraint"
 const OperationRoleDeleteRole = "/api.console.administration.Role/DeleteRole"
+const OperationRoleDeleteRoleConstraint = "/api.console.administration.Role/DeleteRoleConstraint"
 const OperationRoleGetAll = "/api.console.administration.Role/GetAll"
 const OperationRoleGetRole = "/api.console.administration.Role/GetRole"
 const OperationRoleListRole = "/api.console.administration.Role/ListRole"
+const OperationRoleListRoleConstraint = "/api.console.administration.Role/ListRoleConstraint"
+const OperationRoleListRoleConstraintViolation = "/api.console.administration.Role/ListRoleConstraintViolation"
+const OperationRoleSetRolePermissions = "/api.console.administration.Role/SetRolePermissions"
 const OperationRoleUnbindPermission = "/api.console.administration.Role/UnbindPermission"
 const OperationRoleUpdateRole = "/api.console.administration.Role/UpdateRole"
 
 type RoleHTTPServer interface {
 	BindPermission(context.Context, *BindPermissionRequest) (*BindPermissionReply, error)
+	// CloneRole 克隆角色 (包含角色绑定的全部权限)
+	CloneRole(context.Context, *CloneRoleRequest) (*CloneRoleReply, error)
 	CreateRole(context.Context, *CreateRoleRequest) (*CreateRoleReply, error)
+	// CreateRoleConstraint 创建角色互斥约束, 同一用户不能同时持有互斥的两个角色
+	CreateRoleConstraint(context.Context, *CreateRoleConstraintRequest) (*CreateRoleConstraintReply, error)
 	DeleteRole(context.Context, *DeleteRoleRequest) (*DeleteRoleReply, error)
+	DeleteRoleConstraint(context.Context, *DeleteRoleConstraintRequest) (*DeleteRoleConstraintReply, error)
 	GetAll(context.Context, *GetAllRequest) (*GetAllReply, error)
 	GetRole(context.Context, *GetRoleRequest) (*GetRoleReply, error)
 	ListRole(context.Context, *ListRoleRequest) (*ListRoleReply, error)
+	ListRoleConstraint(context.Context, *ListRoleConstraintRequest) (*ListRoleConstraintReply, error)
+	// ListRoleConstraintViolation 违反互斥约束的存量用户报告
+	ListRoleConstraintViolation(context.Context, *ListRoleConstraintViolationRequest) (*ListRoleConstraintViolationReply, error)
+	// SetRolePermissions 全量设置角色权限, 未出现在请求中的权限将被解绑
+	SetRolePermissions(context.Context, *SetRolePermissionsRequest) (*SetRolePermissionsReply, error)
 	UnbindPermission(context.Context, *UnbindPermissionRequest) (*UnbindPermissionReply, error)
 	UpdateRole(context.Context, *UpdateRoleRequest) (*UpdateRoleReply, error)
 }
@@ -49,6 +65,12 @@ func RegisterRoleHTTPServer(s *http.Server, srv RoleHTTPServer) {
 	r.PUT("/api/console/role/{uid}/permission", _Role_BindPermission0_HTTP_Handler(srv))
 	r.PUT("/api/console/role/{uid}/permission/{permission_id}", _Role_UnbindPermission0_HTTP_Handler(srv))
 	r.GET("/api/console/role-all", _Role_GetAll0_HTTP_Handler(srv))
+	r.PUT("/api/console/role/{uid}/permissions", _Role_SetRolePermissions0_HTTP_Handler(srv))
+	r.POST("/api/console/role/{uid}/clone", _Role_CloneRole0_HTTP_Handler(srv))
+	r.POST("/api/console/role-constraint", _Role_CreateRoleConstraint0_HTTP_Handler(srv))
+	r.DELETE("/api/console/role-constraint/{uid}", _Role_DeleteRoleConstraint0_HTTP_Handler(srv))
+	r.GET("/api/console/role-constraint", _Role_ListRoleConstraint0_HTTP_Handler(srv))
+	r.GET("/api/console/role-constraint/{uid}/violation", _Role_ListRoleConstraintViolation0_HTTP_Handler(srv))
 }
 
 func _Role_CreateRole0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
@@ -230,13 +252,154 @@ func _Role_GetAll0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error
 	}
 }
 
+func _Role_SetRolePermissions0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SetRolePermissionsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoleSetRolePermissions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetRolePermissions(ctx, req.(*SetRolePermissionsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SetRolePermissionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Role_CloneRole0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CloneRoleRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoleCloneRole)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CloneRole(ctx, req.(*CloneRoleRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CloneRoleReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Role_CreateRoleConstraint0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateRoleConstraintRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoleCreateRoleConstraint)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateRoleConstraint(ctx, req.(*CreateRoleConstraintRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateRoleConstraintReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Role_DeleteRoleConstraint0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteRoleConstraintRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoleDeleteRoleConstraint)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteRoleConstraint(ctx, req.(*DeleteRoleConstraintRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteRoleConstraintReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Role_ListRoleConstraint0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListRoleConstraintRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoleListRoleConstraint)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListRoleConstraint(ctx, req.(*ListRoleConstraintRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListRoleConstraintReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Role_ListRoleConstraintViolation0_HTTP_Handler(srv RoleHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListRoleConstraintViolationRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationRoleListRoleConstraintViolation)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListRoleConstraintViolation(ctx, req.(*ListRoleConstraintViolationRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListRoleConstraintViolationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type RoleHTTPClient interface {
 	BindPermission(ctx context.Context, req *BindPermissionRequest, opts ...http.CallOption) (rsp *BindPermissionReply, err error)
+	CloneRole(ctx context.Context, req *CloneRoleRequest, opts ...http.CallOption) (rsp *CloneRoleReply, err error)
 	CreateRole(ctx context.Context, req *CreateRoleRequest, opts ...http.CallOption) (rsp *CreateRoleReply, err error)
+	CreateRoleConstraint(ctx context.Context, req *CreateRoleConstraintRequest, opts ...http.CallOption) (rsp *CreateRoleConstraintReply, err error)
 	DeleteRole(ctx context.Context, req *DeleteRoleRequest, opts ...http.CallOption) (rsp *DeleteRoleReply, err error)
+	DeleteRoleConstraint(ctx context.Context, req *DeleteRoleConstraintRequest, opts ...http.CallOption) (rsp *DeleteRoleConstraintReply, err error)
 	GetAll(ctx context.Context, req *GetAllRequest, opts ...http.CallOption) (rsp *GetAllReply, err error)
 	GetRole(ctx context.Context, req *GetRoleRequest, opts ...http.CallOption) (rsp *GetRoleReply, err error)
 	ListRole(ctx context.Context, req *ListRoleRequest, opts ...http.CallOption) (rsp *ListRoleReply, err error)
+	ListRoleConstraint(ctx context.Context, req *ListRoleConstraintRequest, opts ...http.CallOption) (rsp *ListRoleConstraintReply, err error)
+	ListRoleConstraintViolation(ctx context.Context, req *ListRoleConstraintViolationRequest, opts ...http.CallOption) (rsp *ListRoleConstraintViolationReply, err error)
+	SetRolePermissions(ctx context.Context, req *SetRolePermissionsRequest, opts ...http.CallOption) (rsp *SetRolePermissionsReply, err error)
 	UnbindPermission(ctx context.Context, req *UnbindPermissionRequest, opts ...http.CallOption) (rsp *UnbindPermissionReply, err error)
 	UpdateRole(ctx context.Context, req *UpdateRoleRequest, opts ...http.CallOption) (rsp *UpdateRoleReply, err error)
 }
@@ -262,6 +425,19 @@ func (c *RoleHTTPClientImpl) BindPermission(ctx context.Context, in *BindPermiss
 	return &out, nil
 }
 
+func (c *RoleHTTPClientImpl) CloneRole(ctx context.Context, in *CloneRoleRequest, opts ...http.CallOption) (*CloneRoleReply, error) {
+	var out CloneRoleReply
+	pattern := "/api/console/role/{uid}/clone"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoleCloneRole))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *RoleHTTPClientImpl) CreateRole(ctx context.Context, in *CreateRoleRequest, opts ...http.CallOption) (*CreateRoleReply, error) {
 	var out CreateRoleReply
 	pattern := "/api/console/role"
@@ -275,6 +451,19 @@ func (c *RoleHTTPClientImpl) CreateRole(ctx context.Context, in *CreateRoleReque
 	return &out, nil
 }
 
+func (c *RoleHTTPClientImpl) CreateRoleConstraint(ctx context.Context, in *CreateRoleConstraintRequest, opts ...http.CallOption) (*CreateRoleConstraintReply, error) {
+	var out CreateRoleConstraintReply
+	pattern := "/api/console/role-constraint"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoleCreateRoleConstraint))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *RoleHTTPClientImpl) DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...http.CallOption) (*DeleteRoleReply, error) {
 	var out DeleteRoleReply
 	pattern := "/api/console/role/{uid}"
@@ -288,6 +477,19 @@ func (c *RoleHTTPClientImpl) DeleteRole(ctx context.Context, in *DeleteRoleReque
 	return &out, nil
 }
 
+func (c *RoleHTTPClientImpl) DeleteRoleConstraint(ctx context.Context, in *DeleteRoleConstraintRequest, opts ...http.CallOption) (*DeleteRoleConstraintReply, error) {
+	var out DeleteRoleConstraintReply
+	pattern := "/api/console/role-constraint/{uid}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationRoleDeleteRoleConstraint))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *RoleHTTPClientImpl) GetAll(ctx context.Context, in *GetAllRequest, opts ...http.CallOption) (*GetAllReply, error) {
 	var out GetAllReply
 	pattern := "/api/console/role-all"
@@ -327,6 +529,45 @@ func (c *RoleHTTPClientImpl) ListRole(ctx context.Context, in *ListRoleRequest,
 	return &out, nil
 }
 
+func (c *RoleHTTPClientImpl) ListRoleConstraint(ctx context.Context, in *ListRoleConstraintRequest, opts ...http.CallOption) (*ListRoleConstraintReply, error) {
+	var out ListRoleConstraintReply
+	pattern := "/api/console/role-constraint"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationRoleListRoleConstraint))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *RoleHTTPClientImpl) ListRoleConstraintViolation(ctx context.Context, in *ListRoleConstraintViolationRequest, opts ...http.CallOption) (*ListRoleConstraintViolationReply, error) {
+	var out ListRoleConstraintViolationReply
+	pattern := "/api/console/role-constraint/{uid}/violation"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationRoleListRoleConstraintViolation))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *RoleHTTPClientImpl) SetRolePermissions(ctx context.Context, in *SetRolePermissionsRequest, opts ...http.CallOption) (*SetRolePermissionsReply, error) {
+	var out SetRolePermissionsReply
+	pattern := "/api/console/role/{uid}/permissions"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationRoleSetRolePermissions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *RoleHTTPClientImpl) UnbindPermission(ctx context.Context, in *UnbindPermissionRequest, opts ...http.CallOption) (*UnbindPermissionReply, error) {
 	var out UnbindPermissionReply
 	pattern := "/api/console/role/{uid}/permission/{permission_id}"
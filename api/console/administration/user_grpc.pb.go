@@ -19,13 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	User_CreateUser_FullMethodName = "/api.console.administration.User/CreateUser"
-	User_UpdateUser_FullMethodName = "/api.console.administration.User/UpdateUser"
-	User_DeleteUser_FullMethodName = "/api.console.administration.User/DeleteUser"
-	User_GetUser_FullMethodName    = "/api.console.administration.User/GetUser"
-	User_ListUser_FullMethodName   = "/api.console.administration.User/ListUser"
-	User_BindRole_FullMethodName   = "/api.console.administration.User/BindRole"
-	User_UnbindRole_FullMethodName = "/api.console.administration.User/UnbindRole"
+	User_CreateUser_FullMethodName          = "/api.console.administration.User/CreateUser"
+	User_UpdateUser_FullMethodName          = "/api.console.administration.User/UpdateUser"
+	User_DeleteUser_FullMethodName          = "/api.console.administration.User/DeleteUser"
+	User_ListDeletedUsers_FullMethodName    = "/api.console.administration.User/ListDeletedUsers"
+	User_RestoreUser_FullMethodName         = "/api.console.administration.User/RestoreUser"
+	User_GetUser_FullMethodName             = "/api.console.administration.User/GetUser"
+	User_ListUser_FullMethodName            = "/api.console.administration.User/ListUser"
+	User_BindRole_FullMethodName            = "/api.console.administration.User/BindRole"
+	User_UnbindRole_FullMethodName          = "/api.console.administration.User/UnbindRole"
+	User_InviteUser_FullMethodName          = "/api.console.administration.User/InviteUser"
+	User_ResendInvitation_FullMethodName    = "/api.console.administration.User/ResendInvitation"
+	User_ListRegistrations_FullMethodName   = "/api.console.administration.User/ListRegistrations"
+	User_ApproveRegistration_FullMethodName = "/api.console.administration.User/ApproveRegistration"
+	User_RejectRegistration_FullMethodName  = "/api.console.administration.User/RejectRegistration"
+	User_SetAvatar_FullMethodName           = "/api.console.administration.User/SetAvatar"
+	User_ImportUsers_FullMethodName         = "/api.console.administration.User/ImportUsers"
+	User_ExportUsers_FullMethodName         = "/api.console.administration.User/ExportUsers"
 )
 
 // UserClient is the client API for User service.
@@ -34,11 +44,32 @@ const (
 type UserClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserReply, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserReply, error)
+	// 删除用户, 用户可以在保留期内恢复, 超过保留期后被清除
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserReply, error)
+	// 已删除的用户; 需要先于 GetUser 声明, 避免被 /api/console/user/{uid} 匹配
+	ListDeletedUsers(ctx context.Context, in *ListDeletedUsersRequest, opts ...grpc.CallOption) (*ListDeletedUsersReply, error)
+	// 恢复已删除的用户, 恢复后为禁用状态
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserReply, error)
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserReply, error)
 	ListUser(ctx context.Context, in *ListUserRequest, opts ...grpc.CallOption) (*ListUserReply, error)
 	BindRole(ctx context.Context, in *BindRoleRequest, opts ...grpc.CallOption) (*BindRoleReply, error)
 	UnbindRole(ctx context.Context, in *UnbindRoleRequest, opts ...grpc.CallOption) (*UnbindRoleReply, error)
+	// 邀请用户, 创建待激活的用户并发送激活邮件
+	InviteUser(ctx context.Context, in *InviteUserRequest, opts ...grpc.CallOption) (*InviteUserReply, error)
+	// 重新发送邀请, 之前的邀请链接失效
+	ResendInvitation(ctx context.Context, in *ResendInvitationRequest, opts ...grpc.CallOption) (*ResendInvitationReply, error)
+	// 待审批的注册申请
+	ListRegistrations(ctx context.Context, in *ListRegistrationsRequest, opts ...grpc.CallOption) (*ListRegistrationsReply, error)
+	// 通过注册申请
+	ApproveRegistration(ctx context.Context, in *ApproveRegistrationRequest, opts ...grpc.CallOption) (*ApproveRegistrationReply, error)
+	// 拒绝注册申请, 用户被删除
+	RejectRegistration(ctx context.Context, in *RejectRegistrationRequest, opts ...grpc.CallOption) (*RejectRegistrationReply, error)
+	// 设置用户头像, file_id 为已上传的头像文件, 为 0 时清除头像
+	SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...grpc.CallOption) (*SetAvatarReply, error)
+	// 批量导入用户, 逐行校验, 校验通过的用户在同一事务中创建
+	ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...grpc.CallOption) (*ImportUsersReply, error)
+	// 导出用户列表, 以文件分块的方式流式返回; HTTP 入口为 GET /api/console/user/export
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUsersReply], error)
 }
 
 type userClient struct {
@@ -79,6 +110,26 @@ func (c *userClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts
 	return out, nil
 }
 
+func (c *userClient) ListDeletedUsers(ctx context.Context, in *ListDeletedUsersRequest, opts ...grpc.CallOption) (*ListDeletedUsersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedUsersReply)
+	err := c.cc.Invoke(ctx, User_ListDeletedUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreUserReply)
+	err := c.cc.Invoke(ctx, User_RestoreUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserReply)
@@ -119,17 +170,127 @@ func (c *userClient) UnbindRole(ctx context.Context, in *UnbindRoleRequest, opts
 	return out, nil
 }
 
+func (c *userClient) InviteUser(ctx context.Context, in *InviteUserRequest, opts ...grpc.CallOption) (*InviteUserReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteUserReply)
+	err := c.cc.Invoke(ctx, User_InviteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ResendInvitation(ctx context.Context, in *ResendInvitationRequest, opts ...grpc.CallOption) (*ResendInvitationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendInvitationReply)
+	err := c.cc.Invoke(ctx, User_ResendInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ListRegistrations(ctx context.Context, in *ListRegistrationsRequest, opts ...grpc.CallOption) (*ListRegistrationsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRegistrationsReply)
+	err := c.cc.Invoke(ctx, User_ListRegistrations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ApproveRegistration(ctx context.Context, in *ApproveRegistrationRequest, opts ...grpc.CallOption) (*ApproveRegistrationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveRegistrationReply)
+	err := c.cc.Invoke(ctx, User_ApproveRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RejectRegistration(ctx context.Context, in *RejectRegistrationRequest, opts ...grpc.CallOption) (*RejectRegistrationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectRegistrationReply)
+	err := c.cc.Invoke(ctx, User_RejectRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...grpc.CallOption) (*SetAvatarReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAvatarReply)
+	err := c.cc.Invoke(ctx, User_SetAvatar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...grpc.CallOption) (*ImportUsersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportUsersReply)
+	err := c.cc.Invoke(ctx, User_ImportUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUsersReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &User_ServiceDesc.Streams[0], User_ExportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUsersRequest, ExportUsersReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type User_ExportUsersClient = grpc.ServerStreamingClient[ExportUsersReply]
+
 // UserServer is the server API for User service.
 // All implementations must embed UnimplementedUserServer
 // for forward compatibility.
 type UserServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserReply, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserReply, error)
+	// 删除用户, 用户可以在保留期内恢复, 超过保留期后被清除
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserReply, error)
+	// 已删除的用户; 需要先于 GetUser 声明, 避免被 /api/console/user/{uid} 匹配
+	ListDeletedUsers(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersReply, error)
+	// 恢复已删除的用户, 恢复后为禁用状态
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserReply, error)
 	GetUser(context.Context, *GetUserRequest) (*GetUserReply, error)
 	ListUser(context.Context, *ListUserRequest) (*ListUserReply, error)
 	BindRole(context.Context, *BindRoleRequest) (*BindRoleReply, error)
 	UnbindRole(context.Context, *UnbindRoleRequest) (*UnbindRoleReply, error)
+	// 邀请用户, 创建待激活的用户并发送激活邮件
+	InviteUser(context.Context, *InviteUserRequest) (*InviteUserReply, error)
+	// 重新发送邀请, 之前的邀请链接失效
+	ResendInvitation(context.Context, *ResendInvitationRequest) (*ResendInvitationReply, error)
+	// 待审批的注册申请
+	ListRegistrations(context.Context, *ListRegistrationsRequest) (*ListRegistrationsReply, error)
+	// 通过注册申请
+	ApproveRegistration(context.Context, *ApproveRegistrationRequest) (*ApproveRegistrationReply, error)
+	// 拒绝注册申请, 用户被删除
+	RejectRegistration(context.Context, *RejectRegistrationRequest) (*RejectRegistrationReply, error)
+	// 设置用户头像, file_id 为已上传的头像文件, 为 0 时清除头像
+	SetAvatar(context.Context, *SetAvatarRequest) (*SetAvatarReply, error)
+	// 批量导入用户, 逐行校验, 校验通过的用户在同一事务中创建
+	ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersReply, error)
+	// 导出用户列表, 以文件分块的方式流式返回; HTTP 入口为 GET /api/console/user/export
+	ExportUsers(*ExportUsersRequest, grpc.ServerStreamingServer[ExportUsersReply]) error
 	mustEmbedUnimplementedUserServer()
 }
 
@@ -149,6 +310,12 @@ func (UnimplementedUserServer) UpdateUser(context.Context, *UpdateUserRequest) (
 func (UnimplementedUserServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedUserServer) ListDeletedUsers(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedUsers not implemented")
+}
+func (UnimplementedUserServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
 func (UnimplementedUserServer) GetUser(context.Context, *GetUserRequest) (*GetUserReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
 }
@@ -161,6 +328,30 @@ func (UnimplementedUserServer) BindRole(context.Context, *BindRoleRequest) (*Bin
 func (UnimplementedUserServer) UnbindRole(context.Context, *UnbindRoleRequest) (*UnbindRoleReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnbindRole not implemented")
 }
+func (UnimplementedUserServer) InviteUser(context.Context, *InviteUserRequest) (*InviteUserReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteUser not implemented")
+}
+func (UnimplementedUserServer) ResendInvitation(context.Context, *ResendInvitationRequest) (*ResendInvitationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendInvitation not implemented")
+}
+func (UnimplementedUserServer) ListRegistrations(context.Context, *ListRegistrationsRequest) (*ListRegistrationsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRegistrations not implemented")
+}
+func (UnimplementedUserServer) ApproveRegistration(context.Context, *ApproveRegistrationRequest) (*ApproveRegistrationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRegistration not implemented")
+}
+func (UnimplementedUserServer) RejectRegistration(context.Context, *RejectRegistrationRequest) (*RejectRegistrationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectRegistration not implemented")
+}
+func (UnimplementedUserServer) SetAvatar(context.Context, *SetAvatarRequest) (*SetAvatarReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAvatar not implemented")
+}
+func (UnimplementedUserServer) ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedUserServer) ExportUsers(*ExportUsersRequest, grpc.ServerStreamingServer[ExportUsersReply]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
 func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
 func (UnimplementedUserServer) testEmbeddedByValue()              {}
 
@@ -236,6 +427,42 @@ func _User_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_ListDeletedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ListDeletedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_ListDeletedUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ListDeletedUsers(ctx, req.(*ListDeletedUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_RestoreUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _User_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserRequest)
 	if err := dec(in); err != nil {
@@ -308,6 +535,143 @@ func _User_UnbindRole_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_InviteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).InviteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_InviteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).InviteUser(ctx, req.(*InviteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ResendInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ResendInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_ResendInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ResendInvitation(ctx, req.(*ResendInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ListRegistrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ListRegistrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_ListRegistrations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ListRegistrations(ctx, req.(*ListRegistrationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ApproveRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ApproveRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_ApproveRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ApproveRegistration(ctx, req.(*ApproveRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RejectRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RejectRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_RejectRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RejectRegistration(ctx, req.(*RejectRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_SetAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SetAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_SetAvatar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SetAvatar(ctx, req.(*SetAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ImportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ImportUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_ImportUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ImportUsers(ctx, req.(*ImportUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServer).ExportUsers(m, &grpc.GenericServerStream[ExportUsersRequest, ExportUsersReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type User_ExportUsersServer = grpc.ServerStreamingServer[ExportUsersReply]
+
 // User_ServiceDesc is the grpc.ServiceDesc for User service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -327,6 +691,14 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _User_DeleteUser_Handler,
 		},
+		{
+			MethodName: "ListDeletedUsers",
+			Handler:    _User_ListDeletedUsers_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _User_RestoreUser_Handler,
+		},
 		{
 			MethodName: "GetUser",
 			Handler:    _User_GetUser_Handler,
@@ -343,7 +715,41 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnbindRole",
 			Handler:    _User_UnbindRole_Handler,
 		},
+		{
+			MethodName: "InviteUser",
+			Handler:    _User_InviteUser_Handler,
+		},
+		{
+			MethodName: "ResendInvitation",
+			Handler:    _User_ResendInvitation_Handler,
+		},
+		{
+			MethodName: "ListRegistrations",
+			Handler:    _User_ListRegistrations_Handler,
+		},
+		{
+			MethodName: "ApproveRegistration",
+			Handler:    _User_ApproveRegistration_Handler,
+		},
+		{
+			MethodName: "RejectRegistration",
+			Handler:    _User_RejectRegistration_Handler,
+		},
+		{
+			MethodName: "SetAvatar",
+			Handler:    _User_SetAvatar_Handler,
+		},
+		{
+			MethodName: "ImportUsers",
+			Handler:    _User_ImportUsers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _User_ExportUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "console/administration/user.proto",
 }
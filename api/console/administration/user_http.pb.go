@@ -19,20 +19,48 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationUserApproveRegistration = "/api.console.administration.User/ApproveRegistration"
 const OperationUserBindRole = "/api.console.administration.User/BindRole"
 const OperationUserCreateUser = "/api.console.administration.User/CreateUser"
 const OperationUserDeleteUser = "/api.console.administration.User/DeleteUser"
 const OperationUserGetUser = "/api.console.administration.User/GetUser"
+const OperationUserImportUsers = "/api.console.administration.User/ImportUsers"
+const OperationUserInviteUser = "/api.console.administration.User/InviteUser"
+const OperationUserListDeletedUsers = "/api.console.administration.User/ListDeletedUsers"
+const OperationUserListRegistrations = "/api.console.administration.User/ListRegistrations"
 const OperationUserListUser = "/api.console.administration.User/ListUser"
+const OperationUserRejectRegistration = "/api.console.administration.User/RejectRegistration"
+const OperationUserResendInvitation = "/api.console.administration.User/ResendInvitation"
+const OperationUserRestoreUser = "/api.console.administration.User/RestoreUser"
+const OperationUserSetAvatar = "/api.console.administration.User/SetAvatar"
 const OperationUserUnbindRole = "/api.console.administration.User/UnbindRole"
 const OperationUserUpdateUser = "/api.console.administration.User/UpdateUser"
 
 type UserHTTPServer interface {
+	// ApproveRegistration 通过注册申请
+	ApproveRegistration(context.Context, *ApproveRegistrationRequest) (*ApproveRegistrationReply, error)
 	BindRole(context.Context, *BindRoleRequest) (*BindRoleReply, error)
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserReply, error)
+	// DeleteUser 删除用户, 用户可以在保留期内恢复, 超过保留期后被清除
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserReply, error)
 	GetUser(context.Context, *GetUserRequest) (*GetUserReply, error)
+	// ImportUsers 批量导入用户, 逐行校验, 校验通过的用户在同一事务中创建
+	ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersReply, error)
+	// InviteUser 邀请用户, 创建待激活的用户并发送激活邮件
+	InviteUser(context.Context, *InviteUserRequest) (*InviteUserReply, error)
+	// ListDeletedUsers 已删除的用户; 需要先于 GetUser 声明, 避免被 /api/console/user/{uid} 匹配
+	ListDeletedUsers(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersReply, error)
+	// ListRegistrations 待审批的注册申请
+	ListRegistrations(context.Context, *ListRegistrationsRequest) (*ListRegistrationsReply, error)
 	ListUser(context.Context, *ListUserRequest) (*ListUserReply, error)
+	// RejectRegistration 拒绝注册申请, 用户被删除
+	RejectRegistration(context.Context, *RejectRegistrationRequest) (*RejectRegistrationReply, error)
+	// ResendInvitation 重新发送邀请, 之前的邀请链接失效
+	ResendInvitation(context.Context, *ResendInvitationRequest) (*ResendInvitationReply, error)
+	// RestoreUser 恢复已删除的用户, 恢复后为禁用状态
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserReply, error)
+	// SetAvatar 设置用户头像, file_id 为已上传的头像文件, 为 0 时清除头像
+	SetAvatar(context.Context, *SetAvatarRequest) (*SetAvatarReply, error)
 	UnbindRole(context.Context, *UnbindRoleRequest) (*UnbindRoleReply, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserReply, error)
 }
@@ -42,10 +70,19 @@ func RegisterUserHTTPServer(s *http.Server, srv UserHTTPServer) {
 	r.POST("/api/console/user", _User_CreateUser0_HTTP_Handler(srv))
 	r.PUT("/api/console/user/{uid}", _User_UpdateUser0_HTTP_Handler(srv))
 	r.DELETE("/api/console/user/{uid}", _User_DeleteUser0_HTTP_Handler(srv))
+	r.GET("/api/console/user/deleted", _User_ListDeletedUsers0_HTTP_Handler(srv))
+	r.POST("/api/console/user/{uid}/restore", _User_RestoreUser0_HTTP_Handler(srv))
 	r.GET("/api/console/user/{uid}", _User_GetUser0_HTTP_Handler(srv))
 	r.GET("/api/console/user", _User_ListUser0_HTTP_Handler(srv))
 	r.POST("/api/console/user/{uid}/role", _User_BindRole0_HTTP_Handler(srv))
 	r.DELETE("/api/console/user/{uid}/role/{role_id}", _User_UnbindRole0_HTTP_Handler(srv))
+	r.POST("/api/console/user/invite", _User_InviteUser0_HTTP_Handler(srv))
+	r.POST("/api/console/user/{uid}/invite", _User_ResendInvitation0_HTTP_Handler(srv))
+	r.GET("/api/console/registration", _User_ListRegistrations0_HTTP_Handler(srv))
+	r.POST("/api/console/registration/{uid}/approve", _User_ApproveRegistration0_HTTP_Handler(srv))
+	r.POST("/api/console/registration/{uid}/reject", _User_RejectRegistration0_HTTP_Handler(srv))
+	r.PUT("/api/console/user/{uid}/avatar", _User_SetAvatar0_HTTP_Handler(srv))
+	r.POST("/api/console/user/import", _User_ImportUsers0_HTTP_Handler(srv))
 }
 
 func _User_CreateUser0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
@@ -117,6 +154,50 @@ func _User_DeleteUser0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) e
 	}
 }
 
+func _User_ListDeletedUsers0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListDeletedUsersRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserListDeletedUsers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListDeletedUsers(ctx, req.(*ListDeletedUsersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListDeletedUsersReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_RestoreUser0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RestoreUserRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserRestoreUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RestoreUser(ctx, req.(*RestoreUserRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RestoreUserReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _User_GetUser0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in GetUserRequest
@@ -205,12 +286,184 @@ func _User_UnbindRole0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) e
 	}
 }
 
+func _User_InviteUser0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in InviteUserRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserInviteUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.InviteUser(ctx, req.(*InviteUserRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*InviteUserReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_ResendInvitation0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ResendInvitationRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserResendInvitation)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResendInvitation(ctx, req.(*ResendInvitationRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ResendInvitationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_ListRegistrations0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListRegistrationsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserListRegistrations)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListRegistrations(ctx, req.(*ListRegistrationsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListRegistrationsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_ApproveRegistration0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ApproveRegistrationRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserApproveRegistration)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ApproveRegistration(ctx, req.(*ApproveRegistrationRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ApproveRegistrationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_RejectRegistration0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RejectRegistrationRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserRejectRegistration)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RejectRegistration(ctx, req.(*RejectRegistrationRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RejectRegistrationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_SetAvatar0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SetAvatarRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserSetAvatar)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SetAvatar(ctx, req.(*SetAvatarRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SetAvatarReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _User_ImportUsers0_HTTP_Handler(srv UserHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImportUsersRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserImportUsers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ImportUsers(ctx, req.(*ImportUsersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ImportUsersReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type UserHTTPClient interface {
+	ApproveRegistration(ctx context.Context, req *ApproveRegistrationRequest, opts ...http.CallOption) (rsp *ApproveRegistrationReply, err error)
 	BindRole(ctx context.Context, req *BindRoleRequest, opts ...http.CallOption) (rsp *BindRoleReply, err error)
 	CreateUser(ctx context.Context, req *CreateUserRequest, opts ...http.CallOption) (rsp *CreateUserReply, err error)
 	DeleteUser(ctx context.Context, req *DeleteUserRequest, opts ...http.CallOption) (rsp *DeleteUserReply, err error)
 	GetUser(ctx context.Context, req *GetUserRequest, opts ...http.CallOption) (rsp *GetUserReply, err error)
+	ImportUsers(ctx context.Context, req *ImportUsersRequest, opts ...http.CallOption) (rsp *ImportUsersReply, err error)
+	InviteUser(ctx context.Context, req *InviteUserRequest, opts ...http.CallOption) (rsp *InviteUserReply, err error)
+	ListDeletedUsers(ctx context.Context, req *ListDeletedUsersRequest, opts ...http.CallOption) (rsp *ListDeletedUsersReply, err error)
+	ListRegistrations(ctx context.Context, req *ListRegistrationsRequest, opts ...http.CallOption) (rsp *ListRegistrationsReply, err error)
 	ListUser(ctx context.Context, req *ListUserRequest, opts ...http.CallOption) (rsp *ListUserReply, err error)
+	RejectRegistration(ctx context.Context, req *RejectRegistrationRequest, opts ...http.CallOption) (rsp *RejectRegistrationReply, err error)
+	ResendInvitation(ctx context.Context, req *ResendInvitationRequest, opts ...http.CallOption) (rsp *ResendInvitationReply, err error)
+	RestoreUser(ctx context.Context, req *RestoreUserRequest, opts ...http.CallOption) (rsp *RestoreUserReply, err error)
+	SetAvatar(ctx context.Context, req *SetAvatarRequest, opts ...http.CallOption) (rsp *SetAvatarReply, err error)
 	UnbindRole(ctx context.Context, req *UnbindRoleRequest, opts ...http.CallOption) (rsp *UnbindRoleReply, err error)
 	UpdateUser(ctx context.Context, req *UpdateUserRequest, opts ...http.CallOption) (rsp *UpdateUserReply, err error)
 }
@@ -223,6 +476,19 @@ func NewUserHTTPClient(client *http.Client) UserHTTPClient {
 	return &UserHTTPClientImpl{client}
 }
 
+func (c *UserHTTPClientImpl) ApproveRegistration(ctx context.Context, in *ApproveRegistrationRequest, opts ...http.CallOption) (*ApproveRegistrationReply, error) {
+	var out ApproveRegistrationReply
+	pattern := "/api/console/registration/{uid}/approve"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserApproveRegistration))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserHTTPClientImpl) BindRole(ctx context.Context, in *BindRoleRequest, opts ...http.CallOption) (*BindRoleReply, error) {
 	var out BindRoleReply
 	pattern := "/api/console/user/{uid}/role"
@@ -275,6 +541,58 @@ func (c *UserHTTPClientImpl) GetUser(ctx context.Context, in *GetUserRequest, op
 	return &out, nil
 }
 
+func (c *UserHTTPClientImpl) ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...http.CallOption) (*ImportUsersReply, error) {
+	var out ImportUsersReply
+	pattern := "/api/console/user/import"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserImportUsers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserHTTPClientImpl) InviteUser(ctx context.Context, in *InviteUserRequest, opts ...http.CallOption) (*InviteUserReply, error) {
+	var out InviteUserReply
+	pattern := "/api/console/user/invite"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserInviteUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserHTTPClientImpl) ListDeletedUsers(ctx context.Context, in *ListDeletedUsersRequest, opts ...http.CallOption) (*ListDeletedUsersReply, error) {
+	var out ListDeletedUsersReply
+	pattern := "/api/console/user/deleted"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserListDeletedUsers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserHTTPClientImpl) ListRegistrations(ctx context.Context, in *ListRegistrationsRequest, opts ...http.CallOption) (*ListRegistrationsReply, error) {
+	var out ListRegistrationsReply
+	pattern := "/api/console/registration"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserListRegistrations))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserHTTPClientImpl) ListUser(ctx context.Context, in *ListUserRequest, opts ...http.CallOption) (*ListUserReply, error) {
 	var out ListUserReply
 	pattern := "/api/console/user"
@@ -288,6 +606,58 @@ func (c *UserHTTPClientImpl) ListUser(ctx context.Context, in *ListUserRequest,
 	return &out, nil
 }
 
+func (c *UserHTTPClientImpl) RejectRegistration(ctx context.Context, in *RejectRegistrationRequest, opts ...http.CallOption) (*RejectRegistrationReply, error) {
+	var out RejectRegistrationReply
+	pattern := "/api/console/registration/{uid}/reject"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserRejectRegistration))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserHTTPClientImpl) ResendInvitation(ctx context.Context, in *ResendInvitationRequest, opts ...http.CallOption) (*ResendInvitationReply, error) {
+	var out ResendInvitationReply
+	pattern := "/api/console/user/{uid}/invite"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserResendInvitation))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserHTTPClientImpl) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...http.CallOption) (*RestoreUserReply, error) {
+	var out RestoreUserReply
+	pattern := "/api/console/user/{uid}/restore"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserRestoreUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserHTTPClientImpl) SetAvatar(ctx context.Context, in *SetAvatarRequest, opts ...http.CallOption) (*SetAvatarReply, error) {
+	var out SetAvatarReply
+	pattern := "/api/console/user/{uid}/avatar"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserSetAvatar))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserHTTPClientImpl) UnbindRole(ctx context.Context, in *UnbindRoleRequest, opts ...http.CallOption) (*UnbindRoleReply, error) {
 	var out UnbindRoleReply
 	pattern := "/api/console/user/{uid}/role/{role_id}"
@@ -19,16 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Passport_Login_FullMethodName             = "/api.console.passport.Passport/Login"
-	Passport_Logout_FullMethodName            = "/api.console.passport.Passport/Logout"
-	Passport_Register_FullMethodName          = "/api.console.passport.Passport/Register"
-	Passport_SendCaptcha_FullMethodName       = "/api.console.passport.Passport/SendCaptcha"
-	Passport_SendResetPassword_FullMethodName = "/api.console.passport.Passport/SendResetPassword"
-	Passport_ResetPassword_FullMethodName     = "/api.console.passport.Passport/ResetPassword"
-	Passport_UpdateUsername_FullMethodName    = "/api.console.passport.Passport/UpdateUsername"
-	Passport_UpdateProfile_FullMethodName     = "/api.console.passport.Passport/UpdateProfile"
-	Passport_CurrentUser_FullMethodName       = "/api.console.passport.Passport/CurrentUser"
-	Passport_AuthorizeMenu_FullMethodName     = "/api.console.passport.Passport/AuthorizeMenu"
+	Passport_Login_FullMethodName              = "/api.console.passport.Passport/Login"
+	Passport_Logout_FullMethodName             = "/api.console.passport.Passport/Logout"
+	Passport_Register_FullMethodName           = "/api.console.passport.Passport/Register"
+	Passport_VerifyEmail_FullMethodName        = "/api.console.passport.Passport/VerifyEmail"
+	Passport_ResendVerification_FullMethodName = "/api.console.passport.Passport/ResendVerification"
+	Passport_SendCaptcha_FullMethodName        = "/api.console.passport.Passport/SendCaptcha"
+	Passport_SendResetPassword_FullMethodName  = "/api.console.passport.Passport/SendResetPassword"
+	Passport_ResetPassword_FullMethodName      = "/api.console.passport.Passport/ResetPassword"
+	Passport_AcceptInvitation_FullMethodName   = "/api.console.passport.Passport/AcceptInvitation"
+	Passport_UpdateUsername_FullMethodName     = "/api.console.passport.Passport/UpdateUsername"
+	Passport_UpdateProfile_FullMethodName      = "/api.console.passport.Passport/UpdateProfile"
+	Passport_ChangePassword_FullMethodName     = "/api.console.passport.Passport/ChangePassword"
+	Passport_CurrentUser_FullMethodName        = "/api.console.passport.Passport/CurrentUser"
+	Passport_AuthorizeMenu_FullMethodName      = "/api.console.passport.Passport/AuthorizeMenu"
 )
 
 // PassportClient is the client API for Passport service.
@@ -41,16 +45,24 @@ type PassportClient interface {
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutReply, error)
 	// 注册
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterReply, error)
+	// 验证注册邮箱
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailReply, error)
+	// 重新发送注册邮箱的验证邮件
+	ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationReply, error)
 	// 发送验证码
 	SendCaptcha(ctx context.Context, in *SendCaptchaRequest, opts ...grpc.CallOption) (*SendCaptchaReply, error)
 	// 发送重置密码验证码
 	SendResetPassword(ctx context.Context, in *SendResetPasswordCaptchaRequest, opts ...grpc.CallOption) (*SendResetPasswordCaptchaReply, error)
 	// 重置密码
 	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordReply, error)
-	// 更新用户名
+	// 接受邀请, 设置密码并激活用户
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationReply, error)
+	// 更新用户名, 只能修改自己的用户名且有频率限制
 	UpdateUsername(ctx context.Context, in *UpdateUsernameRequest, opts ...grpc.CallOption) (*UpdateUsernameReply, error)
 	// 更新用户信息
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileReply, error)
+	// 修改密码, 需要提供当前密码
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordReply, error)
 	// 获取当前用户信息
 	CurrentUser(ctx context.Context, in *CurrentUserRequest, opts ...grpc.CallOption) (*CurrentUserReply, error)
 	// 获取授权的菜单
@@ -95,6 +107,26 @@ func (c *passportClient) Register(ctx context.Context, in *RegisterRequest, opts
 	return out, nil
 }
 
+func (c *passportClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailReply)
+	err := c.cc.Invoke(ctx, Passport_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passportClient) ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendVerificationReply)
+	err := c.cc.Invoke(ctx, Passport_ResendVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *passportClient) SendCaptcha(ctx context.Context, in *SendCaptchaRequest, opts ...grpc.CallOption) (*SendCaptchaReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SendCaptchaReply)
@@ -125,6 +157,16 @@ func (c *passportClient) ResetPassword(ctx context.Context, in *ResetPasswordReq
 	return out, nil
 }
 
+func (c *passportClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptInvitationReply)
+	err := c.cc.Invoke(ctx, Passport_AcceptInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *passportClient) UpdateUsername(ctx context.Context, in *UpdateUsernameRequest, opts ...grpc.CallOption) (*UpdateUsernameReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateUsernameReply)
@@ -145,6 +187,16 @@ func (c *passportClient) UpdateProfile(ctx context.Context, in *UpdateProfileReq
 	return out, nil
 }
 
+func (c *passportClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordReply)
+	err := c.cc.Invoke(ctx, Passport_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *passportClient) CurrentUser(ctx context.Context, in *CurrentUserRequest, opts ...grpc.CallOption) (*CurrentUserReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CurrentUserReply)
@@ -175,16 +227,24 @@ type PassportServer interface {
 	Logout(context.Context, *LogoutRequest) (*LogoutReply, error)
 	// 注册
 	Register(context.Context, *RegisterRequest) (*RegisterReply, error)
+	// 验证注册邮箱
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error)
+	// 重新发送注册邮箱的验证邮件
+	ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationReply, error)
 	// 发送验证码
 	SendCaptcha(context.Context, *SendCaptchaRequest) (*SendCaptchaReply, error)
 	// 发送重置密码验证码
 	SendResetPassword(context.Context, *SendResetPasswordCaptchaRequest) (*SendResetPasswordCaptchaReply, error)
 	// 重置密码
 	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordReply, error)
-	// 更新用户名
+	// 接受邀请, 设置密码并激活用户
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationReply, error)
+	// 更新用户名, 只能修改自己的用户名且有频率限制
 	UpdateUsername(context.Context, *UpdateUsernameRequest) (*UpdateUsernameReply, error)
 	// 更新用户信息
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileReply, error)
+	// 修改密码, 需要提供当前密码
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordReply, error)
 	// 获取当前用户信息
 	CurrentUser(context.Context, *CurrentUserRequest) (*CurrentUserReply, error)
 	// 获取授权的菜单
@@ -208,6 +268,12 @@ func (UnimplementedPassportServer) Logout(context.Context, *LogoutRequest) (*Log
 func (UnimplementedPassportServer) Register(context.Context, *RegisterRequest) (*RegisterReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedPassportServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedPassportServer) ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerification not implemented")
+}
 func (UnimplementedPassportServer) SendCaptcha(context.Context, *SendCaptchaRequest) (*SendCaptchaReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendCaptcha not implemented")
 }
@@ -217,12 +283,18 @@ func (UnimplementedPassportServer) SendResetPassword(context.Context, *SendReset
 func (UnimplementedPassportServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
 }
+func (UnimplementedPassportServer) AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
 func (UnimplementedPassportServer) UpdateUsername(context.Context, *UpdateUsernameRequest) (*UpdateUsernameReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUsername not implemented")
 }
 func (UnimplementedPassportServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
 }
+func (UnimplementedPassportServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
 func (UnimplementedPassportServer) CurrentUser(context.Context, *CurrentUserRequest) (*CurrentUserReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CurrentUser not implemented")
 }
@@ -304,6 +376,42 @@ func _Passport_Register_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Passport_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassportServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Passport_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassportServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Passport_ResendVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassportServer).ResendVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Passport_ResendVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassportServer).ResendVerification(ctx, req.(*ResendVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Passport_SendCaptcha_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendCaptchaRequest)
 	if err := dec(in); err != nil {
@@ -358,6 +466,24 @@ func _Passport_ResetPassword_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Passport_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassportServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Passport_AcceptInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassportServer).AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Passport_UpdateUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateUsernameRequest)
 	if err := dec(in); err != nil {
@@ -394,6 +520,24 @@ func _Passport_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Passport_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassportServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Passport_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassportServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Passport_CurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CurrentUserRequest)
 	if err := dec(in); err != nil {
@@ -449,6 +593,14 @@ var Passport_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _Passport_Register_Handler,
 		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _Passport_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerification",
+			Handler:    _Passport_ResendVerification_Handler,
+		},
 		{
 			MethodName: "SendCaptcha",
 			Handler:    _Passport_SendCaptcha_Handler,
@@ -461,6 +613,10 @@ var Passport_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetPassword",
 			Handler:    _Passport_ResetPassword_Handler,
 		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _Passport_AcceptInvitation_Handler,
+		},
 		{
 			MethodName: "UpdateUsername",
 			Handler:    _Passport_UpdateUsername_Handler,
@@ -469,6 +625,10 @@ var Passport_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProfile",
 			Handler:    _Passport_UpdateProfile_Handler,
 		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _Passport_ChangePassword_Handler,
+		},
 		{
 			MethodName: "CurrentUser",
 			Handler:    _Passport_CurrentUser_Handler,
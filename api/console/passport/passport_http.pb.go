@@ -19,20 +19,28 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationPassportAcceptInvitation = "/api.console.passport.Passport/AcceptInvitation"
 const OperationPassportAuthorizeMenu = "/api.console.passport.Passport/AuthorizeMenu"
+const OperationPassportChangePassword = "/api.console.passport.Passport/ChangePassword"
 const OperationPassportCurrentUser = "/api.console.passport.Passport/CurrentUser"
 const OperationPassportLogin = "/api.console.passport.Passport/Login"
 const OperationPassportLogout = "/api.console.passport.Passport/Logout"
 const OperationPassportRegister = "/api.console.passport.Passport/Register"
+const OperationPassportResendVerification = "/api.console.passport.Passport/ResendVerification"
 const OperationPassportResetPassword = "/api.console.passport.Passport/ResetPassword"
 const OperationPassportSendCaptcha = "/api.console.passport.Passport/SendCaptcha"
 const OperationPassportSendResetPassword = "/api.console.passport.Passport/SendResetPassword"
 const OperationPassportUpdateProfile = "/api.console.passport.Passport/UpdateProfile"
 const OperationPassportUpdateUsername = "/api.console.passport.Passport/UpdateUsername"
+const OperationPassportVerifyEmail = "/api.console.passport.Passport/VerifyEmail"
 
 type PassportHTTPServer interface {
+	// AcceptInvitation 接受邀请, 设置密码并激活用户
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationReply, error)
 	// AuthorizeMenu 获取授权的菜单
 	AuthorizeMenu(context.Context, *AuthorizeMenuRequest) (*AuthorizeMenuReply, error)
+	// ChangePassword 修改密码, 需要提供当前密码
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordReply, error)
 	// CurrentUser 获取当前用户信息
 	CurrentUser(context.Context, *CurrentUserRequest) (*CurrentUserReply, error)
 	// Login 登录
@@ -41,6 +49,8 @@ type PassportHTTPServer interface {
 	Logout(context.Context, *LogoutRequest) (*LogoutReply, error)
 	// Register 注册
 	Register(context.Context, *RegisterRequest) (*RegisterReply, error)
+	// ResendVerification 重新发送注册邮箱的验证邮件
+	ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationReply, error)
 	// ResetPassword 重置密码
 	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordReply, error)
 	// SendCaptcha 发送验证码
@@ -49,8 +59,10 @@ type PassportHTTPServer interface {
 	SendResetPassword(context.Context, *SendResetPasswordCaptchaRequest) (*SendResetPasswordCaptchaReply, error)
 	// UpdateProfile 更新用户信息
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileReply, error)
-	// UpdateUsername 更新用户名
+	// UpdateUsername 更新用户名, 只能修改自己的用户名且有频率限制
 	UpdateUsername(context.Context, *UpdateUsernameRequest) (*UpdateUsernameReply, error)
+	// VerifyEmail 验证注册邮箱
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error)
 }
 
 func RegisterPassportHTTPServer(s *http.Server, srv PassportHTTPServer) {
@@ -58,11 +70,15 @@ func RegisterPassportHTTPServer(s *http.Server, srv PassportHTTPServer) {
 	r.POST("/api/console/passport/login", _Passport_Login0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/logout", _Passport_Logout0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/register", _Passport_Register0_HTTP_Handler(srv))
+	r.POST("/api/console/passport/verify_email", _Passport_VerifyEmail0_HTTP_Handler(srv))
+	r.POST("/api/console/passport/resend_verification", _Passport_ResendVerification0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/send_captcha", _Passport_SendCaptcha0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/send_reset_password", _Passport_SendResetPassword0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/reset_password", _Passport_ResetPassword0_HTTP_Handler(srv))
+	r.POST("/api/console/passport/accept_invitation", _Passport_AcceptInvitation0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/{id}/username", _Passport_UpdateUsername0_HTTP_Handler(srv))
 	r.POST("/api/console/passport/profile", _Passport_UpdateProfile0_HTTP_Handler(srv))
+	r.POST("/api/console/passport/password", _Passport_ChangePassword0_HTTP_Handler(srv))
 	r.GET("/api/console/passport/current", _Passport_CurrentUser0_HTTP_Handler(srv))
 	r.GET("/api/console/passport/authorize_menu", _Passport_AuthorizeMenu0_HTTP_Handler(srv))
 }
@@ -133,6 +149,50 @@ func _Passport_Register0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Cont
 	}
 }
 
+func _Passport_VerifyEmail0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in VerifyEmailRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPassportVerifyEmail)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.VerifyEmail(ctx, req.(*VerifyEmailRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*VerifyEmailReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Passport_ResendVerification0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ResendVerificationRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPassportResendVerification)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResendVerification(ctx, req.(*ResendVerificationRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ResendVerificationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _Passport_SendCaptcha0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in SendCaptchaRequest
@@ -199,6 +259,28 @@ func _Passport_ResetPassword0_HTTP_Handler(srv PassportHTTPServer) func(ctx http
 	}
 }
 
+func _Passport_AcceptInvitation0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AcceptInvitationRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPassportAcceptInvitation)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AcceptInvitationReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _Passport_UpdateUsername0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in UpdateUsernameRequest
@@ -246,6 +328,28 @@ func _Passport_UpdateProfile0_HTTP_Handler(srv PassportHTTPServer) func(ctx http
 	}
 }
 
+func _Passport_ChangePassword0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ChangePasswordRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPassportChangePassword)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ChangePassword(ctx, req.(*ChangePasswordRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ChangePasswordReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _Passport_CurrentUser0_HTTP_Handler(srv PassportHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in CurrentUserRequest
@@ -285,16 +389,20 @@ func _Passport_AuthorizeMenu0_HTTP_Handler(srv PassportHTTPServer) func(ctx http
 }
 
 type PassportHTTPClient interface {
+	AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest, opts ...http.CallOption) (rsp *AcceptInvitationReply, err error)
 	AuthorizeMenu(ctx context.Context, req *AuthorizeMenuRequest, opts ...http.CallOption) (rsp *AuthorizeMenuReply, err error)
+	ChangePassword(ctx context.Context, req *ChangePasswordRequest, opts ...http.CallOption) (rsp *ChangePasswordReply, err error)
 	CurrentUser(ctx context.Context, req *CurrentUserRequest, opts ...http.CallOption) (rsp *CurrentUserReply, err error)
 	Login(ctx context.Context, req *LoginRequest, opts ...http.CallOption) (rsp *LoginReply, err error)
 	Logout(ctx context.Context, req *LogoutRequest, opts ...http.CallOption) (rsp *LogoutReply, err error)
 	Register(ctx context.Context, req *RegisterRequest, opts ...http.CallOption) (rsp *RegisterReply, err error)
+	ResendVerification(ctx context.Context, req *ResendVerificationRequest, opts ...http.CallOption) (rsp *ResendVerificationReply, err error)
 	ResetPassword(ctx context.Context, req *ResetPasswordRequest, opts ...http.CallOption) (rsp *ResetPasswordReply, err error)
 	SendCaptcha(ctx context.Context, req *SendCaptchaRequest, opts ...http.CallOption) (rsp *SendCaptchaReply, err error)
 	SendResetPassword(ctx context.Context, req *SendResetPasswordCaptchaRequest, opts ...http.CallOption) (rsp *SendResetPasswordCaptchaReply, err error)
 	UpdateProfile(ctx context.Context, req *UpdateProfileRequest, opts ...http.CallOption) (rsp *UpdateProfileReply, err error)
 	UpdateUsername(ctx context.Context, req *UpdateUsernameRequest, opts ...http.CallOption) (rsp *UpdateUsernameReply, err error)
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest, opts ...http.CallOption) (rsp *VerifyEmailReply, err error)
 }
 
 type PassportHTTPClientImpl struct {
@@ -305,6 +413,19 @@ func NewPassportHTTPClient(client *http.Client) PassportHTTPClient {
 	return &PassportHTTPClientImpl{client}
 }
 
+func (c *PassportHTTPClientImpl) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...http.CallOption) (*AcceptInvitationReply, error) {
+	var out AcceptInvitationReply
+	pattern := "/api/console/passport/accept_invitation"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPassportAcceptInvitation))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PassportHTTPClientImpl) AuthorizeMenu(ctx context.Context, in *AuthorizeMenuRequest, opts ...http.CallOption) (*AuthorizeMenuReply, error) {
 	var out AuthorizeMenuReply
 	pattern := "/api/console/passport/authorize_menu"
@@ -318,6 +439,19 @@ func (c *PassportHTTPClientImpl) AuthorizeMenu(ctx context.Context, in *Authoriz
 	return &out, nil
 }
 
+func (c *PassportHTTPClientImpl) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...http.CallOption) (*ChangePasswordReply, error) {
+	var out ChangePasswordReply
+	pattern := "/api/console/passport/password"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPassportChangePassword))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PassportHTTPClientImpl) CurrentUser(ctx context.Context, in *CurrentUserRequest, opts ...http.CallOption) (*CurrentUserReply, error) {
 	var out CurrentUserReply
 	pattern := "/api/console/passport/current"
@@ -370,6 +504,19 @@ func (c *PassportHTTPClientImpl) Register(ctx context.Context, in *RegisterReque
 	return &out, nil
 }
 
+func (c *PassportHTTPClientImpl) ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...http.CallOption) (*ResendVerificationReply, error) {
+	var out ResendVerificationReply
+	pattern := "/api/console/passport/resend_verification"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPassportResendVerification))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PassportHTTPClientImpl) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...http.CallOption) (*ResetPasswordReply, error) {
 	var out ResetPasswordReply
 	pattern := "/api/console/passport/reset_password"
@@ -434,3 +581,16 @@ func (c *PassportHTTPClientImpl) UpdateUsername(ctx context.Context, in *UpdateU
 	}
 	return &out, nil
 }
+
+func (c *PassportHTTPClientImpl) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...http.CallOption) (*VerifyEmailReply, error) {
+	var out VerifyEmailReply
+	pattern := "/api/console/passport/verify_email"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPassportVerifyEmail))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
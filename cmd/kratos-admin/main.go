@@ -55,7 +55,7 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 }
 
-func newApp(logger log.Logger, applicationEventPublisher *event.ApplicationEventPublisher, embedEtcd *server.EmbedEtcdServer, registrar registry.Registrar, gs *grpc.Server, hs *http.Server, hh *health.Server) *kratos.App {
+func newApp(logger log.Logger, applicationEventPublisher *event.ApplicationEventPublisher, embedEtcd *server.EmbedEtcdServer, registrar registry.Registrar, gs *grpc.Server, hs *http.Server, hh *health.Server, bg *server.BackgroundTaskManager, ce *server.CacheEvictor) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -71,6 +71,8 @@ func newApp(logger log.Logger, applicationEventPublisher *event.ApplicationEvent
 			hh,
 			embedEtcd,
 			applicationEventPublisher,
+			bg,
+			ce,
 		),
 	)
 }
@@ -91,28 +93,15 @@ func main() {
 	)
 	log.SetLogger(logger)
 
-	c := config.New(
-		config.WithSource(
-			file.NewSource(flagconf),
-		),
-	)
+	c, bc := loadConfig()
 	defer c.Close()
 
-	if err := c.Load(); err != nil {
-		panic(err)
-	}
-
 	_ = c.Watch("logger.level", func(s string, value config.Value) {
 		lvl := log.ParseLevel(value.Load().(string))
 		log.Infof("log level has changed %v", lvl)
 		//l := log.With(log.NewFilter(logger, log.FilterLevel(lvl)))
 	})
 
-	var bc conf.Bootstrap
-	if err := c.Scan(&bc); err != nil {
-		panic(err)
-	}
-
 	if bc.Tracing.GetEndpoint() != "" {
 		trace.InitTracer(
 			trace.WithServiceName(Name), // service-name registered in jaeger-service
@@ -120,7 +109,7 @@ func main() {
 		)
 	}
 
-	app, cleanup, err := wireApp(&bc, bc.Server, bc.Data, bc.Passport, logger)
+	app, cleanup, err := wireApp(bc, bc.Server, bc.Data, bc.Passport, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -132,6 +121,24 @@ func main() {
 	}
 }
 
+// loadConfig 加载 --conf 指定的配置
+func loadConfig() (config.Config, *conf.Bootstrap) {
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+		),
+	)
+	if err := c.Load(); err != nil {
+		panic(err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		panic(err)
+	}
+	return c, &bc
+}
+
 const fuzzyStr = "***"
 
 var re = regexp.MustCompile(`password:"([^"]+)"`)
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/spf13/cobra"
+
+	"github.com/omalloc/kratos-admin/internal/data"
+)
+
+var (
+	flagMigrateTo    int64
+	flagMigrateSteps int
+	flagMigrateDir   string
+
+	migrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage versioned database migrations",
+	}
+
+	migrateUpCmd = &cobra.Command{
+		Use:   "up",
+		Short: "Apply pending migrations",
+		Run: runMigrator(func(m *data.Migrator) error {
+			done, err := m.Up(flagMigrateTo)
+			for _, item := range done {
+				fmt.Printf("applied  %d_%s\n", item.Version, item.Name)
+			}
+			return err
+		}),
+	}
+
+	migrateDownCmd = &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the most recently applied migrations",
+		Run: runMigrator(func(m *data.Migrator) error {
+			done, err := m.Down(flagMigrateSteps)
+			for _, item := range done {
+				fmt.Printf("reverted %d_%s\n", item.Version, item.Name)
+			}
+			return err
+		}),
+	}
+
+	migrateStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		Run: runMigrator(func(m *data.Migrator) error {
+			status, err := m.Status()
+			if err != nil {
+				return err
+			}
+			for _, item := range status {
+				state := "pending"
+				if item.AppliedAt != nil {
+					state = "applied " + item.AppliedAt.Format(time.DateTime)
+				}
+				if item.Unknown {
+					state += " (unknown to this binary)"
+				}
+				fmt.Printf("%d_%-40s %s\n", item.Version, item.Name, state)
+			}
+			fmt.Printf("expected version: %d\n", data.LatestMigrationVersion())
+			return nil
+		}),
+	}
+
+	migrateCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a new migration file",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path, err := data.CreateMigration(flagMigrateDir, args[0], time.Now())
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			fmt.Println("created", path)
+			os.Exit(0)
+		},
+	}
+)
+
+func init() {
+	migrateUpCmd.Flags().Int64Var(&flagMigrateTo, "to", 0, "migrate up to this version (inclusive), 0 for latest")
+	migrateDownCmd.Flags().IntVar(&flagMigrateSteps, "steps", 1, "number of migrations to roll back")
+	migrateCreateCmd.Flags().StringVar(&flagMigrateDir, "dir", "internal/data", "directory of migration files")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateCreateCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+// runMigrator 按配置连接数据库后执行 fn, 完成后退出进程
+func runMigrator(fn func(m *data.Migrator) error) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		_, bc := loadConfig()
+
+		logger := log.NewFilter(log.DefaultLogger, log.FilterLevel(log.LevelWarn))
+		db, err := data.OpenDatabase(bc.Data, logger)
+		if err == nil {
+			var m *data.Migrator
+			if m, err = data.NewMigrator(db, log.DefaultLogger); err == nil {
+				err = fn(m)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/omalloc/contrib/kratos/health"
-	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/kratos/registry"
 	"github.com/omalloc/kratos-admin/internal/biz"
 	"github.com/omalloc/kratos-admin/internal/conf"
@@ -55,29 +54,77 @@ func wireApp(bootstrap *conf.Bootstrap, confServer *conf.Server, confData *conf.
 		cleanup()
 		return nil, nil, err
 	}
-	transaction := orm.NewTransactionManager(dataData)
+	transaction := data.NewTransactionManager(dataData)
 	userRepo := data.NewUserRepo(transaction)
 	roleRepo := data.NewRoleRepo(transaction)
-	userUsecase := biz.NewUserUsecase(userRepo, roleRepo, transaction, logger)
-	userService := service.NewUserService(userUsecase, logger)
-	roleUsecase := biz.NewRoleUsecase(roleRepo, transaction, logger)
-	roleService := service.NewRoleService(roleUsecase)
+	roleConstraintRepo := data.NewRoleConstraintRepo(transaction)
+	fileRepo := data.NewFileRepo(transaction)
+	passwordPolicy := server.NewPasswordPolicy(passport)
+	passwordHistoryRepo := data.NewPasswordHistoryRepo(transaction)
+	passwordManager := biz.NewPasswordManager(passwordPolicy, passwordHistoryRepo)
+	cache, cleanup4, err := data.NewCache(confData, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	menuRepo := data.NewMenuRepo(transaction, logger)
+	authzCache := biz.NewAuthzCache(cache, applicationEventPublisher, userRepo, roleRepo, menuRepo, logger)
+	userUsecase := biz.NewUserUsecase(userRepo, roleRepo, roleConstraintRepo, fileRepo, passwordManager, authzCache, transaction, logger)
+	invitationRepo := data.NewInvitationRepo(transaction)
+	invitationUsecase := biz.NewInvitationUsecase(invitationRepo, userRepo, roleRepo, roleConstraintRepo, passwordManager, transaction, logger)
+	blobStore, err := data.NewBlobStore(confData, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	fileUsecase := biz.NewFileUsecase(fileRepo, userRepo, blobStore, transaction, logger)
+	registrationPolicy := server.NewRegistrationPolicy(passport)
+	emailVerificationRepo := data.NewEmailVerificationRepo(transaction)
+	registrationUsecase := biz.NewRegistrationUsecase(registrationPolicy, emailVerificationRepo, userRepo, roleRepo, roleConstraintRepo, passwordManager, transaction, logger)
+	mailer := server.NewMailer(bootstrap, logger)
+	userService := service.NewUserService(userUsecase, invitationUsecase, fileUsecase, registrationUsecase, applicationEventPublisher, passport, mailer, logger)
 	permissionRepo := data.NewPermissionRepo(transaction)
+	roleUsecase := biz.NewRoleUsecase(roleRepo, permissionRepo, roleConstraintRepo, authzCache, transaction, logger)
+	roleService := service.NewRoleService(roleUsecase)
 	permissionUsecase := biz.NewPermissionUsecase(transaction, permissionRepo)
 	permissionService := service.NewPermissionService(permissionUsecase)
-	menuRepo := data.NewMenuRepo(transaction, logger)
-	menuUsecase := biz.NewMenuUsecase(menuRepo, logger)
-	passportService := service.NewPassportService(bootstrap, applicationEventPublisher, userUsecase, menuUsecase, client)
+	menuUsecase := biz.NewMenuUsecase(menuRepo, permissionRepo, authzCache, transaction, logger)
+	tenantRepo := data.NewTenantRepo(transaction)
+	tenantUsecase := biz.NewTenantUsecase(tenantRepo, transaction, logger)
+	passportService := service.NewPassportService(bootstrap, applicationEventPublisher, userUsecase, menuUsecase, tenantUsecase, invitationUsecase, registrationUsecase, client, mailer)
 	menuService := service.NewMenuService(menuUsecase)
+	accessRequestRepo := data.NewAccessRequestRepo(transaction)
+	accessRequestUsecase := biz.NewAccessRequestUsecase(accessRequestRepo, userRepo, roleRepo, roleConstraintRepo, authzCache, transaction, logger)
+	accessRequestService := service.NewAccessRequestService(accessRequestUsecase, applicationEventPublisher, logger)
+	departmentRepo := data.NewDepartmentRepo(transaction)
+	departmentUsecase := biz.NewDepartmentUsecase(departmentRepo, userRepo, transaction, logger)
+	departmentService := service.NewDepartmentService(departmentUsecase)
+	tenantService := service.NewTenantService(tenantUsecase)
 	crontabRepo := data.NewCrontabRepo(transaction)
 	crontabUsecase := biz.NewCrontabUsecase(crontabRepo, transaction, logger)
 	crontabService := service.NewCrontabService(crontabUsecase, logger)
-	grpcServer := server.NewGRPCServer(confServer, passport, logger, consoleService, userService, roleService, permissionService, passportService, menuService, crontabService)
-	httpServer := server.NewHTTPServer(confServer, passport, logger, userService, roleService, permissionService, passportService, menuService, crontabService)
+	fileService := service.NewFileService(fileUsecase, logger)
+	grpcServer := server.NewGRPCServer(confServer, passport, logger, consoleService, userService, roleService, permissionService, passportService, menuService, accessRequestService, departmentService, tenantService, crontabService, fileService)
+	httpServer := server.NewHTTPServer(confServer, passport, logger, userService, roleService, permissionService, passportService, menuService, accessRequestService, departmentService, tenantService, crontabService, fileService)
 	v := server.NewChecker(dataData, client)
 	healthServer := health.NewServer(v, logger, httpServer)
-	app := newApp(logger, applicationEventPublisher, embedEtcdServer, registrar, grpcServer, httpServer, healthServer)
+	backgroundTaskManager, err := server.NewBackgroundTaskManager(logger, userService)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	cacheEvictor := server.NewCacheEvictor(applicationEventPublisher, authzCache, logger)
+	app := newApp(logger, applicationEventPublisher, embedEtcdServer, registrar, grpcServer, httpServer, healthServer, backgroundTaskManager, cacheEvictor)
 	return app, func() {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
@@ -10,4 +10,12 @@ var ProviderSet = wire.NewSet(
 	NewPermissionUsecase,
 	NewMenuUsecase,
 	NewCrontabUsecase,
+	NewAccessRequestUsecase,
+	NewDepartmentUsecase,
+	NewTenantUsecase,
+	NewInvitationUsecase,
+	NewPasswordManager,
+	NewFileUsecase,
+	NewRegistrationUsecase,
+	NewAuthzCache,
 )
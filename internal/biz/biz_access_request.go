@@ -0,0 +1,312 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+)
+
+const (
+	// AccessRequestPermission 审批人需要持有的权限名
+	AccessRequestPermission = "access_request"
+	// AccessRequestApproveAction 审批动作
+	AccessRequestApproveAction = "APPROVE"
+	// MaxAccessRequestDuration 单次申请的最长时效
+	MaxAccessRequestDuration = 7 * 24 * time.Hour
+)
+
+// 申请状态, 与 pb.AccessRequestStatus 保持一致
+const (
+	AccessRequestPending int64 = iota
+	AccessRequestApproved
+	AccessRequestDenied
+	AccessRequestCancelled
+)
+
+var (
+	ErrAccessRequestNotFound        = errors.New(404, "ACCESS_REQUEST_NOT_FOUND", "申请不存在")
+	ErrAccessRequestNotPending      = errors.New(400, "ACCESS_REQUEST_NOT_PENDING", "申请已处理")
+	ErrAccessRequestDuplicate       = errors.New(400, "ACCESS_REQUEST_DUPLICATE", "该角色已有待审批的申请")
+	ErrAccessRequestInvalidDuration = errors.New(400, "ACCESS_REQUEST_INVALID_DURATION", "申请时长无效")
+	ErrAccessRequestReasonEmpty     = errors.New(400, "ACCESS_REQUEST_REASON_EMPTY", "申请理由不能为空")
+	ErrAccessRequestForbidden       = errors.New(403, "ACCESS_REQUEST_FORBIDDEN", "没有审批权限")
+	ErrAccessRequestSelfReview      = errors.New(403, "ACCESS_REQUEST_SELF_REVIEW", "不能审批自己的申请")
+	ErrAccessRequestRoleHeld        = errors.New(400, "ACCESS_REQUEST_ROLE_HELD", "已拥有该角色")
+)
+
+// AccessRequest 临时提权申请
+type AccessRequest struct {
+	ID            int64      `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID           int64      `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID      int64      `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	UserID        int64      `json:"user_id" gorm:"column:user_id;type:BIGINT;index;comment:申请人"`
+	RoleID        int64      `json:"role_id" gorm:"column:role_id;type:BIGINT;comment:申请的角色"`
+	Duration      int64      `json:"duration" gorm:"column:duration;type:BIGINT;comment:申请时长(秒)"`
+	Reason        string     `json:"reason" gorm:"column:reason;type:varchar(500);comment:申请理由"`
+	Status        int64      `json:"status" gorm:"column:status;type:int;index;comment:状态"` // 0: 待审批, 1: 已通过, 2: 已拒绝, 3: 已撤销
+	ReviewerID    int64      `json:"reviewer_id" gorm:"column:reviewer_id;type:BIGINT;comment:审批人"`
+	ReviewComment string     `json:"review_comment" gorm:"column:review_comment;type:varchar(500);comment:审批意见"`
+	ReviewedAt    *time.Time `json:"reviewed_at" gorm:"column:reviewed_at;comment:审批时间"`
+	ExpiresAt     *time.Time `json:"expires_at" gorm:"column:expires_at;comment:授权到期时间"`
+
+	orm.DBModel
+}
+
+func (AccessRequest) TableName() string {
+	return "access_requests"
+}
+
+type AccessRequestRepo interface {
+	Create(ctx context.Context, request *AccessRequest) error
+	SelectByUID(ctx context.Context, uid int64) (*AccessRequest, error)
+	SelectPending(ctx context.Context, pagination *protobuf.Pagination) ([]*AccessRequest, error)
+	ExistPending(ctx context.Context, userID int64, roleID int64) bool
+	// UpdateStatus 仅当申请仍处于待审批状态时更新, 返回是否更新成功
+	UpdateStatus(ctx context.Context, request *AccessRequest) (bool, error)
+}
+
+type AccessRequestUsecase struct {
+	log               *log.Helper
+	txm               orm.Transaction
+	accessRequestRepo AccessRequestRepo
+	userRepo          UserRepo
+	roleRepo          RoleRepo
+	constraintRepo    RoleConstraintRepo
+	authz             *AuthzCache
+}
+
+func NewAccessRequestUsecase(repo AccessRequestRepo, userRepo UserRepo, roleRepo RoleRepo, constraintRepo RoleConstraintRepo, authz *AuthzCache, txm orm.Transaction, logger log.Logger) *AccessRequestUsecase {
+	return &AccessRequestUsecase{
+		log:               log.NewHelper(logger),
+		txm:               txm,
+		accessRequestRepo: repo,
+		userRepo:          userRepo,
+		roleRepo:          roleRepo,
+		constraintRepo:    constraintRepo,
+		authz:             authz,
+	}
+}
+
+// CreateAccessRequest 发起申请
+func (uc *AccessRequestUsecase) CreateAccessRequest(ctx context.Context, request *AccessRequest) error {
+	if request.Duration <= 0 || time.Duration(request.Duration)*time.Second > MaxAccessRequestDuration {
+		return ErrAccessRequestInvalidDuration
+	}
+	if request.Reason == "" {
+		return ErrAccessRequestReasonEmpty
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		roles, err := uc.roleRepo.SelectByIDs(ctx, []int64{request.RoleID})
+		if err != nil {
+			return err
+		}
+		if len(roles) <= 0 {
+			return ErrRoleNotFound
+		}
+		if roles[0].IsTemplate {
+			return ErrRoleIsTemplate
+		}
+		if err := uc.checkGrantable(ctx, request.UserID, request.RoleID); err != nil {
+			return err
+		}
+
+		if uc.accessRequestRepo.ExistPending(ctx, request.UserID, request.RoleID) {
+			return ErrAccessRequestDuplicate
+		}
+
+		request.Status = AccessRequestPending
+		return uc.accessRequestRepo.Create(ctx, request)
+	})
+}
+
+// ListPendingAccessRequest 待审批的申请列表, 仅审批人可以查看
+func (uc *AccessRequestUsecase) ListPendingAccessRequest(ctx context.Context, reviewerID int64, pagination *protobuf.Pagination) ([]*AccessRequest, error) {
+	ok, err := uc.canApprove(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrAccessRequestForbidden
+	}
+	return uc.accessRequestRepo.SelectPending(ctx, pagination)
+}
+
+// ApproveAccessRequest 审批通过, 为申请人绑定一个有时效的角色
+func (uc *AccessRequestUsecase) ApproveAccessRequest(ctx context.Context, uid int64, reviewerID int64, comment string) (*AccessRequest, error) {
+	var request *AccessRequest
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if request, err = uc.review(ctx, uid, reviewerID, comment, AccessRequestApproved); err != nil {
+			return err
+		}
+
+		roles, err := uc.roleRepo.SelectByIDs(ctx, []int64{request.RoleID})
+		if err != nil {
+			return err
+		}
+		if len(roles) <= 0 {
+			return ErrRoleNotFound
+		}
+		if roles[0].IsTemplate {
+			return ErrRoleIsTemplate
+		}
+		// 已持有的授权 (例如永久授权) 不应被临时授权覆盖, 且授权后不能违反角色互斥约束
+		if err := uc.checkGrantable(ctx, request.UserID, request.RoleID); err != nil {
+			return err
+		}
+
+		return uc.userRepo.BindRole(ctx, &UserRole{
+			UserID:    request.UserID,
+			RoleID:    request.RoleID,
+			ExpiresAt: request.ExpiresAt,
+			GrantedBy: reviewerID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.authz.userRolesChanged(ctx, request.UserID)
+	return request, nil
+}
+
+// DenyAccessRequest 审批拒绝
+func (uc *AccessRequestUsecase) DenyAccessRequest(ctx context.Context, uid int64, reviewerID int64, comment string) (*AccessRequest, error) {
+	var request *AccessRequest
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		request, err = uc.review(ctx, uid, reviewerID, comment, AccessRequestDenied)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// CancelAccessRequest 申请人撤销自己的申请
+func (uc *AccessRequestUsecase) CancelAccessRequest(ctx context.Context, uid int64, userID int64) (*AccessRequest, error) {
+	var request *AccessRequest
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if request, err = uc.selectPending(ctx, uid); err != nil {
+			return err
+		}
+		if request.UserID != userID {
+			return ErrAccessRequestNotFound
+		}
+
+		request.Status = AccessRequestCancelled
+		return uc.updateStatus(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// review 校验审批人权限并更新申请状态
+func (uc *AccessRequestUsecase) review(ctx context.Context, uid int64, reviewerID int64, comment string, status int64) (*AccessRequest, error) {
+	request, err := uc.selectPending(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if request.UserID == reviewerID {
+		return nil, ErrAccessRequestSelfReview
+	}
+
+	ok, err := uc.canApprove(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrAccessRequestForbidden
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.ReviewerID = reviewerID
+	request.ReviewComment = comment
+	request.ReviewedAt = &now
+	if status == AccessRequestApproved {
+		request.ExpiresAt = lo.ToPtr(now.Add(time.Duration(request.Duration) * time.Second))
+	}
+
+	if err := uc.updateStatus(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (uc *AccessRequestUsecase) selectPending(ctx context.Context, uid int64) (*AccessRequest, error) {
+	request, err := uc.accessRequestRepo.SelectByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrAccessRequestNotFound
+	}
+	if request.Status != AccessRequestPending {
+		return nil, ErrAccessRequestNotPending
+	}
+	return request, nil
+}
+
+func (uc *AccessRequestUsecase) updateStatus(ctx context.Context, request *AccessRequest) error {
+	ok, err := uc.accessRequestRepo.UpdateStatus(ctx, request)
+	if err != nil {
+		return err
+	}
+	// 并发审批时仅第一个生效
+	if !ok {
+		return ErrAccessRequestNotPending
+	}
+	return nil
+}
+
+// checkGrantable 检查申请人是否已持有该角色, 以及临时授权后是否违反角色互斥约束
+func (uc *AccessRequestUsecase) checkGrantable(ctx context.Context, userID int64, roleID int64) error {
+	roles, err := uc.roleRepo.SelectByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if lo.SomeBy(roles, func(item *Role) bool { return item.UID == roleID }) {
+		return ErrAccessRequestRoleHeld
+	}
+
+	roleIDs := append(lo.Map(roles, func(item *Role, _ int) int64 { return item.UID }), roleID)
+	return checkRoleConflict(ctx, uc.constraintRepo, roleIDs)
+}
+
+// canApprove 审批人需要通过有效角色持有 access_request 权限的 APPROVE 动作
+func (uc *AccessRequestUsecase) canApprove(ctx context.Context, userID int64) (bool, error) {
+	roles, err := uc.roleRepo.SelectByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if len(roles) <= 0 {
+		return false, nil
+	}
+
+	rolePermissions, err := uc.roleRepo.SelectRolePermission(ctx, lo.Map(roles, func(item *Role, _ int) int64 { return item.UID }))
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range rolePermissions {
+		for _, permission := range role.Permissions {
+			if permission.Name != AccessRequestPermission {
+				continue
+			}
+			if lo.SomeBy(permission.Actions, func(item *Action) bool { return item.Key == AccessRequestApproveAction }) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
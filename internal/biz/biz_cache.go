@@ -0,0 +1,216 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/samber/lo"
+
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+// Cache 缓存, 值以 JSON 存储, 每次读取得到的都是副本
+type Cache interface {
+	// Get 读取 key 并解码到 v, key 不存在时返回 false
+	Get(ctx context.Context, key string, v any) (bool, error)
+	Set(ctx context.Context, key string, v any, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// 权限相关数据变更时发布的事件, 载荷为变更的 uid 列表 (JSON), 订阅方据此删除缓存
+const (
+	EventUserRolesChanged = "authz.user.roles.changed"
+	EventRolesChanged     = "authz.roles.changed"
+	EventMenusChanged     = "authz.menus.changed"
+)
+
+const (
+	// userRolesCacheTTL 用户角色绑定的缓存时长, 不会超过最早到期的临时授权
+	userRolesCacheTTL       = 5 * time.Minute
+	rolePermissionsCacheTTL = 10 * time.Minute
+	menusCacheTTL           = 10 * time.Minute
+
+	menusCacheKey = "authz:menus"
+)
+
+type primaryKey struct{}
+
+// WithPrimary 标记 ctx 内的查询读取主库; 回填缓存时使用, 避免把从库上尚未同步的旧数据写入缓存
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// ReadPrimary ctx 中的查询是否要求读取主库
+func ReadPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+func userRolesCacheKey(uid int64) string {
+	return fmt.Sprintf("authz:user:%d:roles", uid)
+}
+
+func rolePermissionsCacheKey(roleID int64) string {
+	return fmt.Sprintf("authz:role:%d:permissions", roleID)
+}
+
+// AuthzCache 用户角色绑定, 角色权限及菜单列表的读缓存, 用于每次请求都要解析的 CurrentUser 及授权菜单.
+//
+// 变更方在事务提交成功后发布 Event*Changed 事件, 由 server.CacheEvictor 订阅并调用 Evict 删除缓存;
+// 缓存未命中时从主库回填, 缓存读写失败时直接读取数据库.
+type AuthzCache struct {
+	cache     Cache
+	publisher *event.ApplicationEventPublisher
+	userRepo  UserRepo
+	roleRepo  RoleRepo
+	menuRepo  MenuRepo
+	log       *log.Helper
+}
+
+func NewAuthzCache(cache Cache, publisher *event.ApplicationEventPublisher, userRepo UserRepo, roleRepo RoleRepo, menuRepo MenuRepo, logger log.Logger) *AuthzCache {
+	return &AuthzCache{
+		cache:     cache,
+		publisher: publisher,
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		menuRepo:  menuRepo,
+		log:       log.NewHelper(logger),
+	}
+}
+
+// UserRoleIDs 用户有效 (未过期) 的角色
+func (c *AuthzCache) UserRoleIDs(ctx context.Context, uid int64) ([]int64, error) {
+	var roleIDs []int64
+	key := userRolesCacheKey(uid)
+	if c.get(ctx, key, &roleIDs) {
+		return roleIDs, nil
+	}
+
+	bindings, err := c.userRepo.SelectRoleBindings(WithPrimary(ctx), uid)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	ttl := userRolesCacheTTL
+	roleIDs = make([]int64, 0, len(bindings))
+	for _, binding := range bindings {
+		roleIDs = append(roleIDs, binding.RoleID)
+		if binding.ExpiresAt != nil {
+			ttl = min(ttl, binding.ExpiresAt.Sub(now))
+		}
+	}
+	if ttl > 0 {
+		c.set(ctx, key, roleIDs, ttl)
+	}
+	return roleIDs, nil
+}
+
+// RolePermissions 角色及其绑定的权限, 按 roleIDs 的顺序返回, 不存在的角色被忽略
+func (c *AuthzCache) RolePermissions(ctx context.Context, roleIDs []int64) ([]*RoleJoinPermission, error) {
+	found := make(map[int64]*RoleJoinPermission, len(roleIDs))
+	var missing []int64
+	for _, roleID := range lo.Uniq(roleIDs) {
+		var role RoleJoinPermission
+		if c.get(ctx, rolePermissionsCacheKey(roleID), &role) {
+			found[roleID] = &role
+			continue
+		}
+		missing = append(missing, roleID)
+	}
+
+	if len(missing) > 0 {
+		roles, err := c.roleRepo.SelectRolePermission(WithPrimary(ctx), missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			c.set(ctx, rolePermissionsCacheKey(role.UID), role, rolePermissionsCacheTTL)
+			found[role.UID] = role
+		}
+	}
+
+	ret := make([]*RoleJoinPermission, 0, len(found))
+	for _, roleID := range lo.Uniq(roleIDs) {
+		if role, ok := found[roleID]; ok {
+			ret = append(ret, role)
+		}
+	}
+	return ret, nil
+}
+
+// Menus 当前租户可见的全部菜单.
+// 缓存的是全部租户的菜单, 读取后按租户过滤 (与 data 层的租户限定一致), 菜单变更时只需删除一个 key
+func (c *AuthzCache) Menus(ctx context.Context) ([]*Menu, error) {
+	var menus []*Menu
+	if !c.get(ctx, menusCacheKey, &menus) {
+		var err error
+		if menus, err = c.menuRepo.SelectAll(WithPrimary(tenant.WithoutTenant(ctx))); err != nil {
+			return nil, err
+		}
+		c.set(ctx, menusCacheKey, menus, menusCacheTTL)
+	}
+
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return menus, nil
+	}
+	return lo.Filter(menus, func(item *Menu, _ int) bool {
+		return item.TenantID == tenant.PlatformID || item.TenantID == tenantID
+	}), nil
+}
+
+// Evict 删除 topic 事件对应的缓存
+func (c *AuthzCache) Evict(ctx context.Context, topic string, ids []int64) error {
+	var keys []string
+	switch topic {
+	case EventUserRolesChanged:
+		keys = lo.Map(ids, func(item int64, _ int) string { return userRolesCacheKey(item) })
+	case EventRolesChanged:
+		keys = lo.Map(ids, func(item int64, _ int) string { return rolePermissionsCacheKey(item) })
+	case EventMenusChanged:
+		keys = []string{menusCacheKey}
+	default:
+		return fmt.Errorf("unknown cache event %s", topic)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.cache.Delete(ctx, keys...)
+}
+
+func (c *AuthzCache) userRolesChanged(ctx context.Context, uids ...int64) {
+	c.publish(ctx, EventUserRolesChanged, lo.Uniq(uids))
+}
+
+func (c *AuthzCache) rolesChanged(ctx context.Context, roleIDs ...int64) {
+	c.publish(ctx, EventRolesChanged, lo.Uniq(roleIDs))
+}
+
+func (c *AuthzCache) menusChanged(ctx context.Context) {
+	c.publish(ctx, EventMenusChanged, nil)
+}
+
+func (c *AuthzCache) publish(ctx context.Context, topic string, ids []int64) {
+	if ids == nil {
+		ids = []int64{}
+	}
+	c.publisher.Publish(ctx, topic, event.NewMessage(event.NewUUID(), event.Marshal(ids)))
+}
+
+func (c *AuthzCache) get(ctx context.Context, key string, v any) bool {
+	ok, err := c.cache.Get(ctx, key, v)
+	if err != nil {
+		c.log.Warnf("cache get %s failed: %v", key, err)
+		return false
+	}
+	return ok
+}
+
+func (c *AuthzCache) set(ctx context.Context, key string, v any, ttl time.Duration) {
+	if err := c.cache.Set(ctx, key, v, ttl); err != nil {
+		c.log.Warnf("cache set %s failed: %v", key, err)
+	}
+}
@@ -13,12 +13,13 @@ import (
 // Crontab 定时任务模型
 type Crontab struct {
 	ID        int64      `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
-	UID       int64      `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex:idx_uid_uk"`
+	UID       int64      `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID  int64      `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
 	Name      string     `json:"name" gorm:"column:name;type:varchar(255);comment:任务名称;not null"`
 	Expr      string     `json:"expr" gorm:"column:expr;type:varchar(255);comment:Cron表达式;not null"`
 	Action    string     `json:"action" gorm:"column:action;type:text;comment:任务动作;not null"`
 	Describe  string     `json:"describe" gorm:"column:describe;type:varchar(500);comment:任务描述"`
-	LastRunAt *time.Time `json:"last_run_at" gorm:"column:last_run_at;type:datetime;comment:上次执行时间"`
+	LastRunAt *time.Time `json:"last_run_at" gorm:"column:last_run_at;comment:上次执行时间"`
 
 	orm.DBModel
 }
@@ -0,0 +1,278 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrDepartmentNotFound        = errors.New(404, "DEPARTMENT_NOT_FOUND", "部门不存在")
+	ErrDepartmentNameEmpty       = errors.New(400, "DEPARTMENT_NAME_EMPTY", "部门名称不能为空")
+	ErrDepartmentNotEmpty        = errors.New(400, "DEPARTMENT_NOT_EMPTY", "部门下存在子部门或成员")
+	ErrDepartmentMoveCycle       = errors.New(400, "DEPARTMENT_MOVE_CYCLE", "不能移动到自身或子部门下")
+	ErrDepartmentPrimaryRequired = errors.New(400, "DEPARTMENT_PRIMARY_REQUIRED", "需要先设置主部门")
+)
+
+// Department 部门, 使用物化路径保存层级关系
+type Department struct {
+	ID       int64  `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID int64  `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	PID      int64  `json:"pid" gorm:"column:pid;type:BIGINT;index;comment:上级部门"` // zero is root node.
+	Name     string `json:"name" gorm:"column:name;type:varchar(64);comment:部门名称"`
+	Describe string `json:"describe" gorm:"column:describe;type:varchar(255);comment:描述"`
+	SortBy   int64  `json:"sort_by" gorm:"column:sort_by;type:int;comment:排序"`
+	Path     string `json:"path" gorm:"column:path;type:varchar(512);index;comment:物化路径"` // /root_uid/.../self_uid/
+
+	orm.DBModel
+}
+
+func (Department) TableName() string {
+	return "departments"
+}
+
+// UserDepartment 用户所属部门, 每个用户最多一个主部门
+type UserDepartment struct {
+	ID           int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UserID       int64     `json:"user_id" gorm:"column:user_id;type:BIGINT;uniqueIndex:idx_unique_user_department;comment:用户ID"`
+	DepartmentID int64     `json:"department_id" gorm:"column:department_id;type:BIGINT;uniqueIndex:idx_unique_user_department;index;comment:部门ID"`
+	IsPrimary    bool      `json:"is_primary" gorm:"column:is_primary;type:boolean;comment:是否主部门"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;comment:创建时间"`
+}
+
+func (UserDepartment) TableName() string {
+	return "users_bind_department"
+}
+
+type DepartmentRepo interface {
+	Create(ctx context.Context, department *Department) error
+	Update(ctx context.Context, uid int64, department *Department) error
+	Delete(ctx context.Context, uid int64) error
+	SelectByUID(ctx context.Context, uid int64) (*Department, error)
+	SelectByUIDs(ctx context.Context, uids []int64) ([]*Department, error)
+	SelectAll(ctx context.Context) ([]*Department, error)
+	// SelectSubtree 查询 path 前缀下的全部部门 (包含自身)
+	SelectSubtree(ctx context.Context, path string) ([]*Department, error)
+	UpdatePath(ctx context.Context, uid int64, pid int64, path string) error
+	CountChildren(ctx context.Context, uid int64) (int64, error)
+	CountMembers(ctx context.Context, uid int64) (int64, error)
+
+	SelectUserDepartments(ctx context.Context, userID int64) ([]*UserDepartment, error)
+	SetUserDepartments(ctx context.Context, userID int64, bindings []*UserDepartment) error
+}
+
+type DepartmentUsecase struct {
+	log            *log.Helper
+	txm            orm.Transaction
+	departmentRepo DepartmentRepo
+	userRepo       UserRepo
+}
+
+func NewDepartmentUsecase(repo DepartmentRepo, userRepo UserRepo, txm orm.Transaction, logger log.Logger) *DepartmentUsecase {
+	return &DepartmentUsecase{
+		log:            log.NewHelper(logger),
+		txm:            txm,
+		departmentRepo: repo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateDepartment 创建部门
+func (uc *DepartmentUsecase) CreateDepartment(ctx context.Context, department *Department) error {
+	if department.Name == "" {
+		return ErrDepartmentNameEmpty
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		parentPath := "/"
+		if department.PID > 0 {
+			parent, err := uc.selectByUID(ctx, department.PID)
+			if err != nil {
+				return err
+			}
+			parentPath = parent.Path
+		}
+
+		department.Path = departmentPath(parentPath, department.UID)
+		return uc.departmentRepo.Create(ctx, department)
+	})
+}
+
+// UpdateDepartment 更新部门信息, 层级关系通过 MoveDepartment 修改
+func (uc *DepartmentUsecase) UpdateDepartment(ctx context.Context, department *Department) error {
+	if department.Name == "" {
+		return ErrDepartmentNameEmpty
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.selectByUID(ctx, department.UID); err != nil {
+			return err
+		}
+		return uc.departmentRepo.Update(ctx, department.UID, department)
+	})
+}
+
+// DeleteDepartment 删除部门, 仅允许删除没有子部门和成员的部门
+func (uc *DepartmentUsecase) DeleteDepartment(ctx context.Context, uid int64) error {
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.selectByUID(ctx, uid); err != nil {
+			return err
+		}
+
+		children, err := uc.departmentRepo.CountChildren(ctx, uid)
+		if err != nil {
+			return err
+		}
+		members, err := uc.departmentRepo.CountMembers(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if children > 0 || members > 0 {
+			return ErrDepartmentNotEmpty
+		}
+
+		return uc.departmentRepo.Delete(ctx, uid)
+	})
+}
+
+func (uc *DepartmentUsecase) GetDepartment(ctx context.Context, uid int64) (*Department, error) {
+	return uc.selectByUID(ctx, uid)
+}
+
+func (uc *DepartmentUsecase) ListDepartment(ctx context.Context) ([]*Department, error) {
+	return uc.departmentRepo.SelectAll(ctx)
+}
+
+// MoveDepartment 移动部门到新的上级部门下, 子部门的路径一并更新
+func (uc *DepartmentUsecase) MoveDepartment(ctx context.Context, uid int64, pid int64) error {
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		department, err := uc.selectByUID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if department.PID == pid {
+			return nil
+		}
+
+		parentPath := "/"
+		if pid > 0 {
+			parent, err := uc.selectByUID(ctx, pid)
+			if err != nil {
+				return err
+			}
+			// 新的上级部门不能是自身或自身的子部门
+			if strings.HasPrefix(parent.Path, department.Path) {
+				return ErrDepartmentMoveCycle
+			}
+			parentPath = parent.Path
+		}
+
+		subtree, err := uc.departmentRepo.SelectSubtree(ctx, department.Path)
+		if err != nil {
+			return err
+		}
+
+		oldPath := department.Path
+		newPath := departmentPath(parentPath, department.UID)
+		for _, item := range subtree {
+			itemPID := item.PID
+			if item.UID == department.UID {
+				itemPID = pid
+			}
+			if err := uc.departmentRepo.UpdatePath(ctx, item.UID, itemPID, newPath+strings.TrimPrefix(item.Path, oldPath)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetUserDepartment 全量设置用户所属部门
+func (uc *DepartmentUsecase) SetUserDepartment(ctx context.Context, userID int64, primary int64, secondary []int64) error {
+	secondary = lo.Without(lo.Uniq(secondary), primary, 0)
+	if primary <= 0 && len(secondary) > 0 {
+		return ErrDepartmentPrimaryRequired
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if err := checkUserExist(ctx, uc.userRepo, userID); err != nil {
+			return err
+		}
+
+		bindings := make([]*UserDepartment, 0, len(secondary)+1)
+		if primary > 0 {
+			bindings = append(bindings, &UserDepartment{UserID: userID, DepartmentID: primary, IsPrimary: true})
+		}
+		for _, id := range secondary {
+			bindings = append(bindings, &UserDepartment{UserID: userID, DepartmentID: id})
+		}
+
+		ids := lo.Map(bindings, func(item *UserDepartment, _ int) int64 { return item.DepartmentID })
+		if len(ids) > 0 {
+			departments, err := uc.departmentRepo.SelectByUIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+			if len(departments) != len(ids) {
+				return ErrDepartmentNotFound
+			}
+		}
+
+		return uc.departmentRepo.SetUserDepartments(ctx, userID, bindings)
+	})
+}
+
+// GetUserDepartment 用户所属的主部门和兼职部门
+func (uc *DepartmentUsecase) GetUserDepartment(ctx context.Context, userID int64) (*Department, []*Department, error) {
+	bindings, err := uc.departmentRepo.SelectUserDepartments(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bindings) <= 0 {
+		return nil, nil, nil
+	}
+
+	departments, err := uc.departmentRepo.SelectByUIDs(ctx, lo.Map(bindings, func(item *UserDepartment, _ int) int64 { return item.DepartmentID }))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		primary   *Department
+		secondary = make([]*Department, 0, len(departments))
+		primaryID int64
+	)
+	if binding, ok := lo.Find(bindings, func(item *UserDepartment) bool { return item.IsPrimary }); ok {
+		primaryID = binding.DepartmentID
+	}
+	for _, department := range departments {
+		if department.UID == primaryID {
+			primary = department
+			continue
+		}
+		secondary = append(secondary, department)
+	}
+	return primary, secondary, nil
+}
+
+func (uc *DepartmentUsecase) selectByUID(ctx context.Context, uid int64) (*Department, error) {
+	department, err := uc.departmentRepo.SelectByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if department == nil {
+		return nil, ErrDepartmentNotFound
+	}
+	return department, nil
+}
+
+func departmentPath(parentPath string, uid int64) string {
+	return fmt.Sprintf("%s%d/", parentPath, uid)
+}
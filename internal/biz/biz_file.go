@@ -0,0 +1,274 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+const (
+	// MaxFileSize 上传文件的大小上限
+	MaxFileSize = 10 << 20
+	// MaxAvatarSize 头像的大小上限
+	MaxAvatarSize = 2 << 20
+	// MaxAvatarDimension 头像的最大宽高
+	MaxAvatarDimension = 4096
+
+	FilePurposeGeneral = 0
+	FilePurposeAvatar  = 1
+)
+
+var (
+	ErrFileNotFound  = errors.New(404, "FILE_NOT_FOUND", "文件不存在")
+	ErrFileEmpty     = errors.New(400, "FILE_EMPTY", "文件内容为空")
+	ErrFileTooLarge  = errors.New(400, "FILE_TOO_LARGE", "文件大小超出限制")
+	ErrFileType      = errors.New(400, "FILE_TYPE_NOT_ALLOWED", "不支持的文件类型")
+	ErrFileForbidden = errors.New(403, "FILE_FORBIDDEN", "无权操作该文件")
+	ErrFileNotAvatar = errors.New(400, "FILE_NOT_AVATAR", "文件不是头像图片")
+)
+
+// avatarMimes 允许作为头像的图片类型, webp 仅校验文件头
+var avatarMimes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// File 上传的文件, 内容按 sha256 存储在 BlobStore 中, 相同内容只保存一份
+type File struct {
+	ID       int64  `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID int64  `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	OwnerID  int64  `json:"owner_id" gorm:"column:owner_id;type:BIGINT;index;comment:上传者"`
+	Name     string `json:"name" gorm:"column:name;type:varchar(255);comment:文件名"`
+	Hash     string `json:"hash" gorm:"column:hash;type:varchar(64);index;comment:内容sha256"`
+	Mime     string `json:"mime" gorm:"column:mime;type:varchar(128);comment:文件类型"`
+	Size     int64  `json:"size" gorm:"column:size;type:BIGINT;comment:文件大小"`
+	Purpose  int    `json:"purpose" gorm:"column:purpose;type:int;comment:用途"` // 0: 通用, 1: 头像
+	Key      string `json:"-" gorm:"column:storage_key;type:varchar(255);index;comment:存储key"`
+
+	orm.DBModel
+}
+
+func (File) TableName() string {
+	return "files"
+}
+
+// FileURL 文件的下载地址, uid 为 0 时返回空
+func FileURL(uid int64) string {
+	if uid <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("/api/console/file/%d/content", uid)
+}
+
+// AvatarURL 头像的公开地址, 不需要登录即可访问, uid 为 0 时返回空
+func AvatarURL(uid int64) string {
+	if uid <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("/api/console/avatar/%d", uid)
+}
+
+// BlobStore 文件内容存储, key 不存在时 Get 返回 fs.ErrNotExist
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type FileRepo interface {
+	Create(ctx context.Context, file *File) error
+	SelectByUID(ctx context.Context, uid int64) (*File, error)
+	Delete(ctx context.Context, uid int64) error
+	// CountByKey 引用同一存储内容的文件数
+	CountByKey(ctx context.Context, key string) (int64, error)
+}
+
+type FileUsecase struct {
+	log      *log.Helper
+	txm      orm.Transaction
+	fileRepo FileRepo
+	userRepo UserRepo
+	store    BlobStore
+}
+
+func NewFileUsecase(repo FileRepo, userRepo UserRepo, store BlobStore, txm orm.Transaction, logger log.Logger) *FileUsecase {
+	return &FileUsecase{
+		log:      log.NewHelper(logger),
+		txm:      txm,
+		fileRepo: repo,
+		userRepo: userRepo,
+		store:    store,
+	}
+}
+
+// Upload 校验并保存文件, 类型以内容识别为准
+func (uc *FileUsecase) Upload(ctx context.Context, file *File, content []byte) error {
+	if len(content) == 0 {
+		return ErrFileEmpty
+	}
+	limit := lo.Ternary(file.Purpose == FilePurposeAvatar, MaxAvatarSize, MaxFileSize)
+	if len(content) > limit {
+		return ErrFileTooLarge.WithMetadata(map[string]string{
+			"max_size": strconv.Itoa(limit),
+		})
+	}
+
+	file.Mime = http.DetectContentType(content)
+	if file.Purpose == FilePurposeAvatar {
+		if err := checkImage(file.Mime, content); err != nil {
+			return err
+		}
+	}
+
+	sum := sha256.Sum256(content)
+	file.Hash = hex.EncodeToString(sum[:])
+	file.Size = int64(len(content))
+	file.Key = fmt.Sprintf("%s/%s/%s", file.Hash[:2], file.Hash[2:4], file.Hash)
+
+	// 内容按 hash 寻址, 重复写入相同内容是幂等的
+	if err := uc.store.Put(ctx, file.Key, bytes.NewReader(content), file.Size, file.Mime); err != nil {
+		return err
+	}
+	return uc.fileRepo.Create(ctx, file)
+}
+
+func (uc *FileUsecase) GetFile(ctx context.Context, uid int64) (*File, error) {
+	file, err := uc.fileRepo.SelectByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, ErrFileNotFound
+	}
+	return file, nil
+}
+
+// Open 打开文件内容, 调用方负责关闭
+func (uc *FileUsecase) Open(ctx context.Context, uid int64) (*File, io.ReadCloser, error) {
+	file, err := uc.GetFile(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return uc.open(ctx, file)
+}
+
+// OpenAvatar 打开公开的头像, 不区分租户; 仅限正在被用户使用的头像, 其它文件按不存在处理
+func (uc *FileUsecase) OpenAvatar(ctx context.Context, uid int64) (*File, io.ReadCloser, error) {
+	ctx = tenant.WithoutTenant(ctx)
+	file, err := uc.GetFile(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if file.Purpose != FilePurposeAvatar {
+		return nil, nil, ErrFileNotFound
+	}
+	count, err := uc.userRepo.CountByAvatar(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if count <= 0 {
+		return nil, nil, ErrFileNotFound
+	}
+	return uc.open(ctx, file)
+}
+
+func (uc *FileUsecase) open(ctx context.Context, file *File) (*File, io.ReadCloser, error) {
+	rc, err := uc.store.Get(ctx, file.Key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			uc.log.Errorf("file %d content %s is missing", file.UID, file.Key)
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+	return file, rc, nil
+}
+
+// DeleteFile 删除文件, 使用该文件作为头像的用户头像被清除, 内容不再被引用时一并删除
+func (uc *FileUsecase) DeleteFile(ctx context.Context, uid int64, operator int64) error {
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		file, err := uc.GetFile(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if file.OwnerID != operator {
+			return ErrFileForbidden
+		}
+
+		if err := uc.fileRepo.Delete(ctx, uid); err != nil {
+			return err
+		}
+		if file.Purpose == FilePurposeAvatar {
+			if err := uc.userRepo.ClearAvatar(ctx, uid); err != nil {
+				return err
+			}
+		}
+
+		count, err := uc.fileRepo.CountByKey(ctx, file.Key)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		return uc.store.Delete(ctx, file.Key)
+	})
+}
+
+// SetAvatar 设置用户头像, fileID 为 0 时清除头像
+func (uc *FileUsecase) SetAvatar(ctx context.Context, userID int64, fileID int64) error {
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepo.SelectUserByUID(ctx, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if err := checkAvatar(ctx, uc.fileRepo, fileID, 0); err != nil {
+			return err
+		}
+		return uc.userRepo.UpdateAvatar(ctx, userID, fileID)
+	})
+}
+
+// checkImage 校验图片类型与尺寸
+func checkImage(mime string, content []byte) error {
+	if !lo.Contains(avatarMimes, mime) {
+		return ErrFileType.WithMetadata(map[string]string{
+			"mime": mime,
+		})
+	}
+	// 标准库不支持解码 webp, 仅以文件头识别
+	if mime == "image/webp" {
+		return nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return ErrFileType.WithMetadata(map[string]string{
+			"mime": mime,
+		})
+	}
+	if cfg.Width > MaxAvatarDimension || cfg.Height > MaxAvatarDimension {
+		return ErrFileTooLarge.WithMetadata(map[string]string{
+			"max_dimension": strconv.Itoa(MaxAvatarDimension),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package biz
+
+import (
+	"context"
+	"net/mail"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+)
+
+// DefaultInvitationTTL 邀请默认有效期
+const DefaultInvitationTTL = 72 * time.Hour
+
+var (
+	ErrInvitationNotFound   = errors.New(404, "INVITATION_NOT_FOUND", "邀请不存在或已失效")
+	ErrInvitationExpired    = errors.New(400, "INVITATION_EXPIRED", "邀请已过期")
+	ErrInvitationUsed       = errors.New(400, "INVITATION_USED", "邀请已被使用")
+	ErrInvitationNotPending = errors.New(400, "INVITATION_NOT_PENDING", "用户不是待激活状态")
+)
+
+// Invitation 用户邀请, 仅保存令牌摘要
+type Invitation struct {
+	ID         int64      `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID        int64      `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID   int64      `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	UserID     int64      `json:"user_id" gorm:"column:user_id;type:BIGINT;index;comment:被邀请的用户"`
+	Digest     string     `json:"-" gorm:"column:digest;type:varchar(64);uniqueIndex;comment:令牌摘要"`
+	InvitedBy  int64      `json:"invited_by" gorm:"column:invited_by;type:BIGINT;comment:邀请人"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"column:expires_at;comment:过期时间"`
+	AcceptedAt *time.Time `json:"accepted_at" gorm:"column:accepted_at;comment:激活时间"`
+
+	orm.DBModel
+}
+
+func (Invitation) TableName() string {
+	return "users_invitation"
+}
+
+type InvitationRepo interface {
+	Create(ctx context.Context, invitation *Invitation) error
+	SelectByDigest(ctx context.Context, digest string) (*Invitation, error)
+	// DeleteByUserID 删除用户未使用的邀请
+	DeleteByUserID(ctx context.Context, userID int64) error
+	// Accept 仅当邀请未被使用时标记为已使用, 返回是否标记成功
+	Accept(ctx context.Context, uid int64, acceptedAt time.Time) (bool, error)
+}
+
+type InvitationUsecase struct {
+	log             *log.Helper
+	txm             orm.Transaction
+	invitationRepo  InvitationRepo
+	userRepo        UserRepo
+	roleRepo        RoleRepo
+	constraintRepo  RoleConstraintRepo
+	passwordManager *PasswordManager
+}
+
+func NewInvitationUsecase(repo InvitationRepo, userRepo UserRepo, roleRepo RoleRepo, constraintRepo RoleConstraintRepo, passwordManager *PasswordManager, txm orm.Transaction, logger log.Logger) *InvitationUsecase {
+	return &InvitationUsecase{
+		log:             log.NewHelper(logger),
+		txm:             txm,
+		invitationRepo:  repo,
+		userRepo:        userRepo,
+		roleRepo:        roleRepo,
+		constraintRepo:  constraintRepo,
+		passwordManager: passwordManager,
+	}
+}
+
+// InviteUser 创建待激活的用户并生成邀请, 用户通过邀请设置密码后激活
+func (uc *InvitationUsecase) InviteUser(ctx context.Context, user *User, roleIDs []int64, invitation *Invitation) error {
+	if user.Username == "" {
+		return ErrUsernameEmpty
+	}
+	if addr, err := mail.ParseAddress(user.Email); err != nil || addr.Address != user.Email {
+		return ErrEmailInvalid
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		curr, err := uc.userRepo.SelectUserByName(ctx, user.Username)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if curr.UID > 0 {
+			return ErrUserExist
+		}
+		curr, err = uc.userRepo.SelectUserByEmail(ctx, user.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if curr.UID > 0 {
+			return ErrEmailExist
+		}
+
+		if err := checkAssignable(ctx, uc.roleRepo, roleIDs); err != nil {
+			return err
+		}
+		if err := checkRoleConflict(ctx, uc.constraintRepo, roleIDs); err != nil {
+			return err
+		}
+
+		user.Password = ""
+		user.Status = UserStatusPending
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if len(roleIDs) > 0 {
+			if err := uc.userRepo.UpdateRole(ctx, user.UID, roleIDs); err != nil {
+				return err
+			}
+		}
+
+		invitation.UserID = user.UID
+		return uc.invitationRepo.Create(ctx, invitation)
+	})
+}
+
+// ResendInvitation 为待激活的用户重新生成邀请, 之前的邀请失效
+func (uc *InvitationUsecase) ResendInvitation(ctx context.Context, invitation *Invitation) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		info, err := uc.userRepo.SelectUserByUID(ctx, invitation.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if info.Status != UserStatusPending {
+			return ErrInvitationNotPending
+		}
+		user = &info.User
+
+		if err := uc.invitationRepo.DeleteByUserID(ctx, invitation.UserID); err != nil {
+			return err
+		}
+		return uc.invitationRepo.Create(ctx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// AcceptInvitation 使用邀请设置密码并激活用户, 邀请只能使用一次
+func (uc *InvitationUsecase) AcceptInvitation(ctx context.Context, digest string, password string) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		invitation, err := uc.invitationRepo.SelectByDigest(ctx, digest)
+		if err != nil {
+			return err
+		}
+		if invitation == nil {
+			return ErrInvitationNotFound
+		}
+		if invitation.AcceptedAt != nil {
+			return ErrInvitationUsed
+		}
+		now := time.Now()
+		if now.After(invitation.ExpiresAt) {
+			return ErrInvitationExpired
+		}
+
+		user, err = uc.userRepo.SelectUserAccount(ctx, invitation.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvitationNotFound
+			}
+			return err
+		}
+		if user.Status != UserStatusPending {
+			return ErrInvitationNotPending
+		}
+		if err := uc.passwordManager.Apply(ctx, user, password); err != nil {
+			return err
+		}
+
+		// 并发使用同一邀请时仅第一个生效
+		ok, err := uc.invitationRepo.Accept(ctx, invitation.UID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvitationUsed
+		}
+
+		user.Status = UserStatusNormal
+		return uc.userRepo.Update(ctx, user.UID, &User{
+			Password:          user.Password,
+			PasswordChangedAt: user.PasswordChangedAt,
+			Status:            user.Status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -2,52 +2,114 @@ package biz
 
 import (
 	"context"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
 	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+var (
+	ErrMenuNotFound        = errors.New(404, "MENU_NOT_FOUND", "菜单不存在")
+	ErrMenuReorderMismatch = errors.New(400, "MENU_REORDER_MISMATCH", "排序列表与同级菜单不一致")
+	ErrMenuHasChildren     = errors.New(400, "MENU_HAS_CHILDREN", "菜单存在子菜单, 不能删除")
+	ErrMenuParentNotFound  = errors.New(400, "MENU_PARENT_NOT_FOUND", "上级菜单不存在")
+	ErrMenuCycle           = errors.New(400, "MENU_CYCLE", "不能将菜单移动到自身或其子菜单下")
+	ErrMenuForbidden       = errors.New(403, "MENU_FORBIDDEN", "平台菜单仅超级管理员可以修改")
 )
 
 type Menu struct {
-	ID           int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
-	UID          int64     `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex:idx_uid_uk"`
-	PID          int64     `json:"pid" gorm:"column:pid;type:BIGINT;comment:父级ID"` // zero is root node.
-	PermissionID int64     `json:"permission_id" gorm:"column:permission_id;type:BIGINT;comment:权限ID"`
-	Name         string    `json:"name" gorm:"column:name;type:varchar(255);comment:名称"`
-	Icon         string    `json:"icon" gorm:"column:icon;type:varchar(255);comment:图标"`
-	Path         string    `json:"path" gorm:"column:path;type:varchar(255);comment:路径"`
-	SortBy       int64     `json:"sort_by" gorm:"column:sort_by;type:int;comment:排序"`
-	Hidden       bool      `json:"hidden" gorm:"column:hidden;type:tinyint;comment:是否隐藏=0显示1隐藏"`
-	Status       int32     `json:"status" gorm:"column:status;type:int;comment:状态"`
-	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;type:datetime;comment:创建时间"`
-	UpdatedAt    time.Time `json:"updated_at" gorm:"column:updated_at;type:datetime;comment:更新时间"`
-	gorm.DeletedAt
+	ID           int64             `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID          int64             `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID     int64             `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	PID          int64             `json:"pid" gorm:"column:pid;type:BIGINT;comment:父级ID"` // zero is root node.
+	PermissionID int64             `json:"permission_id" gorm:"column:permission_id;type:BIGINT;comment:权限ID"`
+	Name         string            `json:"name" gorm:"column:name;type:varchar(255);comment:名称"`
+	Icon         string            `json:"icon" gorm:"column:icon;type:varchar(255);comment:图标"`
+	Path         string            `json:"path" gorm:"column:path;type:varchar(255);comment:路径"`
+	SortBy       int64             `json:"sort_by" gorm:"column:sort_by;type:int;comment:排序"`
+	Hidden       bool              `json:"hidden" gorm:"column:hidden;type:boolean;comment:是否隐藏=0显示1隐藏"`
+	Titles       map[string]string `json:"titles" gorm:"column:titles;type:json;serializer:json;comment:多语言标题"`
+	Component    string            `json:"component" gorm:"column:component;type:varchar(255);comment:前端组件路径"`
+	Redirect     string            `json:"redirect" gorm:"column:redirect;type:varchar(255);comment:重定向路径"`
+	Target       string            `json:"target" gorm:"column:target;type:varchar(16);comment:外部链接打开方式"`
+	KeepAlive    bool              `json:"keep_alive" gorm:"column:keep_alive;type:boolean;comment:是否缓存页面"`
+	Badge        string            `json:"badge" gorm:"column:badge;type:varchar(32);comment:角标"`
+	Status       int32             `json:"status" gorm:"column:status;type:int;comment:状态"`
+	Version      int64             `json:"version" gorm:"column:version;type:BIGINT;not null;default:1;comment:版本号"` // 每次更新加一, 用于乐观锁
+	CreatedAt    time.Time         `json:"created_at" gorm:"column:created_at;comment:创建时间"`
+	UpdatedAt    time.Time         `json:"updated_at" gorm:"column:updated_at;comment:更新时间"`
+	// 不能匿名嵌入, 否则 DeletedAt 的 MarshalJSON 会使整个 Menu 序列化为 null
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"column:deleted_at"`
 }
 
 func (Menu) TableName() string {
 	return "menus"
 }
 
+// MenuDeleteMode 删除存在子菜单的菜单时的处理方式, 与 pb.MenuDeleteMode 保持一致
+type MenuDeleteMode int32
+
+const (
+	MenuDeleteRestrict MenuDeleteMode = iota
+	MenuDeleteCascade
+	MenuDeleteReparent
+)
+
+// 菜单状态, 与 pb.MenuStatus 保持一致
+const (
+	MenuStatusEnable  int32 = 0
+	MenuStatusDisable int32 = 1
+)
+
+// MenuNode 菜单树节点
+type MenuNode struct {
+	*Menu
+
+	Actions  []string // 已授权的动作, 仅 Authorize 填充
+	Children []*MenuNode
+}
+
+// TenantShared 平台租户的菜单对所有租户可见, 但仅平台可以修改
+func (Menu) TenantShared() bool {
+	return true
+}
+
 type MenuRepo interface {
 	Create(ctx context.Context, m *Menu) error
 	Update(ctx context.Context, m *Menu) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, uids ...int64) error
 	Get(ctx context.Context, id int64) (*Menu, error)
 	SelectList(ctx context.Context, pagination *protobuf.Pagination, name string, status int32) ([]*Menu, error)
 	SelectAll(ctx context.Context) ([]*Menu, error)
+	// UpdatePosition 更新上级与排序值, 允许零值
+	UpdatePosition(ctx context.Context, uid, pid, sortBy int64) error
+	// UnlinkPermission 解除菜单关联的权限并禁用菜单
+	UnlinkPermission(ctx context.Context, uids ...int64) error
 }
 
 type MenuUsecase struct {
-	repo MenuRepo
-	log  *log.Helper
+	repo           MenuRepo
+	permissionRepo PermissionRepo
+	authz          *AuthzCache
+	txm            orm.Transaction
+	log            *log.Helper
 }
 
-func NewMenuUsecase(repo MenuRepo, logger log.Logger) *MenuUsecase {
+func NewMenuUsecase(repo MenuRepo, permissionRepo PermissionRepo, authz *AuthzCache, txm orm.Transaction, logger log.Logger) *MenuUsecase {
 	return &MenuUsecase{
-		repo: repo,
-		log:  log.NewHelper(logger),
+		repo:           repo,
+		permissionRepo: permissionRepo,
+		authz:          authz,
+		txm:            txm,
+		log:            log.NewHelper(logger),
 	}
 }
 
@@ -56,35 +118,133 @@ func (uc *MenuUsecase) Create(ctx context.Context, m *Menu) error {
 	if m.Name == "" {
 		return errors.New(400, "MENU_NAME_EMPTY", "菜单名称不能为空")
 	}
+	if err := uc.checkParent(ctx, m.UID, m.PID); err != nil {
+		return err
+	}
+	if err := normalizeMenu(m); err != nil {
+		return err
+	}
 
 	m.CreatedAt = time.Now()
 	m.UpdatedAt = time.Now()
 
-	return uc.repo.Create(ctx, m)
+	if err := uc.repo.Create(ctx, m); err != nil {
+		return err
+	}
+	uc.authz.menusChanged(ctx)
+	return nil
 }
 
 // Update 更新菜单
 func (uc *MenuUsecase) Update(ctx context.Context, m *Menu) error {
-	if m.ID <= 0 {
+	if m.UID <= 0 {
 		return errors.New(400, "MENU_ID_INVALID", "菜单ID无效")
 	}
 
 	if m.Name == "" {
 		return errors.New(400, "MENU_NAME_EMPTY", "菜单名称不能为空")
 	}
+	if err := checkVersion(m.Version); err != nil {
+		return err
+	}
+	if err := normalizeMenu(m); err != nil {
+		return err
+	}
 
 	m.UpdatedAt = time.Now()
 
-	return uc.repo.Update(ctx, m)
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.Get(ctx, m.UID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMenuNotFound
+			}
+			return err
+		}
+		if err := checkMenuWritable(ctx, current); err != nil {
+			return err
+		}
+		if err := uc.checkParent(ctx, m.UID, m.PID); err != nil {
+			return err
+		}
+		return uc.repo.Update(ctx, m)
+	})
+	if err != nil {
+		return err
+	}
+	uc.authz.menusChanged(ctx)
+	return nil
 }
 
-// Delete 删除菜单
-func (uc *MenuUsecase) Delete(ctx context.Context, id int64) error {
+// Delete 按 mode 删除菜单, 返回被删除的菜单 (包括级联删除的子菜单)
+func (uc *MenuUsecase) Delete(ctx context.Context, id int64, mode MenuDeleteMode) ([]int64, error) {
 	if id <= 0 {
-		return errors.New(400, "MENU_ID_INVALID", "菜单ID无效")
+		return nil, errors.New(400, "MENU_ID_INVALID", "菜单ID无效")
 	}
 
-	return uc.repo.Delete(ctx, id)
+	var deleted []int64
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		menus, err := uc.repo.SelectAll(ctx)
+		if err != nil {
+			return err
+		}
+		m, ok := lo.Find(menus, func(item *Menu) bool {
+			return item.UID == id
+		})
+		if !ok {
+			return ErrMenuNotFound
+		}
+		if err = checkMenuWritable(ctx, m); err != nil {
+			return err
+		}
+		children := lo.Filter(menus, func(item *Menu, _ int) bool {
+			return item.PID == id
+		})
+
+		deleted = []int64{id}
+		switch {
+		case len(children) == 0:
+		case mode == MenuDeleteCascade:
+			descendants := menuDescendants(menus, id)
+			if err = checkMenuWritable(ctx, lo.Filter(menus, func(item *Menu, _ int) bool {
+				return lo.Contains(descendants, item.UID)
+			})...); err != nil {
+				return err
+			}
+			deleted = append(deleted, descendants...)
+		case mode == MenuDeleteReparent:
+			if err = checkMenuWritable(ctx, children...); err != nil {
+				return err
+			}
+			// 子菜单按原顺序插入到被删除菜单的位置, 只重新编号当前租户自己的菜单
+			var ordered []*Menu
+			for _, sibling := range sortMenus(lo.Filter(menus, func(item *Menu, _ int) bool {
+				return item.PID == m.PID && menuWritable(ctx, item)
+			})) {
+				if sibling.UID != id {
+					ordered = append(ordered, sibling)
+					continue
+				}
+				for _, child := range sortMenus(children) {
+					child.PID = m.PID
+					ordered = append(ordered, child)
+				}
+			}
+			if err = uc.renumber(ctx, ordered, lo.Map(children, func(item *Menu, _ int) int64 {
+				return item.UID
+			})...); err != nil {
+				return err
+			}
+		default:
+			return ErrMenuHasChildren.WithMetadata(map[string]string{"children": strconv.Itoa(len(children))})
+		}
+		return uc.repo.Delete(ctx, deleted...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.authz.menusChanged(ctx)
+	return deleted, nil
 }
 
 // SelectByID 获取菜单
@@ -101,6 +261,220 @@ func (uc *MenuUsecase) SelectList(ctx context.Context, pagination *protobuf.Pagi
 	return uc.repo.SelectList(ctx, pagination, name, status)
 }
 
+// SelectAll 获取全部菜单, 读取自缓存; 修改菜单时需要的最新数据请直接读取 repo
 func (uc *MenuUsecase) SelectAll(ctx context.Context) ([]*Menu, error) {
-	return uc.repo.SelectAll(ctx)
+	return uc.authz.Menus(ctx)
+}
+
+// SelectTree 获取菜单树, allow 为空时返回全部菜单; 被 allow 排除的菜单连同其子树一起移除
+func (uc *MenuUsecase) SelectTree(ctx context.Context, allow func(m *Menu) bool) ([]*MenuNode, error) {
+	menus, err := uc.authz.Menus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if allow != nil {
+		menus = lo.Filter(menus, func(item *Menu, _ int) bool {
+			return allow(item)
+		})
+	}
+	return BuildMenuTree(menus), nil
+}
+
+// Move 将菜单移动到 pid 下的第 position 位 (从 0 开始, 越界时放到最后), 并重新编号新的同级菜单
+func (uc *MenuUsecase) Move(ctx context.Context, uid, pid int64, position int) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		menus, err := uc.repo.SelectAll(ctx)
+		if err != nil {
+			return err
+		}
+		m, ok := lo.Find(menus, func(item *Menu) bool {
+			return item.UID == uid
+		})
+		if !ok {
+			return ErrMenuNotFound
+		}
+		if err = checkMenuWritable(ctx, m); err != nil {
+			return err
+		}
+		if err = uc.checkParent(ctx, uid, pid); err != nil {
+			return err
+		}
+
+		// 平台菜单对租户只读, 只在当前租户自己的菜单中排序
+		siblings := sortMenus(lo.Filter(menus, func(item *Menu, _ int) bool {
+			return item.PID == pid && item.UID != uid && menuWritable(ctx, item)
+		}))
+		position = lo.Clamp(position, 0, len(siblings))
+		ordered := append(append(append([]*Menu{}, siblings[:position]...), m), siblings[position:]...)
+
+		m.PID = pid
+		return uc.renumber(ctx, ordered, uid)
+	})
+	if err != nil {
+		return err
+	}
+	uc.authz.menusChanged(ctx)
+	return nil
+}
+
+// Reorder 按 uids 的顺序重排 pid 下的菜单, uids 必须恰好是该上级下当前租户的全部菜单 (不包括平台菜单)
+func (uc *MenuUsecase) Reorder(ctx context.Context, pid int64, uids []int64) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		menus, err := uc.repo.SelectAll(ctx)
+		if err != nil {
+			return err
+		}
+		children := lo.Filter(menus, func(item *Menu, _ int) bool {
+			return item.PID == pid
+		})
+		if err = checkMenuWritable(ctx, lo.Filter(children, func(item *Menu, _ int) bool {
+			return lo.Contains(uids, item.UID)
+		})...); err != nil {
+			return err
+		}
+		siblings := lo.SliceToMap(lo.Filter(children, func(item *Menu, _ int) bool {
+			return menuWritable(ctx, item)
+		}), func(item *Menu) (int64, *Menu) {
+			return item.UID, item
+		})
+		if len(uids) != len(siblings) || len(lo.Uniq(uids)) != len(uids) {
+			return ErrMenuReorderMismatch
+		}
+
+		ordered := make([]*Menu, 0, len(uids))
+		for _, uid := range uids {
+			m, ok := siblings[uid]
+			if !ok {
+				return ErrMenuReorderMismatch.WithMetadata(map[string]string{"uid": strconv.FormatInt(uid, 10)})
+			}
+			ordered = append(ordered, m)
+		}
+		return uc.renumber(ctx, ordered)
+	})
+	if err != nil {
+		return err
+	}
+	uc.authz.menusChanged(ctx)
+	return nil
+}
+
+// renumber 将 ordered 的排序值依次设为 1..n, 仅写入发生变化的菜单; moved 为更换了上级的菜单, 总是写入
+func (uc *MenuUsecase) renumber(ctx context.Context, ordered []*Menu, moved ...int64) error {
+	for i, m := range ordered {
+		sortBy := int64(i + 1)
+		if m.SortBy == sortBy && !lo.Contains(moved, m.UID) {
+			continue
+		}
+		if err := uc.repo.UpdatePosition(ctx, m.UID, m.PID, sortBy); err != nil {
+			return err
+		}
+		m.SortBy = sortBy
+	}
+	return nil
+}
+
+// menuWritable 菜单是否属于当前租户; context 中没有租户 (超级管理员) 时全部可写
+func menuWritable(ctx context.Context, m *Menu) bool {
+	tenantID, ok := tenant.FromContext(ctx)
+	return !ok || m.TenantID == tenantID
+}
+
+// checkMenuWritable 租户只能修改自己的菜单, 平台菜单对租户只读
+func checkMenuWritable(ctx context.Context, menus ...*Menu) error {
+	for _, m := range menus {
+		if !menuWritable(ctx, m) {
+			return ErrMenuForbidden.WithMetadata(map[string]string{"uid": strconv.FormatInt(m.UID, 10)})
+		}
+	}
+	return nil
+}
+
+// checkParent 校验上级菜单存在, 且不是菜单自身或其子孙
+func (uc *MenuUsecase) checkParent(ctx context.Context, uid, pid int64) error {
+	if pid == 0 {
+		return nil
+	}
+	if pid == uid {
+		return ErrMenuCycle
+	}
+
+	menus, err := uc.repo.SelectAll(ctx)
+	if err != nil {
+		return err
+	}
+	parents := lo.SliceToMap(menus, func(item *Menu) (int64, int64) {
+		return item.UID, item.PID
+	})
+	if _, ok := parents[pid]; !ok {
+		return ErrMenuParentNotFound
+	}
+	// 沿上级链向上查找, 遇到自身说明会形成环; 步数上限防止脏数据中已有的环
+	for cur, step := pid, 0; cur != 0 && step <= len(parents); cur, step = parents[cur], step+1 {
+		if cur == uid {
+			return ErrMenuCycle
+		}
+	}
+	return nil
+}
+
+// BuildMenuTree 将扁平菜单组装为树, 同级按 SortBy 升序;
+// 上级不在 menus 中的菜单视为不可达, 连同其子树一起丢弃
+func BuildMenuTree(menus []*Menu) []*MenuNode {
+	children := lo.GroupBy(menus, func(item *Menu) int64 {
+		return item.PID
+	})
+
+	var build func(pid int64) []*MenuNode
+	build = func(pid int64) []*MenuNode {
+		items := sortMenus(children[pid])
+		return lo.Map(items, func(item *Menu, _ int) *MenuNode {
+			return &MenuNode{Menu: item, Children: build(item.UID)}
+		})
+	}
+	return build(0)
+}
+
+// FlattenMenuTree 按先序遍历展开菜单树
+func FlattenMenuTree(nodes []*MenuNode) []*Menu {
+	var menus []*Menu
+	for _, node := range nodes {
+		menus = append(menus, node.Menu)
+		menus = append(menus, FlattenMenuTree(node.Children)...)
+	}
+	return menus
+}
+
+// sortMenus 同级菜单按 SortBy 升序, 相同时按 UID
+func sortMenus(menus []*Menu) []*Menu {
+	sort.SliceStable(menus, func(i, j int) bool {
+		if menus[i].SortBy != menus[j].SortBy {
+			return menus[i].SortBy < menus[j].SortBy
+		}
+		return menus[i].UID < menus[j].UID
+	})
+	return menus
+}
+
+// menuDescendants menus 中 uid 的全部子孙菜单
+func menuDescendants(menus []*Menu, uid int64) []int64 {
+	children := lo.GroupBy(menus, func(item *Menu) int64 {
+		return item.PID
+	})
+	var (
+		ret     []int64
+		visited = map[int64]bool{uid: true}
+		queue   = []int64{uid}
+	)
+	for len(queue) > 0 {
+		for _, child := range children[queue[0]] {
+			if visited[child.UID] {
+				continue
+			}
+			visited[child.UID] = true
+			ret = append(ret, child.UID)
+			queue = append(queue, child.UID)
+		}
+		queue = queue[1:]
+	}
+	return ret
 }
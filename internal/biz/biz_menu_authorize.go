@@ -0,0 +1,60 @@
+package biz
+
+import (
+	"context"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// Authorize 用户可见的菜单树, 仅包含启用的菜单; 每个菜单附带用户在其关联权限上被授予的动作 (多个角色合并).
+// 用户角色, 角色权限及菜单均读取自缓存 (见 AuthzCache), 绑定或菜单变化后由事件清除
+func (uc *MenuUsecase) Authorize(ctx context.Context, uid int64) ([]*MenuNode, error) {
+	roleIDs, err := uc.authz.UserRoleIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make(map[int64][]string)
+	if len(roleIDs) > 0 {
+		rolePermissions, err := uc.authz.RolePermissions(ctx, roleIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range rolePermissions {
+			for _, permission := range role.Permissions {
+				keys := lo.Map(permission.Actions, func(item *Action, _ int) string {
+					return item.Key
+				})
+				actions[permission.PermID] = lo.Uniq(append(actions[permission.PermID], keys...))
+			}
+		}
+		for _, keys := range actions {
+			slices.Sort(keys)
+		}
+	}
+
+	tree, err := uc.SelectTree(ctx, func(m *Menu) bool {
+		if m.Status != MenuStatusEnable {
+			return false
+		}
+		if m.PermissionID == 0 {
+			return true
+		}
+		_, ok := actions[m.PermissionID]
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var attach func(nodes []*MenuNode)
+	attach = func(nodes []*MenuNode) {
+		for _, node := range nodes {
+			node.Actions = actions[node.PermissionID]
+			attach(node.Children)
+		}
+	}
+	attach(tree)
+	return tree, nil
+}
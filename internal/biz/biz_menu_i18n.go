@@ -0,0 +1,66 @@
+package biz
+
+import (
+	"sort"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+)
+
+var (
+	ErrMenuLocaleInvalid = errors.New(400, "MENU_LOCALE_INVALID", "无效的语言标签")
+	ErrMenuTargetInvalid = errors.New(400, "MENU_TARGET_INVALID", "无效的链接打开方式")
+)
+
+// 外部链接的打开方式, 为空时由前端决定
+var menuTargets = []string{"", "_self", "_blank"}
+
+// normalizeMenu 将多语言标题的 key 规范为 BCP 47 标签 (zh-cn => zh-CN), 并校验路由元数据
+func normalizeMenu(m *Menu) error {
+	if !lo.Contains(menuTargets, m.Target) {
+		return ErrMenuTargetInvalid.WithMetadata(map[string]string{"target": m.Target})
+	}
+
+	titles := make(map[string]string, len(m.Titles))
+	for key, title := range m.Titles {
+		if title == "" {
+			continue
+		}
+		tag, err := language.Parse(key)
+		if err != nil {
+			return ErrMenuLocaleInvalid.WithMetadata(map[string]string{"locale": key})
+		}
+		titles[tag.String()] = title
+	}
+	m.Titles = titles
+	return nil
+}
+
+// LocalizedTitle 按 langs 的优先级选择标题, 每种语言先精确匹配, 再匹配相同的基础语言 (zh => zh-CN);
+// 都没有时返回 fallback
+func LocalizedTitle(titles map[string]string, fallback string, langs []string) string {
+	if len(titles) == 0 {
+		return fallback
+	}
+
+	keys := lo.Keys(titles)
+	sort.Strings(keys)
+	for _, lang := range langs {
+		if title, ok := titles[lang]; ok {
+			return title
+		}
+		base := baseLanguage(lang)
+		for _, key := range keys {
+			if baseLanguage(key) == base {
+				return titles[key]
+			}
+		}
+	}
+	return fallback
+}
+
+func baseLanguage(tag string) string {
+	base, _ := language.Make(tag).Base()
+	return base.String()
+}
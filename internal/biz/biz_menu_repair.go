@@ -0,0 +1,140 @@
+package biz
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// 菜单数据问题, 与 pb.MenuIssueType 保持一致
+const (
+	MenuIssueOrphan            = 1 // 上级菜单不存在
+	MenuIssueCycle             = 2 // 上级链形成环
+	MenuIssuePermissionMissing = 3 // 关联的权限已删除
+)
+
+type MenuIssue struct {
+	*Menu
+
+	Type int
+}
+
+// Repair 检查菜单数据问题, dryRun 为 false 时修复:
+// 孤立及循环引用的菜单移动到根节点末尾 (deleteOrphans 时连同子菜单删除),
+// 关联权限已删除的菜单解除关联并禁用, 避免变为所有人可见.
+// 租户只检查和修复自己的菜单, 平台菜单由超级管理员处理
+func (uc *MenuUsecase) Repair(ctx context.Context, dryRun bool, deleteOrphans bool) ([]*MenuIssue, error) {
+	var issues []*MenuIssue
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		menus, err := uc.repo.SelectAll(ctx)
+		if err != nil {
+			return err
+		}
+		permissions, err := uc.permissionRepo.SelectAll(ctx, false)
+		if err != nil {
+			return err
+		}
+		issues = lo.Filter(findMenuIssues(menus, lo.SliceToMap(permissions, func(item *Permission) (int64, bool) {
+			return item.UID, true
+		})), func(item *MenuIssue, _ int) bool {
+			return menuWritable(ctx, item.Menu)
+		})
+		if dryRun || len(issues) == 0 {
+			return nil
+		}
+
+		var (
+			detached = lo.Filter(issues, func(item *MenuIssue, _ int) bool {
+				return item.Type != MenuIssuePermissionMissing
+			})
+			unlinked = lo.FilterMap(issues, func(item *MenuIssue, _ int) (int64, bool) {
+				return item.UID, item.Type == MenuIssuePermissionMissing
+			})
+			deleted []int64
+		)
+		if deleteOrphans {
+			for _, issue := range detached {
+				deleted = append(append(deleted, issue.UID), menuDescendants(menus, issue.UID)...)
+			}
+			// 子孙中的平台菜单不删除, 修复后仍是孤立菜单, 由超级管理员处理
+			deleted = lo.Uniq(lo.Filter(deleted, func(uid int64, _ int) bool {
+				m, ok := lo.Find(menus, func(item *Menu) bool { return item.UID == uid })
+				return ok && menuWritable(ctx, m)
+			}))
+			if len(deleted) > 0 {
+				if err = uc.repo.Delete(ctx, deleted...); err != nil {
+					return err
+				}
+			}
+		} else {
+			last := lo.Max(lo.FilterMap(menus, func(item *Menu, _ int) (int64, bool) {
+				return item.SortBy, item.PID == 0
+			}))
+			for i, issue := range detached {
+				if err = uc.repo.UpdatePosition(ctx, issue.UID, 0, last+int64(i+1)); err != nil {
+					return err
+				}
+			}
+		}
+
+		unlinked = lo.Without(unlinked, deleted...)
+		if len(unlinked) > 0 {
+			return uc.repo.UnlinkPermission(ctx, unlinked...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun && len(issues) > 0 {
+		uc.authz.menusChanged(ctx)
+	}
+	return issues, nil
+}
+
+// findMenuIssues 从根节点不可达的菜单中找出问题的源头 (上级不存在或处于环中), 其子孙不重复报告;
+// 以及关联了不存在权限的菜单
+func findMenuIssues(menus []*Menu, permissions map[int64]bool) []*MenuIssue {
+	menus = slices.Clone(menus)
+	sort.Slice(menus, func(i, j int) bool {
+		return menus[i].UID < menus[j].UID
+	})
+
+	byUID := lo.KeyBy(menus, func(item *Menu) int64 {
+		return item.UID
+	})
+	reachable := lo.SliceToMap(FlattenMenuTree(BuildMenuTree(menus)), func(item *Menu) (int64, bool) {
+		return item.UID, true
+	})
+
+	var issues []*MenuIssue
+	for _, m := range menus {
+		if reachable[m.UID] {
+			continue
+		}
+		if _, ok := byUID[m.PID]; !ok {
+			issues = append(issues, &MenuIssue{Menu: m, Type: MenuIssueOrphan})
+			continue
+		}
+		// 沿上级链回到自身说明处于环中; 否则是孤立或环上菜单的子孙
+		for cur, step := m.PID, 0; step < len(menus); step++ {
+			if cur == m.UID {
+				issues = append(issues, &MenuIssue{Menu: m, Type: MenuIssueCycle})
+				break
+			}
+			parent, ok := byUID[cur]
+			if !ok {
+				break
+			}
+			cur = parent.PID
+		}
+	}
+	for _, m := range menus {
+		if m.PermissionID != 0 && !permissions[m.PermissionID] {
+			issues = append(issues, &MenuIssue{Menu: m, Type: MenuIssuePermissionMissing})
+		}
+	}
+	return issues
+}
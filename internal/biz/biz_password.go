@@ -0,0 +1,196 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/samber/lo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultPasswordMinLength 未配置策略时的最小密码长度
+const DefaultPasswordMinLength = 8
+
+var (
+	ErrPasswordEmpty     = errors.New(400, "PASSWORD_EMPTY", "密码不能为空")
+	ErrPasswordPolicy    = errors.New(400, "PASSWORD_POLICY_VIOLATION", "密码不符合安全策略")
+	ErrPasswordReused    = errors.New(400, "PASSWORD_REUSED", "不能使用最近使用过的密码")
+	ErrPasswordSameAsOld = errors.New(400, "NEW_PASSWORD_SAME_AS_OLD", "新密码与旧密码相同")
+	ErrPasswordExpired   = errors.New(403, "PASSWORD_EXPIRED", "密码已过期, 请修改密码")
+)
+
+// commonPasswords 内置的常见弱密码
+var commonPasswords = []string{
+	"123456", "12345678", "123456789", "1234567890", "111111", "000000", "123123", "654321", "666666", "888888",
+	"password", "password1", "password123", "passw0rd", "p@ssw0rd", "qwerty", "qwerty123", "qwertyuiop",
+	"1q2w3e4r", "1qaz2wsx", "abc123", "abcd1234", "a123456", "admin", "admin123", "administrator", "root",
+	"letmein", "welcome", "iloveyou", "monkey", "dragon", "sunshine", "football", "changeme",
+}
+
+// PasswordPolicy 密码策略
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	DenyList      []string
+	AllowUsername bool
+	MaxAge        time.Duration // 0 表示永不过期
+	History       int           // 0 表示不检查历史密码
+}
+
+// Check 检查密码是否符合策略, 违反的每一项记录在错误的 metadata 中
+func (p *PasswordPolicy) Check(password string, username string) error {
+	violations := make(map[string]string)
+
+	minLength := p.MinLength
+	if minLength <= 0 {
+		minLength = DefaultPasswordMinLength
+	}
+	if len([]rune(password)) < minLength {
+		violations["min_length"] = fmt.Sprintf("长度不能少于 %d 位", minLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		violations["require_upper"] = "需要包含大写字母"
+	}
+	if p.RequireLower && !lower {
+		violations["require_lower"] = "需要包含小写字母"
+	}
+	if p.RequireDigit && !digit {
+		violations["require_digit"] = "需要包含数字"
+	}
+	if p.RequireSymbol && !symbol {
+		violations["require_symbol"] = "需要包含特殊字符"
+	}
+
+	lowered := strings.ToLower(password)
+	denied := func(item string) bool { return lowered == strings.ToLower(item) }
+	if lo.SomeBy(commonPasswords, denied) || lo.SomeBy(p.DenyList, denied) {
+		violations["deny_list"] = "密码过于常见"
+	}
+	if !p.AllowUsername && username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		violations["contains_username"] = "不能包含用户名"
+	}
+
+	if len(violations) > 0 {
+		return ErrPasswordPolicy.WithMetadata(violations)
+	}
+	return nil
+}
+
+// Expired 密码是否已超过有效期, 从未修改过密码的用户以创建时间计算
+func (p *PasswordPolicy) Expired(user *User) bool {
+	if p.MaxAge <= 0 {
+		return false
+	}
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return time.Since(changedAt) > p.MaxAge
+}
+
+// PasswordHistory 历史密码, 用于防止重复使用最近的密码
+type PasswordHistory struct {
+	ID        int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UserID    int64     `json:"user_id" gorm:"column:user_id;type:BIGINT;index;comment:用户ID"`
+	Password  string    `json:"-" gorm:"column:password;type:varchar(64);comment:密码"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;comment:创建时间"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "users_password_history"
+}
+
+type PasswordHistoryRepo interface {
+	Create(ctx context.Context, history *PasswordHistory) error
+	// SelectRecent 最近的 n 条历史密码, 按时间倒序
+	SelectRecent(ctx context.Context, userID int64, n int) ([]*PasswordHistory, error)
+	// Trim 仅保留最近的 n 条历史密码
+	Trim(ctx context.Context, userID int64, n int) error
+}
+
+// PasswordManager 统一处理密码的策略校验, 历史检查与加密
+type PasswordManager struct {
+	policy      *PasswordPolicy
+	historyRepo PasswordHistoryRepo
+}
+
+func NewPasswordManager(policy *PasswordPolicy, historyRepo PasswordHistoryRepo) *PasswordManager {
+	return &PasswordManager{
+		policy:      policy,
+		historyRepo: historyRepo,
+	}
+}
+
+func (m *PasswordManager) Policy() *PasswordPolicy {
+	return m.policy
+}
+
+// Check 仅校验密码策略
+func (m *PasswordManager) Check(password string, username string) error {
+	return m.policy.Check(password, username)
+}
+
+// Apply 校验新密码并设置到 user 上 (加密后), 同时记录历史密码; user.Password 为当前的密码
+func (m *PasswordManager) Apply(ctx context.Context, user *User, password string) error {
+	if password == "" {
+		return ErrPasswordEmpty
+	}
+	if err := m.policy.Check(password, user.Username); err != nil {
+		return err
+	}
+	if user.Password != "" && bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil {
+		return ErrPasswordSameAsOld
+	}
+
+	if m.policy.History > 0 && user.UID > 0 {
+		histories, err := m.historyRepo.SelectRecent(ctx, user.UID, m.policy.History)
+		if err != nil {
+			return err
+		}
+		for _, item := range histories {
+			if bcrypt.CompareHashAndPassword([]byte(item.Password), []byte(password)) == nil {
+				return ErrPasswordReused.WithMetadata(map[string]string{
+					"history": strconv.Itoa(m.policy.History),
+				})
+			}
+		}
+	}
+
+	hp, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	user.Password = string(hp)
+	user.PasswordChangedAt = &now
+
+	if m.policy.History <= 0 {
+		return nil
+	}
+	if err := m.historyRepo.Create(ctx, &PasswordHistory{UserID: user.UID, Password: user.Password}); err != nil {
+		return err
+	}
+	return m.historyRepo.Trim(ctx, user.UID, m.policy.History)
+}
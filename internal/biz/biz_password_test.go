@@ -0,0 +1,80 @@
+package biz
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	strict := &PasswordPolicy{MinLength: 10, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true, DenyList: []string{"Company2026!"}}
+
+	tests := []struct {
+		name     string
+		policy   *PasswordPolicy
+		password string
+		username string
+		want     []string
+	}{
+		{"default min length", &PasswordPolicy{}, "abc12", "", []string{"min_length"}},
+		{"default ok", &PasswordPolicy{}, "correct horse", "", nil},
+		{"min length counts runes", &PasswordPolicy{MinLength: 4}, "密码密码", "", nil},
+		{"common password", &PasswordPolicy{}, "Password123", "", []string{"deny_list"}},
+		{"strict ok", strict, "Tr0ub4dor&3x", "", nil},
+		{"strict missing classes", strict, "abcdefghijk", "", []string{"require_upper", "require_digit", "require_symbol"}},
+		{"strict too short", strict, "Ab1!", "", []string{"min_length"}},
+		{"custom deny list ignores case", strict, "company2026!", "", []string{"require_upper", "deny_list"}},
+		{"contains username", &PasswordPolicy{}, "xxAlice2026", "alice", []string{"contains_username"}},
+		{"username allowed", &PasswordPolicy{AllowUsername: true}, "xxAlice2026", "alice", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password, tt.username)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("check: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrPasswordPolicy) {
+				t.Fatalf("error = %v, want %v", err, ErrPasswordPolicy)
+			}
+			got := slices.Sorted(maps.Keys(kerrors.FromError(err).Metadata))
+			if !slices.Equal(got, slices.Sorted(slices.Values(tt.want))) {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyExpired(t *testing.T) {
+	recent := time.Now().Add(-time.Hour)
+	old := time.Now().Add(-48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		maxAge    time.Duration
+		createdAt time.Time
+		changedAt *time.Time
+		want      bool
+	}{
+		{"never expires", 0, old, nil, false},
+		{"created recently", 24 * time.Hour, recent, nil, false},
+		{"created long ago", 24 * time.Hour, old, nil, true},
+		{"changed recently", 24 * time.Hour, old, &recent, false},
+		{"changed long ago", 24 * time.Hour, recent, &old, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{PasswordChangedAt: tt.changedAt}
+			user.CreatedAt = tt.createdAt
+			if got := (&PasswordPolicy{MaxAge: tt.maxAge}).Expired(user); got != tt.want {
+				t.Fatalf("expired = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,19 +3,23 @@ package biz
 import (
 	"context"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/protobuf"
 )
 
+var ErrPermissionNotFound = errors.New(404, "PERMISSION_NOT_FOUND", "权限不存在")
+
 type Permission struct {
 	ID       int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
-	UID      int64     `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex:idx_uid_uk"`
+	UID      int64     `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
 	Name     string    `json:"name" gorm:"column:name;type:varchar(64);comment:权限名"`
 	Alias    string    `json:"alias" gorm:"column:alias;type:varchar(64);comment:别名,展示名"`
 	Describe string    `json:"describe" gorm:"column:describe;type:varchar(255);comment:描述"`
 	Tags     []string  `json:"tags" gorm:"column:tags;type:json;serializer:json;comment:标签"`
 	Actions  []*Action `json:"actions" gorm:"column:actions;type:json;serializer:json;comment:操作"`
 	Status   int64     `json:"status" gorm:"column:status;type:int;comment:状态"`
+	Version  int64     `json:"version" gorm:"column:version;type:BIGINT;not null;default:1;comment:版本号"` // 每次更新加一, 用于乐观锁
 
 	orm.DBModel
 }
@@ -35,6 +39,7 @@ type PermissionRepo interface {
 	Update(ctx context.Context, id int64, permission *Permission) error
 	Delete(ctx context.Context, id int64) error
 	GetPermission(ctx context.Context, id int64) (*Permission, error)
+	SelectByIDs(ctx context.Context, ids []int64) ([]*Permission, error)
 	SelectList(ctx context.Context, name string, status int32, pagination *protobuf.Pagination) ([]*Permission, error)
 
 	SelectAll(ctx context.Context, scoped bool) ([]*Permission, error)
@@ -67,7 +72,10 @@ func (uc *PermissionUsecase) ListAllPermission(ctx context.Context) ([]*Permissi
 }
 
 func (uc *PermissionUsecase) UpdatePermission(ctx context.Context, permission *Permission) error {
-	return uc.permissionRepo.Update(ctx, permission.ID, permission)
+	if err := checkVersion(permission.Version); err != nil {
+		return err
+	}
+	return uc.permissionRepo.Update(ctx, permission.UID, permission)
 }
 
 func (uc *PermissionUsecase) DeletePermission(ctx context.Context, id int64) error {
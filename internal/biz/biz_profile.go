@@ -0,0 +1,164 @@
+package biz
+
+import (
+	"context"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// UsernameChangeInterval 两次修改用户名的最小间隔
+const UsernameChangeInterval = 30 * 24 * time.Hour
+
+var (
+	ErrUsernameInvalid        = errors.New(400, "USERNAME_INVALID", "用户名只能包含字母, 数字, 下划线, 点和横线, 长度 3-64 位")
+	ErrUsernameChangeTooOften = errors.New(429, "USERNAME_CHANGE_TOO_OFTEN", "修改用户名过于频繁")
+	ErrPasswordIncorrect      = errors.New(400, "PASSWORD_INCORRECT", "当前密码不正确")
+	ErrAvatarNotOwned         = errors.New(403, "AVATAR_NOT_OWNED", "只能使用自己上传的头像")
+)
+
+// usernamePattern 用户名不允许包含 @, 避免与邮箱登录混淆
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,64}$`)
+
+// Profile 用户可自行修改的信息, 字段为空时不修改
+type Profile struct {
+	Nickname string
+	Bio      string
+	// AvatarID 为 nil 时不修改, 为 0 时清除头像
+	AvatarID *int64
+	// Email 修改邮箱前需要先验证新邮箱
+	Email string
+}
+
+// UpdateProfile 修改当前用户的信息
+func (uc *UserUsecase) UpdateProfile(ctx context.Context, uid int64, profile *Profile) error {
+	if profile.Email != "" {
+		if addr, err := mail.ParseAddress(profile.Email); err != nil || addr.Address != profile.Email {
+			return ErrEmailInvalid
+		}
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.SelectUserAccount(ctx, uid)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		if profile.Email != "" && profile.Email != user.Email {
+			curr, err := uc.userRepo.SelectUserByEmail(ctx, profile.Email)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if curr.UID > 0 {
+				return ErrEmailExist
+			}
+		}
+		if profile.AvatarID != nil {
+			if err := checkAvatar(ctx, uc.fileRepo, *profile.AvatarID, uid); err != nil {
+				return err
+			}
+			if err := uc.userRepo.UpdateAvatar(ctx, uid, *profile.AvatarID); err != nil {
+				return err
+			}
+		}
+
+		return uc.userRepo.Update(ctx, uid, &User{
+			Nickname: profile.Nickname,
+			Bio:      profile.Bio,
+			Email:    profile.Email,
+		})
+	})
+}
+
+// UpdateUsername 修改当前用户的用户名, 两次修改之间至少间隔 UsernameChangeInterval
+func (uc *UserUsecase) UpdateUsername(ctx context.Context, uid int64, username string) error {
+	if !usernamePattern.MatchString(username) {
+		return ErrUsernameInvalid
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.SelectUserAccount(ctx, uid)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if user.Username == username {
+			return nil
+		}
+		if user.UsernameChangedAt != nil {
+			if next := user.UsernameChangedAt.Add(UsernameChangeInterval); time.Now().Before(next) {
+				return ErrUsernameChangeTooOften.WithMetadata(map[string]string{
+					"next_allowed_at": next.Format(time.RFC3339),
+				})
+			}
+		}
+
+		curr, err := uc.userRepo.SelectUserByName(ctx, username)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if curr.UID > 0 {
+			return ErrUserExist
+		}
+
+		now := time.Now()
+		return uc.userRepo.Update(ctx, uid, &User{
+			Username:          username,
+			UsernameChangedAt: &now,
+		})
+	})
+}
+
+// ChangePassword 校验当前密码后修改密码
+func (uc *UserUsecase) ChangePassword(ctx context.Context, uid int64, oldPassword string, newPassword string) error {
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.SelectUserAccount(ctx, uid)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if user.Password == "" || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword)) != nil {
+			return ErrPasswordIncorrect
+		}
+
+		if err := uc.passwordManager.Apply(ctx, user, newPassword); err != nil {
+			return err
+		}
+		return uc.userRepo.Update(ctx, uid, &User{
+			Password:          user.Password,
+			PasswordChangedAt: user.PasswordChangedAt,
+		})
+	})
+}
+
+// checkAvatar 检查文件是否可以作为头像, ownerID 大于 0 时要求文件为该用户上传; fileID 为 0 表示清除头像
+func checkAvatar(ctx context.Context, repo FileRepo, fileID int64, ownerID int64) error {
+	if fileID <= 0 {
+		return nil
+	}
+	file, err := repo.SelectByUID(ctx, fileID)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		return ErrFileNotFound
+	}
+	if file.Purpose != FilePurposeAvatar {
+		return ErrFileNotAvatar
+	}
+	if ownerID > 0 && file.OwnerID != ownerID {
+		return ErrAvatarNotOwned
+	}
+	return nil
+}
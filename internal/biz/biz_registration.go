@@ -0,0 +1,317 @@
+package biz
+
+import (
+	"context"
+	"net/mail"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+// 注册模式
+const (
+	RegistrationModeDisabled    = "disabled"
+	RegistrationModeVerifyEmail = "verify_email"
+	RegistrationModeApproval    = "approval"
+)
+
+// DefaultVerificationTTL 邮箱验证链接默认有效期
+const DefaultVerificationTTL = 24 * time.Hour
+
+var (
+	ErrRegistrationDisabled   = errors.New(403, "REGISTRATION_DISABLED", "未开放注册")
+	ErrRegistrationNotPending = errors.New(400, "REGISTRATION_NOT_PENDING", "用户不是待审批状态")
+	ErrVerificationNotFound   = errors.New(404, "VERIFICATION_NOT_FOUND", "验证链接不存在或已失效")
+	ErrVerificationExpired    = errors.New(400, "VERIFICATION_EXPIRED", "验证链接已过期")
+	ErrEmailNotVerified       = errors.New(403, "EMAIL_NOT_VERIFIED", "邮箱尚未验证")
+	ErrAwaitingApproval       = errors.New(403, "AWAITING_APPROVAL", "注册申请等待管理员审批")
+)
+
+// RegistrationPolicy 注册策略
+type RegistrationPolicy struct {
+	Mode         string
+	DefaultRoles []string
+	VerifyTTL    time.Duration
+}
+
+// EmailVerification 注册时的邮箱验证, 仅保存令牌摘要
+type EmailVerification struct {
+	ID         int64      `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID        int64      `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID   int64      `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	UserID     int64      `json:"user_id" gorm:"column:user_id;type:BIGINT;index;comment:用户ID"`
+	Email      string     `json:"email" gorm:"column:email;type:varchar(64);comment:验证的邮箱"`
+	Digest     string     `json:"-" gorm:"column:digest;type:varchar(64);uniqueIndex;comment:令牌摘要"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"column:expires_at;comment:过期时间"`
+	VerifiedAt *time.Time `json:"verified_at" gorm:"column:verified_at;comment:验证时间"`
+
+	orm.DBModel
+}
+
+func (EmailVerification) TableName() string {
+	return "users_email_verification"
+}
+
+type EmailVerificationRepo interface {
+	Create(ctx context.Context, verification *EmailVerification) error
+	SelectByDigest(ctx context.Context, digest string) (*EmailVerification, error)
+	// DeleteByUserID 删除用户未使用的验证
+	DeleteByUserID(ctx context.Context, userID int64) error
+	// Verify 仅当未被使用时标记为已验证, 返回是否标记成功
+	Verify(ctx context.Context, uid int64, verifiedAt time.Time) (bool, error)
+}
+
+type RegistrationUsecase struct {
+	log              *log.Helper
+	txm              orm.Transaction
+	policy           *RegistrationPolicy
+	verificationRepo EmailVerificationRepo
+	userRepo         UserRepo
+	roleRepo         RoleRepo
+	constraintRepo   RoleConstraintRepo
+	passwordManager  *PasswordManager
+}
+
+func NewRegistrationUsecase(policy *RegistrationPolicy, repo EmailVerificationRepo, userRepo UserRepo, roleRepo RoleRepo, constraintRepo RoleConstraintRepo,
+	passwordManager *PasswordManager, txm orm.Transaction, logger log.Logger) *RegistrationUsecase {
+	return &RegistrationUsecase{
+		log:              log.NewHelper(logger),
+		txm:              txm,
+		policy:           policy,
+		verificationRepo: repo,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		constraintRepo:   constraintRepo,
+		passwordManager:  passwordManager,
+	}
+}
+
+func (uc *RegistrationUsecase) Policy() *RegistrationPolicy {
+	return uc.policy
+}
+
+// Register 注册用户, 用户需要通过邮件中的链接验证邮箱; 租户取自 context
+func (uc *RegistrationUsecase) Register(ctx context.Context, user *User, password string, verification *EmailVerification) error {
+	switch uc.policy.Mode {
+	case RegistrationModeVerifyEmail, RegistrationModeApproval:
+	default:
+		return ErrRegistrationDisabled
+	}
+	// 用户必须注册到具体的租户
+	if tenantID, ok := tenant.FromContext(ctx); !ok || tenantID == tenant.PlatformID {
+		return ErrTenantCodeEmpty
+	}
+
+	if !usernamePattern.MatchString(user.Username) {
+		return ErrUsernameInvalid
+	}
+	if addr, err := mail.ParseAddress(user.Email); err != nil || addr.Address != user.Email {
+		return ErrEmailInvalid
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		curr, err := uc.userRepo.SelectUserByName(ctx, user.Username)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if curr.UID > 0 {
+			return ErrUserExist
+		}
+		curr, err = uc.userRepo.SelectUserByEmail(ctx, user.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if curr.UID > 0 {
+			return ErrEmailExist
+		}
+
+		roleIDs, err := uc.defaultRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		user.Password = ""
+		user.Status = UserStatusUnverified
+		if err := uc.passwordManager.Apply(ctx, user, password); err != nil {
+			return err
+		}
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if len(roleIDs) > 0 {
+			if err := uc.userRepo.UpdateRole(ctx, user.UID, roleIDs); err != nil {
+				return err
+			}
+		}
+
+		verification.UserID = user.UID
+		verification.Email = user.Email
+		return uc.verificationRepo.Create(ctx, verification)
+	})
+}
+
+// ResendVerification 为尚未验证邮箱的用户重新生成验证, 用户不存在或已验证时返回 nil
+func (uc *RegistrationUsecase) ResendVerification(ctx context.Context, email string, verification *EmailVerification) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		curr, err := uc.userRepo.SelectUserByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if curr.UID <= 0 || curr.Status != UserStatusUnverified {
+			return nil
+		}
+
+		if err := uc.verificationRepo.DeleteByUserID(ctx, curr.UID); err != nil {
+			return err
+		}
+		verification.UserID = curr.UID
+		verification.Email = curr.Email
+		if err := uc.verificationRepo.Create(ctx, verification); err != nil {
+			return err
+		}
+		user = curr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// VerifyEmail 验证邮箱, 审批模式下用户进入待审批状态, 否则直接启用
+func (uc *RegistrationUsecase) VerifyEmail(ctx context.Context, digest string) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		verification, err := uc.verificationRepo.SelectByDigest(ctx, digest)
+		if err != nil {
+			return err
+		}
+		if verification == nil || verification.VerifiedAt != nil {
+			return ErrVerificationNotFound
+		}
+		now := time.Now()
+		if now.After(verification.ExpiresAt) {
+			return ErrVerificationExpired
+		}
+
+		user, err = uc.userRepo.SelectUserAccount(ctx, verification.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrVerificationNotFound
+			}
+			return err
+		}
+		if user.Status != UserStatusUnverified || user.Email != verification.Email {
+			return ErrVerificationNotFound
+		}
+
+		ok, err := uc.verificationRepo.Verify(ctx, verification.UID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrVerificationNotFound
+		}
+
+		user.Status = UserStatusNormal
+		if uc.policy.Mode == RegistrationModeApproval {
+			user.Status = UserStatusAwaitingApproval
+		}
+		return uc.userRepo.UpdateStatus(ctx, user.UID, user.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListPending 待审批的注册用户
+func (uc *RegistrationUsecase) ListPending(ctx context.Context, pagination *protobuf.Pagination) ([]*UserInfo, error) {
+	return uc.userRepo.SelectList(ctx, pagination, &UserQueryFilter{
+		Status: int(UserStatusAwaitingApproval),
+	})
+}
+
+// Approve 通过注册申请
+func (uc *RegistrationUsecase) Approve(ctx context.Context, uid int64) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = uc.selectPending(ctx, uid); err != nil {
+			return err
+		}
+		user.Status = UserStatusNormal
+		return uc.userRepo.UpdateStatus(ctx, uid, user.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Reject 拒绝注册申请, 用户被删除
+func (uc *RegistrationUsecase) Reject(ctx context.Context, uid int64) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = uc.selectPending(ctx, uid); err != nil {
+			return err
+		}
+		return uc.userRepo.Delete(ctx, uid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (uc *RegistrationUsecase) selectPending(ctx context.Context, uid int64) (*User, error) {
+	user, err := uc.userRepo.SelectUserAccount(ctx, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.Status != UserStatusAwaitingApproval {
+		return nil, ErrRegistrationNotPending
+	}
+	return user, nil
+}
+
+// defaultRoles 按名称查找注册用户的默认角色, 不存在的角色忽略
+func (uc *RegistrationUsecase) defaultRoles(ctx context.Context) ([]int64, error) {
+	if len(uc.policy.DefaultRoles) == 0 {
+		return nil, nil
+	}
+
+	roles, err := uc.roleRepo.GetAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int64, 0, len(uc.policy.DefaultRoles))
+	for _, role := range roles {
+		for _, name := range uc.policy.DefaultRoles {
+			if role.Name == name {
+				roleIDs = append(roleIDs, role.UID)
+			}
+		}
+	}
+	if len(roleIDs) < len(uc.policy.DefaultRoles) {
+		uc.log.Warnf("some default roles %v for registration are not found", uc.policy.DefaultRoles)
+	}
+	if err := checkRoleConflict(ctx, uc.constraintRepo, roleIDs); err != nil {
+		return nil, err
+	}
+	return roleIDs, nil
+}
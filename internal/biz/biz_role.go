@@ -4,19 +4,31 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/kratos/orm/crud"
 	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrRoleNotFound   = errors.New(404, "ROLE_NOT_FOUND", "角色不存在")
+	ErrRoleExist      = errors.New(400, "ROLE_EXIST", "角色已存在")
+	ErrRoleNameEmpty  = errors.New(400, "ROLE_NAME_EMPTY", "角色名称不能为空")
+	ErrRoleIsTemplate = errors.New(400, "ROLE_IS_TEMPLATE", "模板角色不能分配给用户")
 )
 
 type Role struct {
-	ID       int64  `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
-	UID      int64  `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex:idx_uid_uk"`
-	Name     string `json:"name" gorm:"column:name;type:varchar(64);comment:角色唯一标识"`
-	Alias    string `json:"alias" gorm:"column:alias;type:varchar(64);comment:角色别名"`
-	Describe string `json:"describe" gorm:"column:describe;type:varchar(255);comment:描述"`
-	Status   int64  `json:"status" gorm:"column:status;type:int;comment:状态"`
+	ID         int64  `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID        int64  `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID   int64  `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Name       string `json:"name" gorm:"column:name;type:varchar(64);comment:角色唯一标识"`
+	Alias      string `json:"alias" gorm:"column:alias;type:varchar(64);comment:角色别名"`
+	Describe   string `json:"describe" gorm:"column:describe;type:varchar(255);comment:描述"`
+	Status     int64  `json:"status" gorm:"column:status;type:int;comment:状态"`
+	IsTemplate bool   `json:"is_template" gorm:"column:is_template;type:boolean;comment:是否模板角色"`        // 模板角色不能分配给用户, 仅作为克隆来源
+	Version    int64  `json:"version" gorm:"column:version;type:BIGINT;not null;default:1;comment:版本号"` // 每次更新加一, 用于乐观锁
 
 	Permissions []*RolePermission `json:"permissions" gorm:"-"`
 
@@ -29,11 +41,12 @@ func (Role) TableName() string {
 
 type RolePermission struct {
 	ID         int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
-	RoleID     int64     `json:"role_id" gorm:"column:role_id;type:BIGINT;comment:角色ID"`
-	PermID     int64     `json:"perm_id" gorm:"column:perm_id;type:BIGINT;comment:权限ID"`
+	TenantID   int64     `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	RoleID     int64     `json:"role_id" gorm:"column:role_id;type:BIGINT;uniqueIndex:idx_unique_role_perm;comment:角色ID"`
+	PermID     int64     `json:"perm_id" gorm:"column:perm_id;type:BIGINT;uniqueIndex:idx_unique_role_perm;comment:权限ID"`
 	Actions    []*Action `json:"actions" gorm:"column:actions;type:json;serializer:json;comment:操作"`
 	DataAccess []*Action `json:"data_access,omitempty" gorm:"column:data_access;type:json;serializer:json;comment:数据权限"`
-	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;type:datetime;comment:创建时间"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;comment:创建时间"`
 	Name       string    `json:"name" gorm:"column:name;->"`
 	Alias      string    `json:"alias" gorm:"column:alias;->"`
 }
@@ -42,6 +55,13 @@ func (RolePermission) TableName() string {
 	return "roles_bind_permission"
 }
 
+// RolePermissionDiff 全量设置角色权限时的变更结果
+type RolePermissionDiff struct {
+	Added   []int64 `json:"added"`
+	Updated []int64 `json:"updated"`
+	Removed []int64 `json:"removed"`
+}
+
 type RoleJoinPermission struct {
 	Role
 
@@ -59,24 +79,38 @@ type RoleRepo interface {
 	SelectByUserID(ctx context.Context, userID int64) ([]*Role, error)
 	SelectID(ctx context.Context, id int64) (*RoleJoinPermission, error)
 	SelectRolePermission(ctx context.Context, roleIDs []int64) ([]*RoleJoinPermission, error)
-	GetAll(ctx context.Context) ([]*Role, error)
+	SelectByIDs(ctx context.Context, roleIDs []int64) ([]*Role, error)
+	GetAll(ctx context.Context, includeTemplate bool) ([]*Role, error)
+	Exist(ctx context.Context, name string) bool
 
 	Update(ctx context.Context, id int64, role *Role) error
+	// UpdateColumns 更新 role 中 fields 指定的列
+	UpdateColumns(ctx context.Context, id int64, role *Role, fields []string) error
 	BindPermission(ctx context.Context, roleID int64, permissionID int64, actions []*Action, dataAccess []*Action) error
+	CreatePermissions(ctx context.Context, bindings []*RolePermission) error
+	SelectPermissions(ctx context.Context, roleID int64) ([]*RolePermission, error)
+	UpsertPermissions(ctx context.Context, bindings []*RolePermission) error
+	DeletePermissions(ctx context.Context, roleID int64, permissionIDs []int64) error
 	UnbindPermission(ctx context.Context, roleID int64, permissionID int64) error
 }
 
 type RoleUsecase struct {
-	log      *log.Helper
-	txm      orm.Transaction
-	roleRepo RoleRepo
+	log            *log.Helper
+	txm            orm.Transaction
+	roleRepo       RoleRepo
+	permissionRepo PermissionRepo
+	constraintRepo RoleConstraintRepo
+	authz          *AuthzCache
 }
 
-func NewRoleUsecase(repo RoleRepo, txm orm.Transaction, logger log.Logger) *RoleUsecase {
+func NewRoleUsecase(repo RoleRepo, permissionRepo PermissionRepo, constraintRepo RoleConstraintRepo, authz *AuthzCache, txm orm.Transaction, logger log.Logger) *RoleUsecase {
 	return &RoleUsecase{
-		log:      log.NewHelper(logger),
-		txm:      txm,
-		roleRepo: repo,
+		log:            log.NewHelper(logger),
+		txm:            txm,
+		roleRepo:       repo,
+		permissionRepo: permissionRepo,
+		constraintRepo: constraintRepo,
+		authz:          authz,
 	}
 }
 
@@ -86,16 +120,33 @@ func (uc *RoleUsecase) CreateRole(ctx context.Context, role *Role) error {
 	})
 }
 
-func (uc *RoleUsecase) UpdateRole(ctx context.Context, role *Role) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
-		return uc.roleRepo.Update(ctx, role.ID, role)
+// UpdateRole 更新角色, 只修改 fields 指定的列 (name, alias, describe, status, is_template)
+func (uc *RoleUsecase) UpdateRole(ctx context.Context, role *Role, fields ...string) error {
+	if err := checkVersion(role.Version); err != nil {
+		return err
+	}
+	if lo.Contains(fields, "name") && role.Name == "" {
+		return ErrRoleNameEmpty
+	}
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		return uc.roleRepo.UpdateColumns(ctx, role.UID, role, fields)
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.rolesChanged(ctx, role.UID)
+	return nil
 }
 
 func (uc *RoleUsecase) DeleteRole(ctx context.Context, id int64) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
 		return uc.roleRepo.Delete(ctx, id)
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.rolesChanged(ctx, id)
+	return nil
 }
 
 func (uc *RoleUsecase) ListRole(ctx context.Context, pagination *protobuf.Pagination) ([]*RoleJoinPermission, error) {
@@ -111,18 +162,176 @@ func (uc *RoleUsecase) SelectID(ctx context.Context, id int64) (*RoleJoinPermiss
 }
 
 func (uc *RoleUsecase) BindPermission(ctx context.Context, roleID int64, permissionID int64, actions []*Action, dataAccess []*Action) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
 		err := uc.roleRepo.BindPermission(ctx, roleID, permissionID, actions, dataAccess)
 		return err
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.rolesChanged(ctx, roleID)
+	return nil
+}
+
+// SetRolePermissions 全量替换角色的权限绑定, 返回新增、更新、解绑的权限
+func (uc *RoleUsecase) SetRolePermissions(ctx context.Context, roleID int64, bindings []*RolePermission) (*RolePermissionDiff, error) {
+	diff := &RolePermissionDiff{}
+
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		roles, err := uc.roleRepo.SelectByIDs(ctx, []int64{roleID})
+		if err != nil {
+			return err
+		}
+		if len(roles) <= 0 {
+			return ErrRoleNotFound
+		}
+		if err := uc.checkPermissionExist(ctx, bindings); err != nil {
+			return err
+		}
+
+		existing, err := uc.roleRepo.SelectPermissions(ctx, roleID)
+		if err != nil {
+			return err
+		}
+		existingMap := lo.KeyBy(existing, func(item *RolePermission) int64 {
+			return item.PermID
+		})
+		// 同一个权限重复提交时, 以最后一次为准
+		wantMap := lo.KeyBy(bindings, func(item *RolePermission) int64 {
+			return item.PermID
+		})
+
+		changed := make([]*RolePermission, 0, len(wantMap))
+		for _, item := range bindings {
+			if wantMap[item.PermID] != item {
+				continue
+			}
+			item.RoleID = roleID
+
+			last, exist := existingMap[item.PermID]
+			if !exist {
+				diff.Added = append(diff.Added, item.PermID)
+				changed = append(changed, item)
+				continue
+			}
+			if !sameActions(last.Actions, item.Actions) || !sameActions(last.DataAccess, item.DataAccess) {
+				diff.Updated = append(diff.Updated, item.PermID)
+				changed = append(changed, item)
+			}
+		}
+
+		for _, item := range existing {
+			if _, ok := wantMap[item.PermID]; !ok {
+				diff.Removed = append(diff.Removed, item.PermID)
+			}
+		}
+
+		if len(diff.Removed) > 0 {
+			if err := uc.roleRepo.DeletePermissions(ctx, roleID, diff.Removed); err != nil {
+				return err
+			}
+		}
+		if len(changed) > 0 {
+			return uc.roleRepo.UpsertPermissions(ctx, changed)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.authz.rolesChanged(ctx, roleID)
+	return diff, nil
+}
+
+// checkPermissionExist 绑定的权限必须都存在
+func (uc *RoleUsecase) checkPermissionExist(ctx context.Context, bindings []*RolePermission) error {
+	if len(bindings) <= 0 {
+		return nil
+	}
+	permIDs := lo.Uniq(lo.Map(bindings, func(item *RolePermission, _ int) int64 {
+		return item.PermID
+	}))
+	permissions, err := uc.permissionRepo.SelectByIDs(ctx, permIDs)
+	if err != nil {
+		return err
+	}
+	if len(permissions) != len(permIDs) {
+		return ErrPermissionNotFound
+	}
+	return nil
 }
 
 func (uc *RoleUsecase) UnbindPermission(ctx context.Context, roleID int64, permissionID int64) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
 		return uc.roleRepo.UnbindPermission(ctx, roleID, permissionID)
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.rolesChanged(ctx, roleID)
+	return nil
 }
 
-func (uc *RoleUsecase) GetAll(ctx context.Context) ([]*Role, error) {
-	return uc.roleRepo.GetAll(ctx)
+func (uc *RoleUsecase) GetAll(ctx context.Context, includeTemplate bool) ([]*Role, error) {
+	return uc.roleRepo.GetAll(ctx, includeTemplate)
+}
+
+// CloneRole 克隆角色, 新角色复制来源角色的全部权限绑定
+func (uc *RoleUsecase) CloneRole(ctx context.Context, sourceID int64, role *Role) error {
+	if role.Name == "" {
+		return ErrRoleNameEmpty
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		source, err := uc.roleRepo.SelectID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		if source == nil || source.UID == 0 {
+			return ErrRoleNotFound
+		}
+
+		if uc.roleRepo.Exist(ctx, role.Name) {
+			return ErrRoleExist
+		}
+
+		if role.Status == 0 {
+			role.Status = source.Status
+		}
+		if err := uc.roleRepo.Create(ctx, role); err != nil {
+			return err
+		}
+
+		if len(source.Permissions) == 0 {
+			return nil
+		}
+
+		bindings := lo.Map(source.Permissions, func(item *RolePermission, _ int) *RolePermission {
+			return &RolePermission{
+				RoleID:     role.UID,
+				PermID:     item.PermID,
+				Actions:    item.Actions,
+				DataAccess: item.DataAccess,
+			}
+		})
+		return uc.roleRepo.CreatePermissions(ctx, bindings)
+	})
+}
+
+// sameActions 比较两组动作是否一致 (忽略顺序)
+func sameActions(a, b []*Action) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counter := make(map[Action]int, len(a))
+	for _, item := range a {
+		counter[*item]++
+	}
+	for _, item := range b {
+		if counter[*item] <= 0 {
+			return false
+		}
+		counter[*item]--
+	}
+	return true
 }
@@ -0,0 +1,144 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrRoleConflict               = errors.New(400, "ROLE_CONFLICT", "角色互斥, 不能同时持有")
+	ErrRoleConstraintNotFound     = errors.New(404, "ROLE_CONSTRAINT_NOT_FOUND", "角色互斥约束不存在")
+	ErrRoleConstraintExist        = errors.New(400, "ROLE_CONSTRAINT_EXIST", "角色互斥约束已存在")
+	ErrRoleConstraintInvalidRoles = errors.New(400, "ROLE_CONSTRAINT_INVALID_ROLES", "互斥约束需要两个不同的角色")
+	ErrRoleConstraintNameEmpty    = errors.New(400, "ROLE_CONSTRAINT_NAME_EMPTY", "约束名称不能为空")
+)
+
+// RoleConstraint 角色互斥约束 (职责分离), 同一用户不能同时持有 RoleA 和 RoleB
+type RoleConstraint struct {
+	ID       int64  `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID int64  `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Name     string `json:"name" gorm:"column:name;type:varchar(64);comment:约束名称"`
+	Describe string `json:"describe" gorm:"column:describe;type:varchar(255);comment:描述"`
+	RoleA    int64  `json:"role_a" gorm:"column:role_a;type:BIGINT;uniqueIndex:idx_unique_role_constraint;comment:角色A"` // RoleA < RoleB
+	RoleB    int64  `json:"role_b" gorm:"column:role_b;type:BIGINT;uniqueIndex:idx_unique_role_constraint;comment:角色B"`
+
+	orm.DBModel
+}
+
+func (RoleConstraint) TableName() string {
+	return "roles_constraint"
+}
+
+// RoleConstraintViolation 违反互斥约束的用户
+type RoleConstraintViolation struct {
+	UserID   int64  `json:"user_id" gorm:"column:user_id"`
+	Username string `json:"username" gorm:"column:username"`
+	Nickname string `json:"nickname" gorm:"column:nickname"`
+}
+
+type RoleConstraintRepo interface {
+	Create(ctx context.Context, constraint *RoleConstraint) error
+	Delete(ctx context.Context, uid int64) error
+	SelectByUID(ctx context.Context, uid int64) (*RoleConstraint, error)
+	SelectList(ctx context.Context, pagination *protobuf.Pagination) ([]*RoleConstraint, error)
+	Exist(ctx context.Context, roleA int64, roleB int64) bool
+
+	// SelectWithin 查询两端角色都在 roleIDs 内的约束
+	SelectWithin(ctx context.Context, roleIDs []int64) ([]*RoleConstraint, error)
+	// SelectViolations 查询同时持有约束两端角色的用户
+	SelectViolations(ctx context.Context, constraint *RoleConstraint) ([]*RoleConstraintViolation, error)
+}
+
+// CreateRoleConstraint 创建角色互斥约束, 返回已违反该约束的用户数量
+func (uc *RoleUsecase) CreateRoleConstraint(ctx context.Context, constraint *RoleConstraint) (int, error) {
+	if constraint.Name == "" {
+		return 0, ErrRoleConstraintNameEmpty
+	}
+	if constraint.RoleA <= 0 || constraint.RoleB <= 0 || constraint.RoleA == constraint.RoleB {
+		return 0, ErrRoleConstraintInvalidRoles
+	}
+	// 统一顺序, 保证 (A, B) 与 (B, A) 是同一条约束
+	if constraint.RoleA > constraint.RoleB {
+		constraint.RoleA, constraint.RoleB = constraint.RoleB, constraint.RoleA
+	}
+
+	var violations []*RoleConstraintViolation
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		roles, err := uc.roleRepo.SelectByIDs(ctx, []int64{constraint.RoleA, constraint.RoleB})
+		if err != nil {
+			return err
+		}
+		if len(roles) != 2 {
+			return ErrRoleNotFound
+		}
+
+		if uc.constraintRepo.Exist(ctx, constraint.RoleA, constraint.RoleB) {
+			return ErrRoleConstraintExist
+		}
+		if err := uc.constraintRepo.Create(ctx, constraint); err != nil {
+			return err
+		}
+
+		// 新约束不会自动解除存量授权, 仅报告违反的用户
+		violations, err = uc.constraintRepo.SelectViolations(ctx, constraint)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(violations) > 0 {
+		uc.log.Warnf("role constraint %s created, %d users violate it", constraint.Name, len(violations))
+	}
+	return len(violations), nil
+}
+
+func (uc *RoleUsecase) DeleteRoleConstraint(ctx context.Context, uid int64) error {
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		return uc.constraintRepo.Delete(ctx, uid)
+	})
+}
+
+func (uc *RoleUsecase) ListRoleConstraint(ctx context.Context, pagination *protobuf.Pagination) ([]*RoleConstraint, error) {
+	return uc.constraintRepo.SelectList(ctx, pagination)
+}
+
+// ListRoleConstraintViolation 违反指定互斥约束的用户
+func (uc *RoleUsecase) ListRoleConstraintViolation(ctx context.Context, uid int64) (*RoleConstraint, []*RoleConstraintViolation, error) {
+	constraint, err := uc.constraintRepo.SelectByUID(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if constraint == nil {
+		return nil, nil, ErrRoleConstraintNotFound
+	}
+
+	violations, err := uc.constraintRepo.SelectViolations(ctx, constraint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return constraint, violations, nil
+}
+
+// checkRoleConflict 检查一组角色中是否存在互斥的角色
+func checkRoleConflict(ctx context.Context, repo RoleConstraintRepo, roleIDs []int64) error {
+	roleIDs = lo.Uniq(roleIDs)
+	if len(roleIDs) < 2 {
+		return nil
+	}
+
+	constraints, err := repo.SelectWithin(ctx, roleIDs)
+	if err != nil {
+		return err
+	}
+	if len(constraints) > 0 {
+		return ErrRoleConflict.WithMetadata(map[string]string{
+			"constraint": constraints[0].Name,
+		})
+	}
+	return nil
+}
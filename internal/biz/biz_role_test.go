@@ -0,0 +1,33 @@
+package biz
+
+import "testing"
+
+func TestSameActions(t *testing.T) {
+	read := &Action{Key: "READ", Describe: "查看", Checked: true}
+	update := &Action{Key: "UPDATE", Describe: "更新", Checked: true}
+
+	tests := []struct {
+		name string
+		a, b []*Action
+		want bool
+	}{
+		{"both empty", nil, []*Action{}, true},
+		{"same order", []*Action{read, update}, []*Action{read, update}, true},
+		{"different order", []*Action{read, update}, []*Action{update, read}, true},
+		{"equal values", []*Action{read}, []*Action{{Key: "READ", Describe: "查看", Checked: true}}, true},
+		{"different length", []*Action{read}, []*Action{read, update}, false},
+		{"different key", []*Action{read}, []*Action{update}, false},
+		{"different checked", []*Action{read}, []*Action{{Key: "READ", Describe: "查看"}}, false},
+		{"duplicates", []*Action{read, read}, []*Action{read, update}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameActions(tt.a, tt.b); got != tt.want {
+				t.Fatalf("sameActions(a, b) = %v, want %v", got, tt.want)
+			}
+			if got := sameActions(tt.b, tt.a); got != tt.want {
+				t.Fatalf("sameActions(b, a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+var (
+	ErrTenantNotFound  = errors.New(404, "TENANT_NOT_FOUND", "租户不存在")
+	ErrTenantExist     = errors.New(400, "TENANT_EXIST", "租户编码已存在")
+	ErrTenantCodeEmpty = errors.New(400, "TENANT_CODE_EMPTY", "租户编码不能为空")
+	ErrTenantDisabled  = errors.New(403, "TENANT_DISABLED", "租户已禁用")
+	ErrTenantForbidden = errors.New(403, "TENANT_FORBIDDEN", "仅平台管理员可以管理租户")
+)
+
+// DefaultTenantCode 初始化数据创建的默认租户
+const DefaultTenantCode = "default"
+
+// Tenant 租户, 租户内的用户、角色、菜单等数据通过 tenant_id 隔离.
+// tenant_id 为 0 的数据属于平台 (见 tenant.PlatformID), 只有菜单可以属于平台.
+type Tenant struct {
+	ID       int64  `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	Code     string `json:"code" gorm:"column:code;type:varchar(64);uniqueIndex:idx_unique_tenant_code;comment:租户编码"`
+	Name     string `json:"name" gorm:"column:name;type:varchar(64);comment:租户名称"`
+	Describe string `json:"describe" gorm:"column:describe;type:varchar(255);comment:描述"`
+	Status   int64  `json:"status" gorm:"column:status;type:int;comment:状态"` // 0: 禁用, 1: 正常
+
+	orm.DBModel
+}
+
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+type TenantRepo interface {
+	Create(ctx context.Context, t *Tenant) error
+	Update(ctx context.Context, uid int64, t *Tenant) error
+	Delete(ctx context.Context, uid int64) error
+	SelectByUID(ctx context.Context, uid int64) (*Tenant, error)
+	SelectByCode(ctx context.Context, code string) (*Tenant, error)
+	SelectList(ctx context.Context, pagination *protobuf.Pagination) ([]*Tenant, error)
+}
+
+type TenantUsecase struct {
+	log        *log.Helper
+	txm        orm.Transaction
+	tenantRepo TenantRepo
+}
+
+func NewTenantUsecase(repo TenantRepo, txm orm.Transaction, logger log.Logger) *TenantUsecase {
+	return &TenantUsecase{
+		log:        log.NewHelper(logger),
+		txm:        txm,
+		tenantRepo: repo,
+	}
+}
+
+func (uc *TenantUsecase) CreateTenant(ctx context.Context, t *Tenant) error {
+	if !tenant.IsSuperAdmin(ctx) {
+		return ErrTenantForbidden
+	}
+	if t.Code == "" {
+		return ErrTenantCodeEmpty
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		exist, err := uc.tenantRepo.SelectByCode(ctx, t.Code)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			return ErrTenantExist
+		}
+		return uc.tenantRepo.Create(ctx, t)
+	})
+}
+
+func (uc *TenantUsecase) UpdateTenant(ctx context.Context, t *Tenant) error {
+	if !tenant.IsSuperAdmin(ctx) {
+		return ErrTenantForbidden
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.selectByUID(ctx, t.UID); err != nil {
+			return err
+		}
+		return uc.tenantRepo.Update(ctx, t.UID, t)
+	})
+}
+
+func (uc *TenantUsecase) DeleteTenant(ctx context.Context, uid int64) error {
+	if !tenant.IsSuperAdmin(ctx) {
+		return ErrTenantForbidden
+	}
+	return uc.tenantRepo.Delete(ctx, uid)
+}
+
+func (uc *TenantUsecase) GetTenant(ctx context.Context, uid int64) (*Tenant, error) {
+	if !tenant.IsSuperAdmin(ctx) {
+		return nil, ErrTenantForbidden
+	}
+	return uc.selectByUID(ctx, uid)
+}
+
+func (uc *TenantUsecase) ListTenant(ctx context.Context, pagination *protobuf.Pagination) ([]*Tenant, error) {
+	if !tenant.IsSuperAdmin(ctx) {
+		return nil, ErrTenantForbidden
+	}
+	return uc.tenantRepo.SelectList(ctx, pagination)
+}
+
+// Resolve 登录及注册时根据租户编码确定租户, 用户必须属于某个租户
+func (uc *TenantUsecase) Resolve(ctx context.Context, code string) (int64, error) {
+	if code == "" {
+		return 0, ErrTenantCodeEmpty
+	}
+
+	t, err := uc.tenantRepo.SelectByCode(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+	if t == nil {
+		return 0, ErrTenantNotFound
+	}
+	if t.Status != 1 {
+		return 0, ErrTenantDisabled
+	}
+	return t.UID, nil
+}
+
+func (uc *TenantUsecase) selectByUID(ctx context.Context, uid int64) (*Tenant, error) {
+	t, err := uc.tenantRepo.SelectByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTenantNotFound
+	}
+	return t, nil
+}
@@ -14,28 +14,53 @@ import (
 	"gorm.io/gorm"
 
 	passportpb "github.com/omalloc/kratos-admin/api/console/passport"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
 )
 
 type User struct {
 	ID        int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement;"`
-	UID       int64     `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex:idx_uid_uk"`
-	Username  string    `json:"username" gorm:"column:username;type:varchar(64);comment:用户名"`
+	UID       int64     `json:"uid" gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID  int64     `json:"tenant_id" gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Username  string    `json:"username" gorm:"column:username;type:varchar(64);index;comment:用户名"`
 	Password  string    `json:"-" gorm:"column:password;type:varchar(64);comment:密码"`
-	Email     string    `json:"email" gorm:"column:email;type:varchar(64);comment:邮箱"`
+	Email     string    `json:"email" gorm:"column:email;type:varchar(64);index;comment:邮箱"`
 	Nickname  string    `json:"nickname" gorm:"column:nickname;type:varchar(64);comment:昵称"`
 	Bio       string    `json:"bio" gorm:"column:bio;type:varchar(255);comment:个人简介"`
 	AvatarID  int64     `json:"avatar_id" gorm:"column:avatar_id;comment:头像"`
-	Status    int64     `json:"status" gorm:"column:status;type:int;comment:状态"` // 0: 禁用, 1: 正常
-	LastLogin time.Time `json:"last_login" gorm:"column:last_login;comment:上次登录时间"`
+	Status    int64     `json:"status" gorm:"column:status;type:int;index;comment:状态"` // 1: 正常, 2: 禁用, 3: 已删除, 4: 待激活, 5: 待验证邮箱, 6: 待审批
+	LastLogin time.Time `json:"last_login" gorm:"column:last_login;index;comment:上次登录时间"`
 
-	orm.DBModel
-}
+	PasswordChangedAt *time.Time `json:"password_changed_at" gorm:"column:password_changed_at;comment:密码修改时间"`
+	UsernameChangedAt *time.Time `json:"username_changed_at" gorm:"column:username_changed_at;comment:用户名修改时间"`
+	PurgedAt          *time.Time `json:"purged_at" gorm:"column:purged_at;comment:清除时间"`
+	// SuperAdmin 超级管理员可以跨租户操作, 只能由初始化数据或数据库设置
+	SuperAdmin bool `json:"super_admin" gorm:"column:super_admin;not null;default:false;comment:是否超级管理员"`
 
-type UserQueryFilter struct {
-	Status   int
-	Username string
+	orm.DBModel // created_at 的索引 idx_users_created_at 由迁移创建
 }
 
+var (
+	ErrUserNotFound  = errors.New(404, "USER_NOT_FOUND", "用户不存在")
+	ErrUserExist     = errors.New(400, "USER_EXIST", "用户存在")
+	ErrUsernameEmpty = errors.New(400, "USERNAME_EMPTY", "用户名不能为空")
+	ErrEmailInvalid  = errors.New(400, "EMAIL_INVALID", "邮箱格式不正确")
+	ErrEmailExist    = errors.New(400, "EMAIL_EXIST", "邮箱已被使用")
+	// 管理员只能启用或禁用用户, 删除走 DeleteUser
+	ErrUserStatusInvalid = errors.New(400, "USER_STATUS_INVALID", "用户状态只能修改为正常或禁用")
+)
+
+// 用户状态, 与 pb.UserStatus 保持一致
+const (
+	UserStatusNormal   int64 = 1
+	UserStatusDisabled int64 = 2
+	UserStatusDeleted  int64 = 3
+	UserStatusPending  int64 = 4
+	// 自助注册, 尚未验证邮箱
+	UserStatusUnverified int64 = 5
+	// 自助注册, 等待管理员审批
+	UserStatusAwaitingApproval int64 = 6
+)
+
 type UserInfo struct {
 	User
 
@@ -53,10 +78,12 @@ func (User) TableName() string {
 }
 
 type UserRole struct {
-	ID        int64     `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
-	UserID    int64     `json:"user_id" gorm:"column:user_id;type:BIGINT;uniqueIndex:idx_unique_user_role;comment:用户ID"`
-	RoleID    int64     `json:"role_id" gorm:"column:role_id;type:BIGINT;uniqueIndex:idx_unique_user_role;comment:角色ID"`
-	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:datetime;comment:创建时间"` // 授权时间
+	ID        int64      `json:"id" gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UserID    int64      `json:"user_id" gorm:"column:user_id;type:BIGINT;uniqueIndex:idx_unique_user_role;comment:用户ID"`
+	RoleID    int64      `json:"role_id" gorm:"column:role_id;type:BIGINT;uniqueIndex:idx_unique_user_role;comment:角色ID"`
+	ExpiresAt *time.Time `json:"expires_at" gorm:"column:expires_at;index;comment:过期时间"` // 为空表示永久有效
+	GrantedBy int64      `json:"granted_by" gorm:"column:granted_by;type:BIGINT;comment:授权人"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;comment:创建时间"` // 授权时间
 }
 
 func (UserRole) TableName() string {
@@ -66,79 +93,127 @@ func (UserRole) TableName() string {
 type UserRepo interface {
 	SelectList(ctx context.Context, pagination *protobuf.Pagination, filter *UserQueryFilter) ([]*UserInfo, error)
 	SelectUserByUID(ctx context.Context, uid int64) (*UserInfo, error)
+	// SelectUserAccount 查询包含密码等账号信息的用户
+	SelectUserAccount(ctx context.Context, uid int64) (*User, error)
 	SelectUserByName(ctx context.Context, name string) (*User, error)
 	SelectUserByEmail(ctx context.Context, email string) (*User, error)
 	SelectUserByNameOrEmail(ctx context.Context, value string) (*User, error)
 
-	BindRole(ctx context.Context, userID int64, roleID int64) error
+	// SelectRoleBindings 用户有效 (未过期) 的角色授权
+	SelectRoleBindings(ctx context.Context, uid int64) ([]*UserRole, error)
+	BindRole(ctx context.Context, binding *UserRole) error
 	UnbindRole(ctx context.Context, userID int64, roleID int64) error
 	UpdateRole(ctx context.Context, userID int64, roleIDs []int64) error
+	DeleteExpiredRoles(ctx context.Context, now time.Time) ([]*UserRole, error)
 
 	Create(ctx context.Context, user *User) error
 	Update(ctx context.Context, uid int64, user *User) error
 	Delete(ctx context.Context, uid int64) error
 	UpdateStatus(ctx context.Context, uid int64, status int64) error
 	UpdateLastLogin(ctx context.Context, uid int64) error
+	UpdateAvatar(ctx context.Context, uid int64, avatarID int64) error
+
+	// SelectDeletedList 已删除但尚未清除的用户
+	SelectDeletedList(ctx context.Context, pagination *protobuf.Pagination) ([]*UserInfo, error)
+	SelectDeletedUser(ctx context.Context, uid int64) (*User, error)
+	// SelectDeletedBefore 在 before 之前删除且尚未清除的用户
+	SelectDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*User, error)
+	Restore(ctx context.Context, uid int64, status int64) error
+	// Purge 删除用户的关联数据并匿名化用户信息
+	Purge(ctx context.Context, uid int64, purgedAt time.Time) error
+	// ClearAvatar 清除使用该文件作为头像的用户头像
+	ClearAvatar(ctx context.Context, avatarID int64) error
+	// CountByAvatar 使用该文件作为头像的用户数
+	CountByAvatar(ctx context.Context, avatarID int64) (int64, error)
 }
 
 type UserUsecase struct {
-	log      *log.Helper
-	txm      orm.Transaction
-	userRepo UserRepo
-	roleRepo RoleRepo
+	log             *log.Helper
+	txm             orm.Transaction
+	userRepo        UserRepo
+	roleRepo        RoleRepo
+	constraintRepo  RoleConstraintRepo
+	fileRepo        FileRepo
+	passwordManager *PasswordManager
+	authz           *AuthzCache
 }
 
-func NewUserUsecase(repo UserRepo, roleRepo RoleRepo, txm orm.Transaction, logger log.Logger) *UserUsecase {
+func NewUserUsecase(repo UserRepo, roleRepo RoleRepo, constraintRepo RoleConstraintRepo, fileRepo FileRepo, passwordManager *PasswordManager, authz *AuthzCache, txm orm.Transaction, logger log.Logger) *UserUsecase {
 	return &UserUsecase{
-		userRepo: repo,
-		roleRepo: roleRepo,
-		txm:      txm,
-		log:      log.NewHelper(logger),
+		userRepo:        repo,
+		roleRepo:        roleRepo,
+		constraintRepo:  constraintRepo,
+		fileRepo:        fileRepo,
+		passwordManager: passwordManager,
+		authz:           authz,
+		txm:             txm,
+		log:             log.NewHelper(logger),
 	}
 }
 
-func (uc *UserUsecase) CreateUser(ctx context.Context, user *User) error {
-	hp, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
+// CreateUser 创建用户并绑定角色, 角色检查失败时用户也不会被创建
+func (uc *UserUsecase) CreateUser(ctx context.Context, user *User, roleIDs []int64) error {
+	if err := uc.passwordManager.Check(user.Password, user.Username); err != nil {
 		return err
 	}
-	user.Password = string(hp)
+	password := user.Password
+	user.Password = ""
 
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
 		curr, err1 := uc.userRepo.SelectUserByName(ctx, user.Username)
 		if err1 != nil && !errors.Is(err1, gorm.ErrRecordNotFound) {
 			uc.log.Errorf("SelectUserByName error: %v", err1)
-			return errors.New(400, "USER_EXIST", "用户存在")
+			return ErrUserExist
 		}
 
 		if curr.ID > 0 {
-			return errors.New(400, "USER_EXIST", "用户存在")
+			return ErrUserExist
 		}
 
-		return uc.userRepo.Create(ctx, user)
+		if err := uc.passwordManager.Apply(ctx, user, password); err != nil {
+			return err
+		}
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if len(roleIDs) <= 0 {
+			return nil
+		}
+		if err := uc.checkAssignable(ctx, roleIDs); err != nil {
+			return err
+		}
+		if err := checkRoleConflict(ctx, uc.constraintRepo, roleIDs); err != nil {
+			return err
+		}
+		return uc.userRepo.UpdateRole(ctx, user.UID, roleIDs)
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.userRolesChanged(ctx, user.UID)
+	return nil
 }
 
-// GetUser 获取用户信息
+// GetUser 获取用户信息, 角色及其权限读取自缓存 (见 AuthzCache)
 func (uc *UserUsecase) GetUser(ctx context.Context, uid int64) (*UserRoleInfo, error) {
-	var (
-		ret UserRoleInfo
-		err error
-	)
-
-	err = uc.txm.Transaction(ctx, func(ctx context.Context) error {
-		user, err := uc.userRepo.SelectUserByUID(ctx, uid)
-		if err != nil {
-			return err
-		}
+	user, err := uc.userRepo.SelectUserAccount(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = ""
 
-		roles, err := uc.roleRepo.SelectRolePermission(ctx, user.RoleIDs)
-		if err != nil {
-			return err
-		}
+	roleIDs, err := uc.authz.UserRoleIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := uc.authz.RolePermissions(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
 
-		ret.User = user.User
-		ret.Roles = lo.Map(roles, func(item *RoleJoinPermission, _ int) *Role {
+	return &UserRoleInfo{
+		User: *user,
+		Roles: lo.Map(roles, func(item *RoleJoinPermission, _ int) *Role {
 			return &Role{
 				ID:          item.ID,
 				UID:         item.UID,
@@ -147,55 +222,178 @@ func (uc *UserUsecase) GetUser(ctx context.Context, uid int64) (*UserRoleInfo, e
 				Status:      item.Status,
 				Permissions: item.Permissions,
 			}
-		})
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	return &ret, nil
+		}),
+	}, nil
 }
 
-// UpdateUser 更新用户信息
+// UpdateUser 更新用户信息, Password 不为空时按密码策略修改密码
 func (uc *UserUsecase) UpdateUser(ctx context.Context, user *User) error {
 	if user.UID <= 0 {
 		return errors.New(400, "INVALID_USER_UID", "无效的用户ID")
 	}
-	return uc.userRepo.Update(ctx, user.UID, user)
+	// 0 表示不修改状态
+	switch user.Status {
+	case 0, UserStatusNormal, UserStatusDisabled:
+	default:
+		return ErrUserStatusInvalid
+	}
+	if user.Password == "" {
+		return uc.userRepo.Update(ctx, user.UID, user)
+	}
+
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		account, err := uc.userRepo.SelectUserAccount(ctx, user.UID)
+		if err != nil {
+			return err
+		}
+		password := user.Password
+		user.Password = account.Password
+		// 同时修改用户名时以新用户名校验
+		if user.Username == "" {
+			user.Username = account.Username
+		}
+		if err := uc.passwordManager.Apply(ctx, user, password); err != nil {
+			return err
+		}
+		return uc.userRepo.Update(ctx, user.UID, user)
+	})
 }
 
-// DeleteUser 删除用户
+// DeleteUser 删除用户, 用户标记为已删除, 角色等关联数据保留以便恢复
 func (uc *UserUsecase) DeleteUser(ctx context.Context, id int64) error {
 	if id <= 0 {
 		return errors.New(400, "INVALID_USER_UID", "无效的用户ID")
 	}
-	return uc.userRepo.Delete(ctx, id)
+	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		return uc.userRepo.Delete(ctx, id)
+	})
 }
 
-// ListUser 获取用户列表
-func (uc *UserUsecase) ListUser(ctx context.Context, pagination *protobuf.Pagination, filter *UserQueryFilter) ([]*UserInfo, error) {
-	return uc.userRepo.SelectList(ctx, pagination, filter)
-}
+// BindRole 为用户绑定角色, ttl 大于 0 时授权到期后自动失效
+func (uc *UserUsecase) BindRole(ctx context.Context, userID int64, roleID int64, ttl time.Duration, grantedBy int64) (*UserRole, error) {
+	binding := &UserRole{
+		UserID:    userID,
+		RoleID:    roleID,
+		GrantedBy: grantedBy,
+	}
+	if ttl > 0 {
+		binding.ExpiresAt = lo.ToPtr(time.Now().Add(ttl))
+	}
 
-func (uc *UserUsecase) BindRole(ctx context.Context, userID int64, roleID int64) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
-		return uc.userRepo.BindRole(ctx, userID, roleID)
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if err := checkUserExist(ctx, uc.userRepo, userID); err != nil {
+			return err
+		}
+		if err := uc.checkAssignable(ctx, []int64{roleID}); err != nil {
+			return err
+		}
+
+		roles, err := uc.roleRepo.SelectByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		roleIDs := append(lo.Map(roles, func(item *Role, _ int) int64 { return item.UID }), roleID)
+		if err := checkRoleConflict(ctx, uc.constraintRepo, roleIDs); err != nil {
+			return err
+		}
+		return uc.userRepo.BindRole(ctx, binding)
 	})
+	if err != nil {
+		return nil, err
+	}
+	uc.authz.userRolesChanged(ctx, userID)
+	return binding, nil
 }
 
 func (uc *UserUsecase) UnbindRole(ctx context.Context, userID int64, roleID int64) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if err := checkUserExist(ctx, uc.userRepo, userID); err != nil {
+			return err
+		}
 		return uc.userRepo.UnbindRole(ctx, userID, roleID)
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.userRolesChanged(ctx, userID)
+	return nil
 }
 
 func (uc *UserUsecase) UpdateRole(ctx context.Context, userID int64, roleIDs []int64) error {
-	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		if err := checkUserExist(ctx, uc.userRepo, userID); err != nil {
+			return err
+		}
+		if err := uc.checkAssignable(ctx, roleIDs); err != nil {
+			return err
+		}
+		if err := checkRoleConflict(ctx, uc.constraintRepo, roleIDs); err != nil {
+			return err
+		}
 		return uc.userRepo.UpdateRole(ctx, userID, roleIDs)
 	})
+	if err != nil {
+		return err
+	}
+	uc.authz.userRolesChanged(ctx, userID)
+	return nil
+}
+
+// SweepExpiredRoles 清理已过期的角色授权, 返回被清理的授权记录
+func (uc *UserUsecase) SweepExpiredRoles(ctx context.Context) ([]*UserRole, error) {
+	var expired []*UserRole
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		expired, err = uc.userRepo.DeleteExpiredRoles(ctx, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(expired) > 0 {
+		uc.authz.userRolesChanged(ctx, lo.Map(expired, func(item *UserRole, _ int) int64 {
+			return item.UserID
+		})...)
+	}
+	return expired, nil
 }
 
-func (uc *UserUsecase) Login(ctx context.Context, username string, password string, autoLogin bool) (*User, error) {
+// checkUserExist 检查用户存在于当前租户; 用户的角色、部门绑定表没有 tenant_id, 修改前需要先校验用户
+func checkUserExist(ctx context.Context, repo UserRepo, userID int64) error {
+	if _, err := repo.SelectUserByUID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkAssignable 检查角色是否可以分配给用户, 模板角色以及其他租户的角色不允许分配
+func (uc *UserUsecase) checkAssignable(ctx context.Context, roleIDs []int64) error {
+	return checkAssignable(ctx, uc.roleRepo, roleIDs)
+}
+
+func checkAssignable(ctx context.Context, repo RoleRepo, roleIDs []int64) error {
+	if len(roleIDs) <= 0 {
+		return nil
+	}
+
+	roles, err := repo.SelectByIDs(ctx, roleIDs)
+	if err != nil {
+		return err
+	}
+	if len(roles) != len(lo.Uniq(roleIDs)) {
+		return ErrRoleNotFound
+	}
+	if lo.SomeBy(roles, func(item *Role) bool { return item.IsTemplate }) {
+		return ErrRoleIsTemplate
+	}
+	return nil
+}
+
+// Login 登录, 密码过期时需要同时提供 newPassword 修改密码
+func (uc *UserUsecase) Login(ctx context.Context, username string, password string, newPassword string, autoLogin bool) (*User, error) {
 	user, err := uc.userRepo.SelectUserByNameOrEmail(ctx, username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -226,33 +424,56 @@ func (uc *UserUsecase) Login(ctx context.Context, username string, password stri
 		return nil, passportpb.ErrorUserOrPasswordError("用户或密码不正确")
 	}
 
-	if user.Status != 1 {
+	switch user.Status {
+	case UserStatusNormal:
+	case UserStatusUnverified:
+		return nil, ErrEmailNotVerified
+	case UserStatusAwaitingApproval:
+		return nil, ErrAwaitingApproval
+	default:
 		return nil, errors.New(400, "USER_DISABLED", "用户已禁用")
 	}
 
+	if uc.passwordManager.Policy().Expired(user) {
+		if newPassword == "" {
+			return nil, ErrPasswordExpired
+		}
+		err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+			if err := uc.passwordManager.Apply(ctx, user, newPassword); err != nil {
+				return err
+			}
+			return uc.userRepo.Update(ctx, user.UID, &User{
+				Password:          user.Password,
+				PasswordChangedAt: user.PasswordChangedAt,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	_ = uc.userRepo.UpdateLastLogin(ctx, user.UID)
 
 	return user, nil
 }
 
+// UpdatePassword 通过邮箱重置密码; 邮箱只在租户内唯一, context 中必须有租户
 func (uc *UserUsecase) UpdatePassword(ctx context.Context, email string, password string) error {
+	if _, ok := tenant.FromContext(ctx); !ok {
+		return ErrTenantCodeEmpty
+	}
 	return uc.txm.Transaction(ctx, func(ctx context.Context) error {
 		user, err := uc.userRepo.SelectUserByEmail(ctx, email)
 		if err != nil {
 			return err
 		}
 
-		buf, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-		if err != nil {
+		if err := uc.passwordManager.Apply(ctx, user, password); err != nil {
 			return err
 		}
-
-		newPassword := string(buf)
-		if user.Password == newPassword {
-			return errors.New(400, "NEW_PASSWORD_SAME_AS_OLD", "新密码与旧密码相同")
-		}
-
-		user.Password = newPassword
-		return uc.userRepo.Update(ctx, user.UID, user)
+		return uc.userRepo.Update(ctx, user.UID, &User{
+			Password:          user.Password,
+			PasswordChangedAt: user.PasswordChangedAt,
+		})
 	})
 }
@@ -0,0 +1,198 @@
+package biz
+
+import (
+	"context"
+	"net/mail"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxUserImportRows 单次导入的最大行数
+	MaxUserImportRows = 5000
+	// userExportBatchSize 导出时每批查询的用户数量
+	userExportBatchSize = 500
+)
+
+var (
+	ErrUserImportEmpty     = errors.New(400, "USER_IMPORT_EMPTY", "导入文件没有数据")
+	ErrUserImportTooMany   = errors.New(400, "USER_IMPORT_TOO_MANY", "单次导入的行数超过上限")
+	ErrUserImportDuplicate = errors.New(400, "USER_IMPORT_DUPLICATE", "文件中存在重复的用户名或邮箱")
+)
+
+// UserImportRow 导入文件中的一行
+type UserImportRow struct {
+	Line  int
+	User  *User
+	Roles []string // 角色名称
+}
+
+// UserImportError 未通过校验的行
+type UserImportError struct {
+	Line     int
+	Username string
+	Err      error
+}
+
+type UserImportResult struct {
+	Total   int
+	Created []*User // dry-run 时为校验通过的用户
+	Errors  []*UserImportError
+}
+
+// ImportUsers 批量导入用户, 逐行校验后在同一事务中创建校验通过的用户, dryRun 时仅校验
+func (uc *UserUsecase) ImportUsers(ctx context.Context, rows []*UserImportRow, dryRun bool) (*UserImportResult, error) {
+	if len(rows) <= 0 {
+		return nil, ErrUserImportEmpty
+	}
+	if len(rows) > MaxUserImportRows {
+		return nil, ErrUserImportTooMany.WithMetadata(map[string]string{
+			"max": strconv.Itoa(MaxUserImportRows),
+		})
+	}
+
+	result := &UserImportResult{Total: len(rows)}
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		// 模板角色不允许分配, 不参与名称匹配
+		roles, err := uc.roleRepo.GetAll(ctx, false)
+		if err != nil {
+			return err
+		}
+		roleByName := lo.SliceToMap(roles, func(item *Role) (string, int64) {
+			return item.Name, item.UID
+		})
+
+		var (
+			usernames = make(map[string]struct{}, len(rows))
+			emails    = make(map[string]struct{}, len(rows))
+			bindings  = make(map[int64][]int64, len(rows))
+		)
+		for _, row := range rows {
+			roleIDs, rowErr, err := uc.checkImportRow(ctx, row, roleByName, usernames, emails)
+			if err != nil {
+				return err
+			}
+			if rowErr != nil {
+				result.Errors = append(result.Errors, &UserImportError{
+					Line:     row.Line,
+					Username: row.User.Username,
+					Err:      rowErr,
+				})
+				continue
+			}
+
+			usernames[row.User.Username] = struct{}{}
+			if row.User.Email != "" {
+				emails[row.User.Email] = struct{}{}
+			}
+			bindings[row.User.UID] = roleIDs
+			result.Created = append(result.Created, row.User)
+		}
+		if dryRun {
+			return nil
+		}
+
+		for _, user := range result.Created {
+			// 未提供密码的用户无法登录, 需要通过找回密码设置
+			if password := user.Password; password != "" {
+				user.Password = ""
+				if err := uc.passwordManager.Apply(ctx, user, password); err != nil {
+					return err
+				}
+			}
+			if err := uc.userRepo.Create(ctx, user); err != nil {
+				return err
+			}
+			if roleIDs := bindings[user.UID]; len(roleIDs) > 0 {
+				if err := uc.userRepo.UpdateRole(ctx, user.UID, roleIDs); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkImportRow 校验一行数据, 返回角色ID; rowErr 为该行的校验错误, err 为查询错误
+func (uc *UserUsecase) checkImportRow(ctx context.Context, row *UserImportRow, roleByName map[string]int64, usernames, emails map[string]struct{}) (roleIDs []int64, rowErr error, err error) {
+	user := row.User
+	if user.Username == "" {
+		return nil, ErrUsernameEmpty, nil
+	}
+	if _, ok := usernames[user.Username]; ok {
+		return nil, ErrUserImportDuplicate, nil
+	}
+	curr, err := uc.userRepo.SelectUserByName(ctx, user.Username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+	if curr.UID > 0 {
+		return nil, ErrUserExist, nil
+	}
+
+	if user.Password != "" {
+		if err := uc.passwordManager.Check(user.Password, user.Username); err != nil {
+			return nil, err, nil
+		}
+	}
+
+	if user.Email != "" {
+		if addr, err := mail.ParseAddress(user.Email); err != nil || addr.Address != user.Email {
+			return nil, ErrEmailInvalid, nil
+		}
+		if _, ok := emails[user.Email]; ok {
+			return nil, ErrUserImportDuplicate, nil
+		}
+		curr, err := uc.userRepo.SelectUserByEmail(ctx, user.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, err
+		}
+		if curr.UID > 0 {
+			return nil, ErrEmailExist, nil
+		}
+	}
+
+	roleIDs = make([]int64, 0, len(row.Roles))
+	for _, name := range lo.Uniq(row.Roles) {
+		uid, ok := roleByName[name]
+		if !ok {
+			return nil, ErrRoleNotFound.WithMetadata(map[string]string{"role": name}), nil
+		}
+		roleIDs = append(roleIDs, uid)
+	}
+	if err := checkRoleConflict(ctx, uc.constraintRepo, roleIDs); err != nil {
+		return nil, err, nil
+	}
+	return roleIDs, nil, nil
+}
+
+// ExportUsers 按筛选条件分批查询用户, 逐个回调用户及其角色名称
+func (uc *UserUsecase) ExportUsers(ctx context.Context, filter *UserQueryFilter, fn func(user *UserInfo, roles []string) error) error {
+	roles, err := uc.roleRepo.GetAll(ctx, true)
+	if err != nil {
+		return err
+	}
+	roleNames := lo.SliceToMap(roles, func(item *Role) (int64, string) {
+		return item.UID, item.Name
+	})
+
+	return uc.eachUser(ctx, filter, userExportBatchSize, func(users []*UserInfo) error {
+		for _, user := range users {
+			names := lo.FilterMap(user.RoleIDs, func(item int64, _ int) (string, bool) {
+				name, ok := roleNames[item]
+				return name, ok
+			})
+			if err := fn(user, names); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
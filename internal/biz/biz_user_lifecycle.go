@@ -0,0 +1,92 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/omalloc/contrib/protobuf"
+	"gorm.io/gorm"
+)
+
+// DefaultDeletedUserRetention 已删除用户的默认保留时间, 超过后被清除
+const DefaultDeletedUserRetention = 30 * 24 * time.Hour
+
+// userPurgeBatchSize 每个事务清除的用户数
+const userPurgeBatchSize = 100
+
+var ErrDeletedUserNotFound = errors.New(404, "DELETED_USER_NOT_FOUND", "已删除的用户不存在或已被清除")
+
+// ListDeletedUsers 已删除且可以恢复的用户
+func (uc *UserUsecase) ListDeletedUsers(ctx context.Context, pagination *protobuf.Pagination) ([]*UserInfo, error) {
+	return uc.userRepo.SelectDeletedList(ctx, pagination)
+}
+
+// RestoreUser 恢复已删除的用户, 恢复后为禁用状态, 需要管理员重新启用
+func (uc *UserUsecase) RestoreUser(ctx context.Context, uid int64) (*User, error) {
+	var user *User
+	err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = uc.userRepo.SelectDeletedUser(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return ErrDeletedUserNotFound
+		}
+
+		// 删除期间用户名或邮箱可能已被其他用户使用
+		curr, err := uc.userRepo.SelectUserByName(ctx, user.Username)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if curr.UID > 0 {
+			return ErrUserExist
+		}
+		if user.Email != "" {
+			curr, err = uc.userRepo.SelectUserByEmail(ctx, user.Email)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if curr.UID > 0 {
+				return ErrEmailExist
+			}
+		}
+
+		user.Status = UserStatusDisabled
+		return uc.userRepo.Restore(ctx, uid, user.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// PurgeDeletedUsers 清除 before 之前删除的用户, 返回被清除的用户 uid
+func (uc *UserUsecase) PurgeDeletedUsers(ctx context.Context, before time.Time) ([]int64, error) {
+	var purged []int64
+	for {
+		var batch []int64
+		err := uc.txm.Transaction(ctx, func(ctx context.Context) error {
+			users, err := uc.userRepo.SelectDeletedBefore(ctx, before, userPurgeBatchSize)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			for _, user := range users {
+				if err := uc.userRepo.Purge(ctx, user.UID, now); err != nil {
+					return err
+				}
+				batch = append(batch, user.UID)
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged = append(purged, batch...)
+		if len(batch) < userPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package biz
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+)
+
+// 用户列表可排序字段, 即 users 表上带索引的业务列 (tenant_id 由租户限定, 不参与排序);
+// 部门在关联表上, 只能作为筛选条件
+const (
+	UserSortUID       = "uid"
+	UserSortUsername  = "username"
+	UserSortEmail     = "email"
+	UserSortStatus    = "status"
+	UserSortLastLogin = "last_login"
+	UserSortCreatedAt = "created_at"
+)
+
+var userSortFields = []string{UserSortUID, UserSortUsername, UserSortEmail, UserSortStatus, UserSortLastLogin, UserSortCreatedAt}
+
+var (
+	ErrUserSortInvalid   = errors.New(400, "USER_SORT_INVALID", "不支持的排序字段")
+	ErrUserCursorInvalid = errors.New(400, "USER_CURSOR_INVALID", "分页游标无效")
+)
+
+type UserQueryFilter struct {
+	Status       int
+	Username     string // 包含 @ 时按邮箱匹配
+	Email        string
+	Keyword      string  // 模糊匹配 用户名/邮箱/昵称
+	RoleIDs      []int64 // 拥有其中任一 (未过期) 角色
+	DepartmentID int64   // 包含子部门
+
+	CreatedAfter    time.Time
+	CreatedBefore   time.Time
+	LastLoginAfter  time.Time
+	LastLoginBefore time.Time
+
+	OrderBy string // 为空时按 uid 排序
+	Asc     bool
+
+	// After 游标分页, 非空时从该位置之后取数据, 忽略页码且不统计总数
+	After *UserCursor
+}
+
+// UserCursor 游标分页位置, 即上一页最后一条记录的排序值
+type UserCursor struct {
+	OrderBy string
+	Asc     bool
+	Value   any // 排序字段的值, uid 排序时为空
+	UID     int64
+}
+
+type userCursorJSON struct {
+	OrderBy string `json:"o"`
+	Asc     bool   `json:"a,omitempty"`
+	Value   string `json:"v,omitempty"`
+	UID     int64  `json:"u"`
+}
+
+// Encode 编码为不透明的游标字符串
+func (c *UserCursor) Encode() string {
+	raw := userCursorJSON{OrderBy: c.OrderBy, Asc: c.Asc, UID: c.UID}
+	switch v := c.Value.(type) {
+	case string:
+		raw.Value = v
+	case int64:
+		raw.Value = strconv.FormatInt(v, 10)
+	case time.Time:
+		raw.Value = v.UTC().Format(time.RFC3339Nano)
+	}
+	buf, _ := json.Marshal(raw)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// ParseUserCursor 解析游标, 游标必须与当前的排序方式一致
+func ParseUserCursor(s string, orderBy string, asc bool) (*UserCursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrUserCursorInvalid
+	}
+	var raw userCursorJSON
+	if err = json.Unmarshal(buf, &raw); err != nil || raw.UID <= 0 {
+		return nil, ErrUserCursorInvalid
+	}
+	if raw.OrderBy != orderBy || raw.Asc != asc {
+		return nil, ErrUserCursorInvalid.WithMetadata(map[string]string{"reason": "order mismatch"})
+	}
+
+	cursor := &UserCursor{OrderBy: raw.OrderBy, Asc: raw.Asc, UID: raw.UID}
+	switch raw.OrderBy {
+	case UserSortUsername, UserSortEmail:
+		cursor.Value = raw.Value
+	case UserSortStatus:
+		v, err := strconv.ParseInt(raw.Value, 10, 64)
+		if err != nil {
+			return nil, ErrUserCursorInvalid
+		}
+		cursor.Value = v
+	case UserSortLastLogin, UserSortCreatedAt:
+		t, err := time.Parse(time.RFC3339Nano, raw.Value)
+		if err != nil {
+			return nil, ErrUserCursorInvalid
+		}
+		cursor.Value = t
+	}
+	return cursor, nil
+}
+
+// newUserCursor 以 user 作为游标位置
+func newUserCursor(user *UserInfo, orderBy string, asc bool) *UserCursor {
+	cursor := &UserCursor{OrderBy: orderBy, Asc: asc, UID: user.UID}
+	switch orderBy {
+	case UserSortUsername:
+		cursor.Value = user.Username
+	case UserSortEmail:
+		cursor.Value = user.Email
+	case UserSortStatus:
+		cursor.Value = user.Status
+	case UserSortLastLogin:
+		cursor.Value = user.LastLogin
+	case UserSortCreatedAt:
+		cursor.Value = user.CreatedAt
+	}
+	return cursor
+}
+
+// normalize 校验排序字段并解析游标
+func (f *UserQueryFilter) normalize(cursor string) error {
+	if f.OrderBy == "" {
+		f.OrderBy = UserSortUID
+	}
+	if !lo.Contains(userSortFields, f.OrderBy) {
+		return ErrUserSortInvalid.WithMetadata(map[string]string{"order_by": f.OrderBy})
+	}
+	if cursor == "" {
+		return nil
+	}
+	after, err := ParseUserCursor(cursor, f.OrderBy, f.Asc)
+	if err != nil {
+		return err
+	}
+	f.After = after
+	return nil
+}
+
+// ListUser 获取用户列表, 支持页码与游标两种分页, 返回下一页游标 (为空表示没有更多数据)
+func (uc *UserUsecase) ListUser(ctx context.Context, pagination *protobuf.Pagination, filter *UserQueryFilter, cursor string) ([]*UserInfo, string, error) {
+	if filter == nil {
+		filter = &UserQueryFilter{}
+	}
+	if err := filter.normalize(cursor); err != nil {
+		return nil, "", err
+	}
+
+	users, err := uc.userRepo.SelectList(ctx, pagination, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 游标分页时 total 为 0
+	total := *pagination.Count()
+	more := len(users) == pagination.Limit()
+	if filter.After == nil {
+		more = int64(pagination.Offset()+len(users)) < total
+	}
+	if !more || len(users) == 0 {
+		return users, "", nil
+	}
+	return users, newUserCursor(users[len(users)-1], filter.OrderBy, filter.Asc).Encode(), nil
+}
+
+// eachUser 按游标分批遍历全部匹配的用户, 大表上不会因偏移量增大而变慢
+func (uc *UserUsecase) eachUser(ctx context.Context, filter *UserQueryFilter, batch int, fn func(users []*UserInfo) error) error {
+	query := *filter
+	if err := query.normalize(""); err != nil {
+		return err
+	}
+	for {
+		pagination := protobuf.PageWrap(&protobuf.Pagination{PageSize: int32(batch)})
+		users, err := uc.userRepo.SelectList(ctx, pagination, &query)
+		if err != nil {
+			return err
+		}
+		if err = fn(users); err != nil {
+			return err
+		}
+		if len(users) < batch {
+			return nil
+		}
+		query.After = newUserCursor(users[len(users)-1], query.OrderBy, query.Asc)
+	}
+}
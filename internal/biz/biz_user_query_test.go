@@ -0,0 +1,106 @@
+package biz
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserCursorRoundTrip(t *testing.T) {
+	lastLogin := time.Date(2026, 10, 19, 8, 30, 0, 123456789, time.FixedZone("CST", 8*3600))
+	user := &UserInfo{User: User{UID: 42, Username: "alice", Email: "alice@example.com", Status: 2, LastLogin: lastLogin}}
+	user.CreatedAt = lastLogin.Add(-time.Hour)
+
+	tests := []struct {
+		orderBy string
+		asc     bool
+		want    any
+	}{
+		{UserSortUID, false, nil},
+		{UserSortUsername, true, "alice"},
+		{UserSortEmail, false, "alice@example.com"},
+		{UserSortStatus, true, int64(2)},
+		{UserSortLastLogin, false, lastLogin},
+		{UserSortCreatedAt, true, user.CreatedAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			encoded := newUserCursor(user, tt.orderBy, tt.asc).Encode()
+			got, err := ParseUserCursor(encoded, tt.orderBy, tt.asc)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got.UID != user.UID || got.OrderBy != tt.orderBy || got.Asc != tt.asc {
+				t.Fatalf("cursor = %+v", got)
+			}
+			switch want := tt.want.(type) {
+			case time.Time:
+				if v, ok := got.Value.(time.Time); !ok || !v.Equal(want) {
+					t.Fatalf("value = %#v, want %v", got.Value, want)
+				}
+			default:
+				if got.Value != tt.want {
+					t.Fatalf("value = %#v, want %#v", got.Value, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseUserCursorInvalid(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	tests := []struct {
+		name    string
+		cursor  string
+		orderBy string
+		asc     bool
+	}{
+		{"not base64", "!!!", UserSortUID, false},
+		{"not json", encode("uid"), UserSortUID, false},
+		{"missing uid", encode(`{"o":"uid"}`), UserSortUID, false},
+		{"order mismatch", encode(`{"o":"email","v":"a","u":1}`), UserSortUsername, false},
+		{"direction mismatch", encode(`{"o":"uid","u":1}`), UserSortUID, true},
+		{"bad time", encode(`{"o":"last_login","v":"yesterday","u":1}`), UserSortLastLogin, false},
+		{"bad status", encode(`{"o":"status","v":"x","u":1}`), UserSortStatus, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseUserCursor(tt.cursor, tt.orderBy, tt.asc); !errors.Is(err, ErrUserCursorInvalid) {
+				t.Fatalf("error = %v, want %v", err, ErrUserCursorInvalid)
+			}
+		})
+	}
+}
+
+func TestUserQueryFilterNormalize(t *testing.T) {
+	cursor := (&UserCursor{OrderBy: UserSortCreatedAt, UID: 1, Value: time.Now()}).Encode()
+	tests := []struct {
+		name    string
+		orderBy string
+		cursor  string
+		wantErr error
+	}{
+		{"default", "", "", nil},
+		{"created_at", UserSortCreatedAt, "", nil},
+		{"status", UserSortStatus, "", nil},
+		{"with cursor", UserSortCreatedAt, cursor, nil},
+		{"cursor of another order", UserSortStatus, cursor, ErrUserCursorInvalid},
+		{"not sortable", "password", "", ErrUserSortInvalid},
+		{"department is a filter", "department_id", "", ErrUserSortInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &UserQueryFilter{OrderBy: tt.orderBy}
+			err := f.normalize(tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && tt.cursor != "" && f.After == nil {
+				t.Fatal("cursor not parsed")
+			}
+		})
+	}
+}
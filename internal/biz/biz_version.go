@@ -0,0 +1,16 @@
+package biz
+
+import "github.com/go-kratos/kratos/v2/errors"
+
+// 角色, 权限及菜单带有版本号, 更新时需回传读取到的版本号; 版本号不一致说明已被他人修改, 拒绝覆盖
+var (
+	ErrVersionRequired = errors.New(400, "VERSION_REQUIRED", "缺少版本号")
+	ErrVersionConflict = errors.New(409, "CONFLICT", "数据已被他人修改, 请刷新后重试")
+)
+
+func checkVersion(version int64) error {
+	if version <= 0 {
+		return ErrVersionRequired
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/conf"
+)
+
+const defaultStorageDir = "./bin/files"
+
+var errInvalidBlobKey = errors.New("invalid blob key")
+
+// NewBlobStore 按配置创建文件存储
+func NewBlobStore(c *conf.Data, logger log.Logger) (biz.BlobStore, error) {
+	sc := c.GetStorage()
+	dir := sc.GetDir()
+	if dir == "" {
+		dir = defaultStorageDir
+	}
+
+	switch sc.GetDriver() {
+	case "", "local":
+		return NewLocalBlobStore(dir)
+	case "s3":
+		if sc.GetBucket() == "" {
+			return nil, errors.New("storage bucket is required for s3 driver")
+		}
+		// 尚未接入 S3 客户端, 先以本地目录模拟存储桶
+		log.NewHelper(logger).Warnf("s3 client is not configured, objects are stored in %s", dir)
+		return NewS3BlobStore(NewLocalS3Client(dir), sc.GetBucket()), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", sc.GetDriver())
+	}
+}
+
+// localBlobStore 将内容保存在本地目录下, key 即相对路径
+type localBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) (biz.BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &localBlobStore{root: root}, nil
+}
+
+func (s *localBlobStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", errInvalidBlobKey, key)
+	}
+	return filepath.Join(s.root, clean), nil
+}
+
+// Put 先写入临时文件再重命名, 读取方不会看到写了一半的内容
+func (s *localBlobStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *localBlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+func (s *localBlobStore) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// S3Client S3 兼容对象存储的最小接口, 可由 aws-sdk, minio 等客户端适配.
+// 对象不存在时 GetObject 返回 fs.ErrNotExist
+type S3Client interface {
+	PutObject(ctx context.Context, bucket, key string, r io.Reader, size int64, contentType string) error
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	RemoveObject(ctx context.Context, bucket, key string) error
+}
+
+type s3BlobStore struct {
+	client S3Client
+	bucket string
+}
+
+func NewS3BlobStore(client S3Client, bucket string) biz.BlobStore {
+	return &s3BlobStore{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+func (s *s3BlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	return s.client.PutObject(ctx, s.bucket, key, r, size, contentType)
+}
+
+func (s *s3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.client.GetObject(ctx, s.bucket, key)
+}
+
+func (s *s3BlobStore) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key)
+}
+
+// localS3Client 本地替身, 每个存储桶对应 root 下的一个目录, 用于开发与测试
+type localS3Client struct {
+	root string
+}
+
+func NewLocalS3Client(root string) S3Client {
+	return &localS3Client{root: root}
+}
+
+func (c *localS3Client) bucket(name string) (*localBlobStore, error) {
+	root := &localBlobStore{root: c.root}
+	p, err := root.path(name)
+	if err != nil || strings.ContainsRune(name, '/') {
+		return nil, fmt.Errorf("invalid bucket name %q", name)
+	}
+	return &localBlobStore{root: p}, nil
+}
+
+func (c *localS3Client) PutObject(ctx context.Context, bucket, key string, r io.Reader, size int64, contentType string) error {
+	b, err := c.bucket(bucket)
+	if err != nil {
+		return err
+	}
+	return b.Put(ctx, key, r, size, contentType)
+}
+
+func (c *localS3Client) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	b, err := c.bucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return b.Get(ctx, key)
+}
+
+func (c *localS3Client) RemoveObject(ctx context.Context, bucket, key string) error {
+	b, err := c.bucket(bucket)
+	if err != nil {
+		return err
+	}
+	return b.Delete(ctx, key)
+}
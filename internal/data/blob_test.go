@@ -0,0 +1,110 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStorePath(t *testing.T) {
+	root := t.TempDir()
+	store := &localBlobStore{root: root}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"a.txt", "a.txt"},
+		{"2026/10/a.txt", "2026/10/a.txt"},
+		{"a/../b.txt", "b.txt"},
+		{"./a.txt", "a.txt"},
+		{"a//b.txt", "a/b.txt"},
+		{"..a.txt", "..a.txt"},
+		{"", ""},
+		{".", ""},
+		{"..", ""},
+		{"../a.txt", ""},
+		{"a/../../b.txt", ""},
+		{"/etc/passwd", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := store.path(tt.key)
+			if tt.want == "" {
+				if !errors.Is(err, errInvalidBlobKey) {
+					t.Fatalf("path = %q error = %v, want %v", got, err, errInvalidBlobKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("path: %v", err)
+			}
+			if want := filepath.Join(root, filepath.FromSlash(tt.want)); got != want {
+				t.Fatalf("path = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestLocalBlobStoreTraversal 非法的 key 不会读写存储目录之外的文件
+func TestLocalBlobStoreTraversal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	outside := filepath.Join(dir, "outside.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	store, err := NewLocalBlobStore(filepath.Join(dir, "files"))
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+
+	for _, key := range []string{"../outside.txt", "a/../../outside.txt"} {
+		t.Run(key, func(t *testing.T) {
+			if err := store.Put(ctx, key, strings.NewReader("changed"), 7, ""); !errors.Is(err, errInvalidBlobKey) {
+				t.Fatalf("put error = %v, want %v", err, errInvalidBlobKey)
+			}
+			if _, err := store.Get(ctx, key); !errors.Is(err, errInvalidBlobKey) {
+				t.Fatalf("get error = %v, want %v", err, errInvalidBlobKey)
+			}
+			if err := store.Delete(ctx, key); !errors.Is(err, errInvalidBlobKey) {
+				t.Fatalf("delete error = %v, want %v", err, errInvalidBlobKey)
+			}
+		})
+	}
+	if content, err := os.ReadFile(outside); err != nil || string(content) != "secret" {
+		t.Fatalf("outside file = %q err %v", content, err)
+	}
+
+	// 合法的 key 正常读写
+	if err := store.Put(ctx, "a/b.txt", strings.NewReader("hello"), 5, ""); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	r, err := store.Get(ctx, "a/b.txt")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	_ = r.Close()
+	if string(content) != "hello" {
+		t.Fatalf("content = %q, want hello", content)
+	}
+}
+
+func TestLocalS3ClientBucket(t *testing.T) {
+	client := NewLocalS3Client(t.TempDir())
+	for _, bucket := range []string{"", ".", "..", "../x", "a/b", "/abs"} {
+		t.Run(bucket, func(t *testing.T) {
+			if err := client.PutObject(context.Background(), bucket, "a.txt", strings.NewReader("x"), 1, ""); err == nil {
+				t.Fatalf("put to bucket %q succeeded", bucket)
+			}
+		})
+	}
+	if err := client.PutObject(context.Background(), "avatars", "a.txt", strings.NewReader("x"), 1, ""); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+}
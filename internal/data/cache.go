@@ -0,0 +1,165 @@
+package data
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/conf"
+)
+
+const (
+	// cacheKeyPrefix 多个服务共用 Redis 时区分各自的 key
+	cacheKeyPrefix = "kratos-admin:"
+	// lruCacheSize 内存缓存的最大条目数
+	lruCacheSize = 10000
+)
+
+// NewCache 按配置创建缓存, 未配置 Redis 或 Redis 无法连接时使用进程内的 LRU 缓存 (多实例部署时各自独立)
+func NewCache(c *conf.Data, logger log.Logger) (biz.Cache, func(), error) {
+	helper := log.NewHelper(logger)
+	rc := c.GetRedis()
+	if rc.GetAddr() == "" {
+		helper.Info("redis is not configured, using in-memory cache")
+		return NewLRUCache(lruCacheSize), emptyCallback, nil
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Network:      rc.GetNetwork(),
+		Addr:         rc.GetAddr(),
+		ReadTimeout:  rc.GetReadTimeout().AsDuration(),
+		WriteTimeout: rc.GetWriteTimeout().AsDuration(),
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		helper.Warnf("redis %s is unavailable, using in-memory cache: %v", rc.GetAddr(), err)
+		return NewLRUCache(lruCacheSize), emptyCallback, nil
+	}
+
+	cleanup := func() {
+		_ = client.Close()
+	}
+	return &redisCache{client: client}, cleanup, nil
+}
+
+type redisCache struct {
+	client *redis.Client
+}
+
+func (c *redisCache) Get(ctx context.Context, key string, v any) (bool, error) {
+	buf, err := c.client.Get(ctx, cacheKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(buf, v)
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, v any, ttl time.Duration) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, cacheKeyPrefix+key, buf, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, cacheKeyPrefix+key)
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// lruCache 进程内的 LRU 缓存, 超过容量时淘汰最久未使用的条目
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+func NewLRUCache(size int) biz.Cache {
+	return &lruCache{
+		size:    size,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) Get(_ context.Context, key string, v any) (bool, error) {
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return false, nil
+	}
+	entry := elem.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(elem)
+		c.mu.Unlock()
+		return false, nil
+	}
+	c.ll.MoveToFront(elem)
+	value := entry.value
+	c.mu.Unlock()
+
+	return true, json.Unmarshal(value, v)
+}
+
+func (c *lruCache) Set(_ context.Context, key string, v any, ttl time.Duration) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &lruEntry{key: key, value: buf, expiresAt: time.Now().Add(ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.ll.MoveToFront(elem)
+		return nil
+	}
+	c.entries[key] = c.ll.PushFront(entry)
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+	}
+	return nil
+}
+
+func (c *lruCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (c *lruCache) remove(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).key)
+}
@@ -8,8 +8,8 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 	"github.com/omalloc/contrib/kratos/orm"
-	"github.com/omalloc/kratos-admin/internal/biz"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"github.com/omalloc/kratos-admin/internal/conf"
@@ -19,14 +19,24 @@ import (
 var ProviderSet = wire.NewSet(
 	NewData,
 	wire.Bind(new(orm.DataSourceManager), new(*Data)),
-	orm.NewTransactionManager,
+	NewTransactionManager,
 
 	// rbac modules.
 	NewUserRepo,
 	NewRoleRepo,
+	NewRoleConstraintRepo,
 	NewPermissionRepo,
 	NewMenuRepo,
 	NewCrontabRepo,
+	NewAccessRequestRepo,
+	NewDepartmentRepo,
+	NewTenantRepo,
+	NewInvitationRepo,
+	NewPasswordHistoryRepo,
+	NewFileRepo,
+	NewEmailVerificationRepo,
+	NewBlobStore,
+	NewCache,
 )
 
 var emptyCallback = func() {}
@@ -34,21 +44,22 @@ var emptyCallback = func() {}
 type DriverDialector func(dsn string) gorm.Dialector
 
 var driverSupported = map[string]DriverDialector{
-	"sqlite": sqlite.Open,
-	"mysql":  mysql.Open,
+	"sqlite":   sqlite.Open,
+	"mysql":    mysql.Open,
+	"postgres": postgres.Open,
 }
 
 // Data .
 type Data struct {
 	db *gorm.DB
+	// read replicas, nil if not configured
+	replicas *Replicas
 	// wrapped database client with transaction manager
 	txm orm.Transaction
 }
 
-// NewData .
-func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
-	log.Infof("begin connection database with %s", c.Database.Driver)
-
+// OpenDatabase 按配置连接数据库
+func OpenDatabase(c *conf.Data, logger log.Logger) (*gorm.DB, error) {
 	driver, ok := driverSupported[c.Database.Driver]
 	if !ok {
 		driver = sqlite.Open
@@ -65,21 +76,37 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 			orm.WithLogHelper(logger),
 		),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := registerTenantScope(db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// NewData .
+func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
+	log.Infof("begin connection database with %s", c.Database.Driver)
+
+	db, err := OpenDatabase(c, logger)
 	if err != nil {
 		return nil, emptyCallback, err
 	}
 
+	// 开启 migrate 时启动即执行未执行的迁移, 否则要求数据库已是程序期望的版本
+	migrator, err := NewMigrator(db, logger)
+	if err != nil {
+		return nil, emptyCallback, err
+	}
 	if c.Database.Migrate {
-		_ = db.Session(&gorm.Session{SkipHooks: true}).
-			AutoMigrate(
-				&biz.User{},
-				&biz.Role{},
-				&biz.Permission{},
-				&biz.RolePermission{},
-				&biz.UserRole{},
-				&biz.Menu{},
-				&biz.Crontab{},
-			)
+		if _, err := migrator.Up(0); err != nil {
+			return nil, emptyCallback, err
+		}
+	}
+	if err := migrator.Check(); err != nil {
+		return nil, emptyCallback, err
 	}
 
 	// 初始化基础数据
@@ -88,12 +115,22 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 		return nil, emptyCallback, err
 	}
 
+	// 迁移与初始化数据完成后再注册只读副本, 保证两者只读写主库
+	replicas, err := useReplicas(db, c.Database.Driver, c.Database.Replicas, logger)
+	if err != nil {
+		return nil, emptyCallback, err
+	}
+
 	cleanup := func() {
 		log.Info("closing the data resources")
+		if replicas != nil {
+			replicas.Close()
+		}
 	}
 
 	data := &Data{
-		db: db,
+		db:       db,
+		replicas: replicas,
 	}
 
 	return data, cleanup, nil
@@ -109,6 +146,11 @@ func (d *Data) Check(ctx context.Context) error {
 	return nil
 }
 
+// Replicas 只读副本, 未配置时为 nil
+func (d *Data) Replicas() *Replicas {
+	return d.replicas
+}
+
 func (d *Data) GetDataSource() *gorm.DB {
 	return d.db
 }
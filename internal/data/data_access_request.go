@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type accessRequestRepo struct {
+	txm orm.Transaction
+}
+
+func NewAccessRequestRepo(txm orm.Transaction) biz.AccessRequestRepo {
+	return &accessRequestRepo{
+		txm: txm,
+	}
+}
+
+func (r *accessRequestRepo) Create(ctx context.Context, request *biz.AccessRequest) error {
+	return r.txm.WithContext(ctx).Create(request).Error
+}
+
+func (r *accessRequestRepo) SelectByUID(ctx context.Context, uid int64) (*biz.AccessRequest, error) {
+	var request biz.AccessRequest
+	err := r.txm.WithContext(ctx).Model(&biz.AccessRequest{}).
+		Where("uid = ?", uid).
+		First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *accessRequestRepo) SelectPending(ctx context.Context, pagination *protobuf.Pagination) ([]*biz.AccessRequest, error) {
+	var requests []*biz.AccessRequest
+
+	err := r.txm.WithContext(ctx).Model(&biz.AccessRequest{}).
+		Where("status = ?", biz.AccessRequestPending).
+		Count(pagination.Count()).
+		Scopes(pagination.Paginate()).
+		Order("created_at ASC").
+		Find(&requests).Error
+
+	return requests, err
+}
+
+func (r *accessRequestRepo) ExistPending(ctx context.Context, userID int64, roleID int64) bool {
+	var count int64
+	r.txm.WithContext(ctx).Model(&biz.AccessRequest{}).
+		Where("user_id = ? AND role_id = ? AND status = ?", userID, roleID, biz.AccessRequestPending).
+		Count(&count)
+	return count > 0
+}
+
+// UpdateStatus 以 status = 待审批 作为条件更新, 防止同一申请被重复处理
+func (r *accessRequestRepo) UpdateStatus(ctx context.Context, request *biz.AccessRequest) (bool, error) {
+	result := r.txm.WithContext(ctx).Model(&biz.AccessRequest{}).
+		Where("uid = ? AND status = ?", request.UID, biz.AccessRequestPending).
+		Updates(map[string]any{
+			"status":         request.Status,
+			"reviewer_id":    request.ReviewerID,
+			"review_comment": request.ReviewComment,
+			"reviewed_at":    request.ReviewedAt,
+			"expires_at":     request.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/protobuf"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+// TestListPendingAccessRequest 只有审批人可以查看待审批的申请
+func TestListPendingAccessRequest(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), 1)
+	logger := log.NewStdLogger(io.Discard)
+	txm := newTestTxm(t)
+	userRepo, roleRepo := NewUserRepo(txm), NewRoleRepo(txm)
+	authz := biz.NewAuthzCache(NewLRUCache(lruCacheSize), event.NewApplicationEventPublisher(), userRepo, roleRepo, NewMenuRepo(txm, logger), logger)
+	uc := biz.NewAccessRequestUsecase(NewAccessRequestRepo(txm), userRepo, roleRepo, NewRoleConstraintRepo(txm), authz, txm, logger)
+
+	approve := []*biz.Action{{Key: biz.AccessRequestApproveAction}}
+	setup := []func() error{
+		func() error { return userRepo.Create(ctx, &biz.User{UID: 1, Username: "approver"}) },
+		func() error { return userRepo.Create(ctx, &biz.User{UID: 2, Username: "requester"}) },
+		func() error { return userRepo.Create(ctx, &biz.User{UID: 3, Username: "reader"}) },
+		func() error {
+			return txm.WithContext(ctx).Create(&biz.Permission{UID: 100, Name: biz.AccessRequestPermission, Actions: approve}).Error
+		},
+		func() error { return roleRepo.Create(ctx, &biz.Role{UID: 10, Name: "approver"}) },
+		func() error { return roleRepo.Create(ctx, &biz.Role{UID: 11, Name: "reader"}) },
+		func() error { return roleRepo.Create(ctx, &biz.Role{UID: 12, Name: "ops"}) },
+		func() error { return roleRepo.BindPermission(ctx, 10, 100, approve, nil) },
+		func() error {
+			return roleRepo.BindPermission(ctx, 11, 100, []*biz.Action{{Key: "READ"}}, nil)
+		},
+		func() error { return userRepo.BindRole(ctx, &biz.UserRole{UserID: 1, RoleID: 10}) },
+		func() error { return userRepo.BindRole(ctx, &biz.UserRole{UserID: 3, RoleID: 11}) },
+		func() error {
+			return uc.CreateAccessRequest(ctx, &biz.AccessRequest{UID: 1000, UserID: 2, RoleID: 12, Duration: 3600, Reason: "oncall"})
+		},
+	}
+	for i, step := range setup {
+		if err := step(); err != nil {
+			t.Fatalf("setup step %d: %v", i, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		reviewerID int64
+		wantErr    error
+	}{
+		{"approver", 1, nil},
+		{"requester", 2, biz.ErrAccessRequestForbidden},
+		{"without approve action", 3, biz.ErrAccessRequestForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := uc.ListPendingAccessRequest(ctx, tt.reviewerID, protobuf.PageWrap(nil))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (len(list) != 1 || list[0].UID != 1000) {
+				t.Fatalf("listed %d requests, want the pending one", len(list))
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type departmentRepo struct {
+	txm orm.Transaction
+}
+
+func NewDepartmentRepo(txm orm.Transaction) biz.DepartmentRepo {
+	return &departmentRepo{
+		txm: txm,
+	}
+}
+
+func (r *departmentRepo) Create(ctx context.Context, department *biz.Department) error {
+	return r.txm.WithContext(ctx).Create(department).Error
+}
+
+func (r *departmentRepo) Update(ctx context.Context, uid int64, department *biz.Department) error {
+	return r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Where("uid = ?", uid).
+		Select("name", "describe", "sort_by").
+		Updates(department).Error
+}
+
+func (r *departmentRepo) Delete(ctx context.Context, uid int64) error {
+	return r.txm.WithContext(ctx).Where("uid = ?", uid).Delete(&biz.Department{}).Error
+}
+
+func (r *departmentRepo) SelectByUID(ctx context.Context, uid int64) (*biz.Department, error) {
+	var department biz.Department
+	err := r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Where("uid = ?", uid).
+		First(&department).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *departmentRepo) SelectByUIDs(ctx context.Context, uids []int64) ([]*biz.Department, error) {
+	var departments []*biz.Department
+	err := r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Where("uid IN (?)", uids).
+		Order("sort_by ASC").
+		Find(&departments).Error
+	return departments, err
+}
+
+func (r *departmentRepo) SelectAll(ctx context.Context) ([]*biz.Department, error) {
+	var departments []*biz.Department
+	err := r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Order("sort_by ASC").
+		Find(&departments).Error
+	return departments, err
+}
+
+func (r *departmentRepo) SelectSubtree(ctx context.Context, path string) ([]*biz.Department, error) {
+	var departments []*biz.Department
+	err := r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Where("path LIKE ?", path+"%").
+		Find(&departments).Error
+	return departments, err
+}
+
+func (r *departmentRepo) UpdatePath(ctx context.Context, uid int64, pid int64, path string) error {
+	return r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Where("uid = ?", uid).
+		Updates(map[string]any{
+			"pid":  pid,
+			"path": path,
+		}).Error
+}
+
+func (r *departmentRepo) CountChildren(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	err := r.txm.WithContext(ctx).Model(&biz.Department{}).
+		Where("pid = ?", uid).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *departmentRepo) CountMembers(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	err := r.txm.WithContext(ctx).Model(&biz.UserDepartment{}).
+		Where("department_id = ?", uid).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *departmentRepo) SelectUserDepartments(ctx context.Context, userID int64) ([]*biz.UserDepartment, error) {
+	var bindings []*biz.UserDepartment
+	err := r.txm.WithContext(ctx).Model(&biz.UserDepartment{}).
+		Where("user_id = ?", userID).
+		Find(&bindings).Error
+	return bindings, err
+}
+
+func (r *departmentRepo) SetUserDepartments(ctx context.Context, userID int64, bindings []*biz.UserDepartment) error {
+	tx := r.txm.WithContext(ctx)
+	if err := tx.Where("user_id = ?", userID).Delete(&biz.UserDepartment{}).Error; err != nil {
+		return err
+	}
+	if len(bindings) <= 0 {
+		return nil
+	}
+	return tx.Create(bindings).Error
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type emailVerificationRepo struct {
+	txm orm.Transaction
+}
+
+func NewEmailVerificationRepo(txm orm.Transaction) biz.EmailVerificationRepo {
+	return &emailVerificationRepo{
+		txm: txm,
+	}
+}
+
+func (r *emailVerificationRepo) Create(ctx context.Context, verification *biz.EmailVerification) error {
+	return r.txm.WithContext(ctx).Create(verification).Error
+}
+
+func (r *emailVerificationRepo) SelectByDigest(ctx context.Context, digest string) (*biz.EmailVerification, error) {
+	var verification biz.EmailVerification
+	err := r.txm.WithContext(ctx).Model(&biz.EmailVerification{}).
+		Where("digest = ?", digest).
+		First(&verification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+func (r *emailVerificationRepo) DeleteByUserID(ctx context.Context, userID int64) error {
+	return r.txm.WithContext(ctx).
+		Where("user_id = ? AND verified_at IS NULL", userID).
+		Delete(&biz.EmailVerification{}).Error
+}
+
+func (r *emailVerificationRepo) Verify(ctx context.Context, uid int64, verifiedAt time.Time) (bool, error) {
+	tx := r.txm.WithContext(ctx).Model(&biz.EmailVerification{}).
+		Where("uid = ? AND verified_at IS NULL", uid).
+		Update("verified_at", verifiedAt)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type fileRepo struct {
+	txm orm.Transaction
+}
+
+func NewFileRepo(txm orm.Transaction) biz.FileRepo {
+	return &fileRepo{
+		txm: txm,
+	}
+}
+
+func (r *fileRepo) Create(ctx context.Context, file *biz.File) error {
+	return r.txm.WithContext(ctx).Create(file).Error
+}
+
+func (r *fileRepo) SelectByUID(ctx context.Context, uid int64) (*biz.File, error) {
+	var file biz.File
+	err := r.txm.WithContext(ctx).Model(&biz.File{}).
+		Where("uid = ?", uid).
+		First(&file).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (r *fileRepo) Delete(ctx context.Context, uid int64) error {
+	return r.txm.WithContext(ctx).
+		Where("uid = ?", uid).
+		Delete(&biz.File{}).Error
+}
+
+func (r *fileRepo) CountByKey(ctx context.Context, key string) (int64, error) {
+	var count int64
+	// 相同内容跨租户共享存储, 直接按表查询以跳过租户限定
+	err := r.txm.WithContext(ctx).Table(biz.File{}.TableName()).
+		Where("storage_key = ? AND deleted_at IS NULL", key).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+// TestOpenAvatar 匿名访问只能读取正在被用户使用的头像
+func TestOpenAvatar(t *testing.T) {
+	txm := newTestTxm(t)
+	store, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("blob store: %v", err)
+	}
+	userRepo := NewUserRepo(txm)
+	uc := biz.NewFileUsecase(NewFileRepo(txm), userRepo, store, txm, log.NewStdLogger(io.Discard))
+	ctx := tenant.NewContext(context.Background(), 7)
+
+	var content bytes.Buffer
+	if err := png.Encode(&content, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	for _, file := range []*biz.File{
+		{UID: 1, Name: "used.png", Purpose: biz.FilePurposeAvatar},
+		{UID: 2, Name: "unused.png", Purpose: biz.FilePurposeAvatar},
+		{UID: 3, Name: "general.png", Purpose: biz.FilePurposeGeneral},
+	} {
+		if err := uc.Upload(ctx, file, content.Bytes()); err != nil {
+			t.Fatalf("upload %s: %v", file.Name, err)
+		}
+	}
+	if err := userRepo.Create(ctx, &biz.User{UID: 1, Username: "a", AvatarID: 1}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	// 绕过 SetAvatar 直接引用通用文件, 也不能公开访问
+	if err := userRepo.Create(ctx, &biz.User{UID: 2, Username: "b", AvatarID: 3}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		uid     int64
+		wantErr error
+	}{
+		{"used avatar", 1, nil},
+		{"unused avatar", 2, biz.ErrFileNotFound},
+		{"general file", 3, biz.ErrFileNotFound},
+		{"missing file", 4, biz.ErrFileNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, rc, err := uc.OpenAvatar(context.Background(), tt.uid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("open error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer rc.Close()
+			got, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if file.UID != tt.uid || !bytes.Equal(got, content.Bytes()) {
+				t.Fatalf("opened file %d with %d bytes, want %d with %d bytes", file.UID, len(got), tt.uid, content.Len())
+			}
+		})
+	}
+
+	// 头像被清除后不再公开
+	if err := userRepo.ClearAvatar(ctx, 1); err != nil {
+		t.Fatalf("clear avatar: %v", err)
+	}
+	if _, _, err := uc.OpenAvatar(context.Background(), 1); !errors.Is(err, biz.ErrFileNotFound) {
+		t.Fatalf("cleared avatar error = %v, want %v", err, biz.ErrFileNotFound)
+	}
+}
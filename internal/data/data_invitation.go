@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type invitationRepo struct {
+	txm orm.Transaction
+}
+
+func NewInvitationRepo(txm orm.Transaction) biz.InvitationRepo {
+	return &invitationRepo{
+		txm: txm,
+	}
+}
+
+func (r *invitationRepo) Create(ctx context.Context, invitation *biz.Invitation) error {
+	return r.txm.WithContext(ctx).Create(invitation).Error
+}
+
+func (r *invitationRepo) SelectByDigest(ctx context.Context, digest string) (*biz.Invitation, error) {
+	var invitation biz.Invitation
+	err := r.txm.WithContext(ctx).Model(&biz.Invitation{}).
+		Where("digest = ?", digest).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepo) DeleteByUserID(ctx context.Context, userID int64) error {
+	return r.txm.WithContext(ctx).
+		Where("user_id = ? AND accepted_at IS NULL", userID).
+		Delete(&biz.Invitation{}).Error
+}
+
+func (r *invitationRepo) Accept(ctx context.Context, uid int64, acceptedAt time.Time) (bool, error) {
+	tx := r.txm.WithContext(ctx).Model(&biz.Invitation{}).
+		Where("uid = ? AND accepted_at IS NULL", uid).
+		Update("accepted_at", acceptedAt)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
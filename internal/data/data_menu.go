@@ -2,11 +2,13 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/protobuf"
 	"github.com/omalloc/kratos-admin/internal/biz"
+	"gorm.io/gorm"
 )
 
 type menuRepo struct {
@@ -27,16 +29,37 @@ func (r *menuRepo) Create(ctx context.Context, m *biz.Menu) error {
 	return r.txm.WithContext(ctx).Create(m).Error
 }
 
-// Update 更新菜单
+// Update 更新菜单, 全量更新可编辑字段 (包括零值); 仅当版本号与 m.Version 一致时更新, 并将版本号加一
 func (r *menuRepo) Update(ctx context.Context, m *biz.Menu) error {
+	version := m.Version
+	m.Version++
+
+	db := r.txm.WithContext(ctx)
+	result := db.Model(&biz.Menu{}).
+		Where("uid = ? AND version = ?", m.UID, version).
+		Select("pid", "permission_id", "name", "icon", "path", "sort_by", "hidden", "status",
+			"titles", "component", "redirect", "target", "keep_alive", "badge", "updated_at", "version").
+		Updates(m)
+	return checkVersioned(db, result, &biz.Menu{}, m.UID, biz.ErrMenuNotFound)
+}
+
+// UpdatePosition 更新上级与排序值, 版本号加一使他人读取的旧版本失效
+func (r *menuRepo) UpdatePosition(ctx context.Context, uid, pid, sortBy int64) error {
+	return r.txm.WithContext(ctx).Model(&biz.Menu{}).
+		Where("uid = ?", uid).
+		Updates(map[string]any{"pid": pid, "sort_by": sortBy, "updated_at": time.Now(), "version": gorm.Expr("version + 1")}).Error
+}
+
+// UnlinkPermission 解除菜单关联的权限并禁用菜单, 版本号加一
+func (r *menuRepo) UnlinkPermission(ctx context.Context, uids ...int64) error {
 	return r.txm.WithContext(ctx).Model(&biz.Menu{}).
-		Where("uid = ?", m.UID).
-		Updates(m).Error
+		Where("uid IN ?", uids).
+		Updates(map[string]any{"permission_id": 0, "status": biz.MenuStatusDisable, "updated_at": time.Now(), "version": gorm.Expr("version + 1")}).Error
 }
 
 // Delete 删除菜单
-func (r *menuRepo) Delete(ctx context.Context, uid int64) error {
-	return r.txm.WithContext(ctx).Model(&biz.Menu{}).Delete("uid = ?", uid).Error
+func (r *menuRepo) Delete(ctx context.Context, uids ...int64) error {
+	return r.txm.WithContext(ctx).Where("uid IN ?", uids).Delete(&biz.Menu{}).Error
 }
 
 // Get 获取菜单
@@ -55,7 +78,7 @@ func (r *menuRepo) SelectList(ctx context.Context, pagination *protobuf.Paginati
 	query := r.txm.WithContext(ctx).Model(&biz.Menu{})
 
 	if name != "" {
-		query = query.Where("name LIKE ?", "%"+name+"%")
+		query = query.Where("name "+likeOperator(query)+" ?", "%"+name+"%")
 	}
 
 	if status > 0 {
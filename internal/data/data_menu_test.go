@@ -0,0 +1,449 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/samber/lo"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+func newTestMenuUsecase(t *testing.T) (*biz.MenuUsecase, *event.ApplicationEventPublisher) {
+	t.Helper()
+
+	return newTestMenuUsecaseTxm(t, newTestTxm(t))
+}
+
+func newTestMenuUsecaseTxm(t *testing.T, txm orm.Transaction) (*biz.MenuUsecase, *event.ApplicationEventPublisher) {
+	t.Helper()
+
+	logger := log.NewStdLogger(io.Discard)
+	publisher := event.NewApplicationEventPublisher()
+	t.Cleanup(func() {
+		_ = publisher.Stop(context.Background())
+	})
+	menuRepo := NewMenuRepo(txm, logger)
+	authz := biz.NewAuthzCache(NewLRUCache(lruCacheSize), publisher, NewUserRepo(txm), NewRoleRepo(txm), menuRepo, logger)
+	return biz.NewMenuUsecase(menuRepo, NewPermissionRepo(txm), authz, txm, logger), publisher
+}
+
+func TestMenuCreateThenUpdate(t *testing.T) {
+	ctx := context.Background()
+	uc, _ := newTestMenuUsecase(t)
+
+	// 新建菜单不需要版本号, 由列默认值设为 1
+	m := &biz.Menu{UID: 1, Name: "dashboard", Path: "/dashboard"}
+	if err := uc.Create(ctx, m); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	got, err := uc.SelectByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if got.Version != 1 {
+		t.Fatalf("version after create = %d, want 1", got.Version)
+	}
+
+	got.Name = "home"
+	if err := uc.Update(ctx, got); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got, err = uc.SelectByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if got.Name != "home" || got.Version != 2 {
+		t.Fatalf("after update name = %s version = %d, want home 2", got.Name, got.Version)
+	}
+
+	tests := []struct {
+		name    string
+		menu    *biz.Menu
+		wantErr error
+	}{
+		{"missing version", &biz.Menu{UID: 1, Name: "home"}, biz.ErrVersionRequired},
+		{"stale version", &biz.Menu{UID: 1, Name: "home", Version: 1}, biz.ErrVersionConflict},
+		{"not found", &biz.Menu{UID: 2, Name: "home", Version: 1}, biz.ErrMenuNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := uc.Update(ctx, tt.menu); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("update error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMenuChangedPublishedOnSuccess 修改失败时不发布菜单变更事件
+func TestMenuChangedPublishedOnSuccess(t *testing.T) {
+	ctx := context.Background()
+	uc, publisher := newTestMenuUsecase(t)
+	events, err := publisher.Subscribe(ctx, biz.EventMenusChanged)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if err := uc.Create(ctx, &biz.Menu{UID: 1, Name: "dashboard"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := uc.Create(ctx, &biz.Menu{UID: 2, PID: 3, Name: "orphan"}); err == nil {
+		t.Fatal("create with a missing parent succeeded")
+	}
+	if err := uc.Update(ctx, &biz.Menu{UID: 1, Name: "home", Version: 2}); !errors.Is(err, biz.ErrVersionConflict) {
+		t.Fatalf("update error = %v, want %v", err, biz.ErrVersionConflict)
+	}
+	if err := uc.Move(ctx, 4, 0, 0); !errors.Is(err, biz.ErrMenuNotFound) {
+		t.Fatalf("move error = %v, want %v", err, biz.ErrMenuNotFound)
+	}
+
+	var published int
+	timeout := time.After(200 * time.Millisecond)
+collect:
+	for {
+		select {
+		case msg := <-events:
+			msg.Ack()
+			published++
+		case <-timeout:
+			break collect
+		}
+	}
+	if published != 1 {
+		t.Fatalf("published %d menu events, want 1", published)
+	}
+}
+
+// createTestMenus 创建菜单树:
+//
+//	1
+//	├── 2
+//	│   └── 3
+//	└── 5
+//	4
+func createTestMenus(t *testing.T, uc *biz.MenuUsecase) {
+	t.Helper()
+
+	for _, m := range []*biz.Menu{
+		{UID: 1, Name: "m1", SortBy: 1},
+		{UID: 2, PID: 1, Name: "m2", SortBy: 1},
+		{UID: 3, PID: 2, Name: "m3", SortBy: 1},
+		{UID: 4, Name: "m4", SortBy: 2},
+		{UID: 5, PID: 1, Name: "m5", SortBy: 2},
+	} {
+		if err := uc.Create(context.Background(), m); err != nil {
+			t.Fatalf("create menu %d: %v", m.UID, err)
+		}
+	}
+}
+
+// menuPositions 读取菜单的上级与排序值
+func menuPositions(t *testing.T, uc *biz.MenuUsecase, uids ...int64) map[int64][2]int64 {
+	t.Helper()
+
+	positions := make(map[int64][2]int64, len(uids))
+	for _, uid := range uids {
+		m, err := uc.SelectByID(context.Background(), uid)
+		if err != nil {
+			t.Fatalf("select menu %d: %v", uid, err)
+		}
+		positions[uid] = [2]int64{m.PID, m.SortBy}
+	}
+	return positions
+}
+
+func TestMenuParentCycle(t *testing.T) {
+	ctx := context.Background()
+	uc, _ := newTestMenuUsecase(t)
+	createTestMenus(t, uc)
+
+	tests := []struct {
+		name    string
+		uid     int64
+		pid     int64
+		wantErr error
+	}{
+		{"self", 2, 2, biz.ErrMenuCycle},
+		{"child", 1, 2, biz.ErrMenuCycle},
+		{"grandchild", 1, 3, biz.ErrMenuCycle},
+		{"missing parent", 2, 9, biz.ErrMenuParentNotFound},
+		{"sibling", 2, 5, nil},
+		{"root", 3, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run("update "+tt.name, func(t *testing.T) {
+			m, err := uc.SelectByID(ctx, tt.uid)
+			if err != nil {
+				t.Fatalf("select: %v", err)
+			}
+			m.PID = tt.pid
+			if err := uc.Update(ctx, m); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("update error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// 以上移动后: 1 -> 5 -> 2, 3 与 4 位于顶层
+	for _, tt := range []struct {
+		name    string
+		uid     int64
+		pid     int64
+		wantErr error
+	}{
+		{"self", 5, 5, biz.ErrMenuCycle},
+		{"grandchild", 1, 2, biz.ErrMenuCycle},
+		{"missing parent", 4, 9, biz.ErrMenuParentNotFound},
+		{"unrelated", 3, 2, nil},
+	} {
+		t.Run("move "+tt.name, func(t *testing.T) {
+			if err := uc.Move(ctx, tt.uid, tt.pid, 0); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("move error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMenuMove(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		uid      int64
+		pid      int64
+		position int
+		want     map[int64][2]int64
+	}{
+		{"to front of siblings", 5, 1, 0, map[int64][2]int64{5: {1, 1}, 2: {1, 2}}},
+		{"to another parent", 4, 1, 1, map[int64][2]int64{2: {1, 1}, 4: {1, 2}, 5: {1, 3}, 1: {0, 1}}},
+		{"position out of range", 3, 1, 99, map[int64][2]int64{2: {1, 1}, 5: {1, 2}, 3: {1, 3}}},
+		{"negative position", 3, 0, -1, map[int64][2]int64{3: {0, 1}, 1: {0, 2}, 4: {0, 3}}},
+		{"not found", 9, 0, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestMenuUsecase(t)
+			createTestMenus(t, uc)
+
+			err := uc.Move(ctx, tt.uid, tt.pid, tt.position)
+			if tt.want == nil {
+				if !errors.Is(err, biz.ErrMenuNotFound) {
+					t.Fatalf("move error = %v, want %v", err, biz.ErrMenuNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("move: %v", err)
+			}
+			got := menuPositions(t, uc, lo.Keys(tt.want)...)
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("positions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMenuReorder(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		pid     int64
+		uids    []int64
+		want    map[int64][2]int64
+		wantErr error
+	}{
+		{"reverse top level", 0, []int64{4, 1}, map[int64][2]int64{4: {0, 1}, 1: {0, 2}}, nil},
+		{"children", 1, []int64{5, 2}, map[int64][2]int64{5: {1, 1}, 2: {1, 2}}, nil},
+		{"missing sibling", 1, []int64{5}, nil, biz.ErrMenuReorderMismatch},
+		{"duplicate", 1, []int64{5, 5}, nil, biz.ErrMenuReorderMismatch},
+		{"not a sibling", 1, []int64{5, 3}, nil, biz.ErrMenuReorderMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestMenuUsecase(t)
+			createTestMenus(t, uc)
+
+			if err := uc.Reorder(ctx, tt.pid, tt.uids); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reorder error = %v, want %v", err, tt.wantErr)
+			}
+			want := tt.want
+			if want == nil {
+				// 失败时排序不变
+				want = map[int64][2]int64{2: {1, 1}, 5: {1, 2}}
+			}
+			if got := menuPositions(t, uc, lo.Keys(want)...); !maps.Equal(got, want) {
+				t.Fatalf("positions = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// createTenantMenus 平台菜单 1, 2 与租户 7 的菜单 11, 12, 13:
+//
+//	1 (平台)
+//	└── 13
+//	11
+//	2 (平台)
+//	12
+func createTenantMenus(t *testing.T, uc *biz.MenuUsecase) context.Context {
+	t.Helper()
+
+	ctx := tenant.NewContext(context.Background(), 7)
+	for _, m := range []*biz.Menu{
+		{UID: 1, Name: "p1", SortBy: 1},
+		{UID: 2, Name: "p2", SortBy: 3},
+	} {
+		if err := uc.Create(context.Background(), m); err != nil {
+			t.Fatalf("create platform menu %d: %v", m.UID, err)
+		}
+	}
+	for _, m := range []*biz.Menu{
+		{UID: 11, Name: "t11", SortBy: 2},
+		{UID: 12, Name: "t12", SortBy: 4},
+		{UID: 13, PID: 1, Name: "t13", SortBy: 1},
+	} {
+		if err := uc.Create(ctx, m); err != nil {
+			t.Fatalf("create tenant menu %d: %v", m.UID, err)
+		}
+	}
+	return ctx
+}
+
+// TestMenuTenantPosition 租户不能修改或移动平台菜单, 排序只在自己的菜单中进行
+func TestMenuTenantPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(ctx context.Context, uc *biz.MenuUsecase) error
+		wantErr error
+		want    map[int64][2]int64
+	}{
+		{"update platform menu", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Update(ctx, &biz.Menu{UID: 1, Name: "x", Version: 1})
+		}, biz.ErrMenuForbidden, nil},
+		{"move platform menu", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Move(ctx, 2, 0, 0)
+		}, biz.ErrMenuForbidden, nil},
+		{"reorder with platform menu", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Reorder(ctx, 0, []int64{12, 2, 11})
+		}, biz.ErrMenuForbidden, nil},
+		{"update own menu", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Update(ctx, &biz.Menu{UID: 11, Name: "x", Version: 1})
+		}, nil, nil},
+		{"move own menu", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Move(ctx, 12, 0, 0)
+		}, nil, map[int64][2]int64{12: {0, 1}, 11: {0, 2}}},
+		{"move under platform menu", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Move(ctx, 11, 1, 0)
+		}, nil, map[int64][2]int64{11: {1, 1}, 13: {1, 2}}},
+		{"reorder own menus", func(ctx context.Context, uc *biz.MenuUsecase) error {
+			return uc.Reorder(ctx, 0, []int64{12, 11})
+		}, nil, map[int64][2]int64{12: {0, 1}, 11: {0, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestMenuUsecase(t)
+			ctx := createTenantMenus(t, uc)
+
+			if err := tt.call(ctx, uc); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			// 平台菜单始终不变
+			want := map[int64][2]int64{1: {0, 1}, 2: {0, 3}}
+			maps.Copy(want, tt.want)
+			if got := menuPositions(t, uc, lo.Keys(want)...); !maps.Equal(got, want) {
+				t.Fatalf("positions = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestMenuTenantDelete 租户不能删除平台菜单, 也不能通过级联删除平台菜单
+func TestMenuTenantDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		uid     int64
+		mode    biz.MenuDeleteMode
+		wantErr error
+		want    []int64
+	}{
+		{"platform menu", 2, biz.MenuDeleteCascade, biz.ErrMenuForbidden, nil},
+		{"platform menu with tenant children", 1, biz.MenuDeleteReparent, biz.ErrMenuForbidden, nil},
+		{"own menu", 12, biz.MenuDeleteCascade, nil, []int64{12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestMenuUsecase(t)
+			ctx := createTenantMenus(t, uc)
+
+			deleted, err := uc.Delete(ctx, tt.uid, tt.mode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(deleted, tt.want) {
+				t.Fatalf("deleted = %v, want %v", deleted, tt.want)
+			}
+			for _, uid := range []int64{1, 2} {
+				if _, err := uc.SelectByID(context.Background(), uid); err != nil {
+					t.Fatalf("platform menu %d: %v", uid, err)
+				}
+			}
+		})
+	}
+
+	// 平台菜单挂在租户菜单下时, 租户不能级联删除
+	uc, _ := newTestMenuUsecase(t)
+	ctx := createTenantMenus(t, uc)
+	if err := uc.Move(context.Background(), 2, 11, 0); err != nil {
+		t.Fatalf("move platform menu: %v", err)
+	}
+	if _, err := uc.Delete(ctx, 11, biz.MenuDeleteCascade); !errors.Is(err, biz.ErrMenuForbidden) {
+		t.Fatalf("cascade error = %v, want %v", err, biz.ErrMenuForbidden)
+	}
+}
+
+// TestMenuTenantRepair 租户只检查和修复自己的菜单
+func TestMenuTenantRepair(t *testing.T) {
+	txm := newTestTxm(t)
+	uc, _ := newTestMenuUsecaseTxm(t, txm)
+	ctx := createTenantMenus(t, uc)
+
+	// 上级不存在的平台菜单与租户菜单
+	if err := txm.WithContext(context.Background()).Create([]*biz.Menu{
+		{UID: 3, PID: 99, Name: "p3"},
+		{UID: 14, PID: 99, TenantID: 7, Name: "t14"},
+	}).Error; err != nil {
+		t.Fatalf("create orphans: %v", err)
+	}
+
+	issues, err := uc.Repair(ctx, false, true)
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if got := lo.Map(issues, func(item *biz.MenuIssue, _ int) int64 { return item.UID }); !slices.Equal(got, []int64{14}) {
+		t.Fatalf("issues = %v, want [14]", got)
+	}
+	if _, err := uc.SelectByID(context.Background(), 3); err != nil {
+		t.Fatalf("platform orphan: %v", err)
+	}
+	if _, err := uc.SelectByID(context.Background(), 14); err == nil {
+		t.Fatal("tenant orphan not deleted")
+	}
+
+	// 超级管理员处理平台菜单
+	issues, err = uc.Repair(context.Background(), false, true)
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if got := lo.Map(issues, func(item *biz.MenuIssue, _ int) int64 { return item.UID }); !slices.Equal(got, []int64{3}) {
+		t.Fatalf("issues = %v, want [3]", got)
+	}
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/omalloc/contrib/kratos/orm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type passwordHistoryRepo struct {
+	txm orm.Transaction
+}
+
+func NewPasswordHistoryRepo(txm orm.Transaction) biz.PasswordHistoryRepo {
+	return &passwordHistoryRepo{
+		txm: txm,
+	}
+}
+
+func (r *passwordHistoryRepo) Create(ctx context.Context, history *biz.PasswordHistory) error {
+	return r.txm.WithContext(ctx).Create(history).Error
+}
+
+func (r *passwordHistoryRepo) SelectRecent(ctx context.Context, userID int64, n int) ([]*biz.PasswordHistory, error) {
+	var list []*biz.PasswordHistory
+	err := r.txm.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(n).
+		Find(&list).Error
+	return list, err
+}
+
+func (r *passwordHistoryRepo) Trim(ctx context.Context, userID int64, n int) error {
+	// 第 n 条历史密码的时间, 早于该时间的记录全部删除
+	var cutoff []time.Time
+	if err := r.txm.WithContext(ctx).Model(&biz.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(n-1).
+		Limit(1).
+		Pluck("created_at", &cutoff).Error; err != nil {
+		return err
+	}
+	if len(cutoff) <= 0 {
+		return nil
+	}
+
+	return r.txm.WithContext(ctx).
+		Where("user_id = ? AND created_at < ?", userID, cutoff[0]).
+		Delete(&biz.PasswordHistory{}).Error
+}
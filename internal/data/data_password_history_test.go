@@ -0,0 +1,57 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+// TestPasswordHistory 依次修改密码, 最近 History 次使用过的密码不能再次使用
+func TestPasswordHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPasswordHistoryRepo(newTestTxm(t))
+	manager := biz.NewPasswordManager(&biz.PasswordPolicy{History: 2}, repo)
+	user := &biz.User{UID: 1, Username: "alice"}
+
+	tests := []struct {
+		password string
+		wantErr  error
+	}{
+		{"first-secret", nil},
+		{"first-secret", biz.ErrPasswordSameAsOld},
+		{"second-secret", nil},
+		{"first-secret", biz.ErrPasswordReused},
+		{"third-secret", nil},
+		// 只保留最近 2 次, 第一次的密码可以再次使用
+		{"first-secret", nil},
+		{"third-secret", biz.ErrPasswordReused},
+		{"", biz.ErrPasswordEmpty},
+		{"short", biz.ErrPasswordPolicy},
+	}
+	for i, tt := range tests {
+		before := user.Password
+		err := manager.Apply(ctx, user, tt.password)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("step %d apply %q: error = %v, want %v", i, tt.password, err, tt.wantErr)
+		}
+		if err != nil && user.Password != before {
+			t.Fatalf("step %d: password changed on error", i)
+		}
+		if err == nil && (user.Password == tt.password || user.PasswordChangedAt == nil) {
+			t.Fatalf("step %d: password not hashed or change time not set", i)
+		}
+	}
+
+	histories, err := repo.SelectRecent(ctx, user.UID, 10)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if len(histories) != 2 {
+		t.Fatalf("kept %d histories, want 2", len(histories))
+	}
+	if histories[0].Password != user.Password {
+		t.Fatal("latest history is not the current password")
+	}
+}
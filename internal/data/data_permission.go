@@ -23,10 +23,16 @@ func (r *permissionRepo) Create(ctx context.Context, permission *biz.Permission)
 	return r.txm.WithContext(ctx).Create(permission).Error
 }
 
+// Update 更新权限的非零值字段, 仅当版本号与 permission.Version 一致时更新, 并将版本号加一
 func (r *permissionRepo) Update(ctx context.Context, uid int64, permission *biz.Permission) error {
-	return r.txm.WithContext(ctx).
-		Where("uid = ?", uid).
-		Updates(permission).Error
+	version := permission.Version
+	permission.Version++
+
+	db := r.txm.WithContext(ctx)
+	result := db.Model(&biz.Permission{}).
+		Where("uid = ? AND version = ?", uid, version).
+		Updates(permission)
+	return checkVersioned(db, result, &biz.Permission{}, uid, biz.ErrPermissionNotFound)
 }
 
 func (r *permissionRepo) Delete(ctx context.Context, uid int64) error {
@@ -55,12 +61,21 @@ func (r *permissionRepo) GetPermission(ctx context.Context, uid int64) (*biz.Per
 	return &permission, nil
 }
 
+func (r *permissionRepo) SelectByIDs(ctx context.Context, uids []int64) ([]*biz.Permission, error) {
+	var permissions []*biz.Permission
+	if err := r.txm.WithContext(ctx).Where("uid IN ?", uids).Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
 func (r *permissionRepo) SelectList(ctx context.Context, name string, status int32, pagination *protobuf.Pagination) ([]*biz.Permission, error) {
 	var permissions []*biz.Permission
 
 	tx := r.txm.WithContext(ctx).Model(&biz.Permission{})
 	if name != "" {
-		tx = tx.Where("name LIKE ?", fmt.Sprintf("%%%s%%", name))
+		tx = tx.Where("name "+likeOperator(tx)+" ?", fmt.Sprintf("%%%s%%", name))
 	}
 	if status != 0 {
 		tx = tx.Where("status = ?", status)
@@ -3,6 +3,8 @@ package data
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/kratos/orm/crud"
@@ -27,6 +29,11 @@ func NewRoleRepo(txm orm.Transaction) biz.RoleRepo {
 	}
 }
 
+// Create overrides crud.Create so that role creation joins the context transaction.
+func (r *roleRepo) Create(ctx context.Context, role *biz.Role) error {
+	return r.txm.WithContext(ctx).Create(role).Error
+}
+
 func (r *roleRepo) Exist(ctx context.Context, name string) bool {
 	err := r.txm.WithContext(ctx).Model(&biz.Role{}).Where("name = ?", name).First(&biz.Role{}).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -88,6 +95,7 @@ func (r *roleRepo) SelectByUserID(ctx context.Context, userID int64) ([]*biz.Rol
 	err := r.txm.WithContext(ctx).Model(&biz.Role{}).
 		Joins("LEFT JOIN users_bind_role ON roles.uid = users_bind_role.role_id").
 		Where("users_bind_role.user_id = ?", userID).
+		Where("(users_bind_role.expires_at IS NULL OR users_bind_role.expires_at > ?)", time.Now()).
 		Find(&roles).Error
 
 	return roles, err
@@ -161,11 +169,67 @@ func (r *roleRepo) UnbindPermission(ctx context.Context, roleID int64, permissio
 		Delete(&biz.RolePermission{}).Error
 }
 
+// CreatePermissions 批量创建角色权限绑定
+func (r *roleRepo) CreatePermissions(ctx context.Context, bindings []*biz.RolePermission) error {
+	return r.txm.WithContext(ctx).Model(&biz.RolePermission{}).
+		CreateInBatches(bindings, 100).Error
+}
+
+func (r *roleRepo) SelectPermissions(ctx context.Context, roleID int64) ([]*biz.RolePermission, error) {
+	var bindings []*biz.RolePermission
+	err := r.txm.WithContext(ctx).Model(&biz.RolePermission{}).
+		Where("role_id = ?", roleID).
+		Find(&bindings).Error
+	return bindings, err
+}
+
+// UpsertPermissions 批量写入角色权限绑定, 已存在的绑定只更新操作权限与数据权限
+func (r *roleRepo) UpsertPermissions(ctx context.Context, bindings []*biz.RolePermission) error {
+	return r.txm.WithContext(ctx).Model(&biz.RolePermission{}).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "role_id"}, {Name: "perm_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"actions", "data_access"}),
+		}).
+		CreateInBatches(bindings, 100).Error
+}
+
+func (r *roleRepo) DeletePermissions(ctx context.Context, roleID int64, permissionIDs []int64) error {
+	return r.txm.WithContext(ctx).
+		Where("role_id = ? AND perm_id IN (?)", roleID, permissionIDs).
+		Delete(&biz.RolePermission{}).Error
+}
+
+// Update 更新角色的全部可编辑字段 (包括零值)
 func (r *roleRepo) Update(ctx context.Context, uid int64, role *biz.Role) error {
-	return r.txm.WithContext(ctx).Where("uid = ?", uid).Updates(role).Error
+	return r.UpdateColumns(ctx, uid, role, []string{"name", "alias", "describe", "status", "is_template"})
+}
+
+// UpdateColumns 更新角色 fields 指定的列, 仅当版本号与 role.Version 一致时更新, 并将版本号加一
+func (r *roleRepo) UpdateColumns(ctx context.Context, uid int64, role *biz.Role, fields []string) error {
+	version := role.Version
+	role.Version++
+
+	db := r.txm.WithContext(ctx)
+	result := db.Model(&biz.Role{}).
+		Where("uid = ? AND version = ?", uid, version).
+		Select(append(slices.Clone(fields), "version")).
+		Updates(role)
+	return checkVersioned(db, result, &biz.Role{}, uid, biz.ErrRoleNotFound)
 }
 
-func (r *roleRepo) GetAll(ctx context.Context) ([]*biz.Role, error) {
+func (r *roleRepo) SelectByIDs(ctx context.Context, roleIDs []int64) ([]*biz.Role, error) {
 	var roles []*biz.Role
-	return roles, r.txm.WithContext(ctx).Model(&biz.Role{}).Find(&roles).Error
+	err := r.txm.WithContext(ctx).Model(&biz.Role{}).
+		Where("uid IN (?)", roleIDs).
+		Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepo) GetAll(ctx context.Context, includeTemplate bool) ([]*biz.Role, error) {
+	var roles []*biz.Role
+	tx := r.txm.WithContext(ctx).Model(&biz.Role{})
+	if !includeTemplate {
+		tx = tx.Where("is_template = ?", false)
+	}
+	return roles, tx.Find(&roles).Error
 }
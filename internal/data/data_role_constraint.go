@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type roleConstraintRepo struct {
+	txm orm.Transaction
+}
+
+func NewRoleConstraintRepo(txm orm.Transaction) biz.RoleConstraintRepo {
+	return &roleConstraintRepo{
+		txm: txm,
+	}
+}
+
+func (r *roleConstraintRepo) Create(ctx context.Context, constraint *biz.RoleConstraint) error {
+	return r.txm.WithContext(ctx).Create(constraint).Error
+}
+
+// Delete 物理删除, 避免唯一索引 (role_a, role_b) 阻止重新创建同一约束
+func (r *roleConstraintRepo) Delete(ctx context.Context, uid int64) error {
+	return r.txm.WithContext(ctx).Unscoped().Where("uid = ?", uid).Delete(&biz.RoleConstraint{}).Error
+}
+
+func (r *roleConstraintRepo) SelectByUID(ctx context.Context, uid int64) (*biz.RoleConstraint, error) {
+	var constraint biz.RoleConstraint
+	err := r.txm.WithContext(ctx).Model(&biz.RoleConstraint{}).
+		Where("uid = ?", uid).
+		First(&constraint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &constraint, nil
+}
+
+func (r *roleConstraintRepo) SelectList(ctx context.Context, pagination *protobuf.Pagination) ([]*biz.RoleConstraint, error) {
+	var constraints []*biz.RoleConstraint
+
+	err := r.txm.WithContext(ctx).Model(&biz.RoleConstraint{}).
+		Count(pagination.Count()).
+		Scopes(pagination.Paginate()).
+		Order("created_at DESC").
+		Find(&constraints).Error
+
+	return constraints, err
+}
+
+func (r *roleConstraintRepo) Exist(ctx context.Context, roleA int64, roleB int64) bool {
+	var count int64
+	r.txm.WithContext(ctx).Model(&biz.RoleConstraint{}).
+		Where("role_a = ? AND role_b = ?", roleA, roleB).
+		Count(&count)
+	return count > 0
+}
+
+func (r *roleConstraintRepo) SelectWithin(ctx context.Context, roleIDs []int64) ([]*biz.RoleConstraint, error) {
+	var constraints []*biz.RoleConstraint
+
+	err := r.txm.WithContext(ctx).Model(&biz.RoleConstraint{}).
+		Where("role_a IN (?) AND role_b IN (?)", roleIDs, roleIDs).
+		Find(&constraints).Error
+
+	return constraints, err
+}
+
+func (r *roleConstraintRepo) SelectViolations(ctx context.Context, constraint *biz.RoleConstraint) ([]*biz.RoleConstraintViolation, error) {
+	var violations []*biz.RoleConstraintViolation
+
+	now := time.Now()
+	err := r.txm.WithContext(ctx).Model(&biz.User{}).
+		Select("users.uid AS user_id, users.username, users.nickname").
+		Joins("INNER JOIN users_bind_role a ON users.uid = a.user_id AND a.role_id = ? AND (a.expires_at IS NULL OR a.expires_at > ?)", constraint.RoleA, now).
+		Joins("INNER JOIN users_bind_role b ON users.uid = b.user_id AND b.role_id = ? AND (b.expires_at IS NULL OR b.expires_at > ?)", constraint.RoleB, now).
+		Find(&violations).Error
+
+	return violations, err
+}
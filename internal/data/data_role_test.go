@@ -0,0 +1,175 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/samber/lo"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+func TestSetRolePermissions(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), 1)
+	logger := log.NewStdLogger(io.Discard)
+	txm := newTestTxm(t)
+	roleRepo := NewRoleRepo(txm)
+	authz := biz.NewAuthzCache(NewLRUCache(lruCacheSize), event.NewApplicationEventPublisher(), NewUserRepo(txm), roleRepo, NewMenuRepo(txm, logger), logger)
+	permissionRepo := NewPermissionRepo(txm)
+	uc := biz.NewRoleUsecase(roleRepo, permissionRepo, NewRoleConstraintRepo(txm), authz, txm, logger)
+
+	const roleID = 1
+	if err := uc.CreateRole(ctx, &biz.Role{UID: roleID, Name: "ops"}); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	for _, uid := range []int64{10, 11, 12} {
+		if err := permissionRepo.Create(ctx, &biz.Permission{UID: uid, Name: fmt.Sprintf("perm%d", uid)}); err != nil {
+			t.Fatalf("create permission: %v", err)
+		}
+	}
+
+	read := []*biz.Action{{Key: "READ"}}
+	readWrite := []*biz.Action{{Key: "READ"}, {Key: "UPDATE"}}
+	binding := func(permID int64, actions []*biz.Action, dataAccess ...*biz.Action) *biz.RolePermission {
+		return &biz.RolePermission{PermID: permID, Actions: actions, DataAccess: dataAccess}
+	}
+
+	// 每一步在上一步的结果上全量设置
+	tests := []struct {
+		name        string
+		bindings    []*biz.RolePermission
+		want        biz.RolePermissionDiff
+		wantActions map[int64]int
+	}{
+		{
+			name:        "add",
+			bindings:    []*biz.RolePermission{binding(10, read), binding(11, read)},
+			want:        biz.RolePermissionDiff{Added: []int64{10, 11}},
+			wantActions: map[int64]int{10: 1, 11: 1},
+		},
+		{
+			name:        "unchanged in another order",
+			bindings:    []*biz.RolePermission{binding(11, read), binding(10, read)},
+			want:        biz.RolePermissionDiff{},
+			wantActions: map[int64]int{10: 1, 11: 1},
+		},
+		{
+			name:        "update actions",
+			bindings:    []*biz.RolePermission{binding(10, readWrite), binding(11, read)},
+			want:        biz.RolePermissionDiff{Updated: []int64{10}},
+			wantActions: map[int64]int{10: 2, 11: 1},
+		},
+		{
+			name:        "update data access",
+			bindings:    []*biz.RolePermission{binding(10, readWrite), binding(11, read, &biz.Action{Key: "SELF"})},
+			want:        biz.RolePermissionDiff{Updated: []int64{11}},
+			wantActions: map[int64]int{10: 2, 11: 1},
+		},
+		{
+			name:        "add update and remove",
+			bindings:    []*biz.RolePermission{binding(11, readWrite), binding(12, read)},
+			want:        biz.RolePermissionDiff{Added: []int64{12}, Updated: []int64{11}, Removed: []int64{10}},
+			wantActions: map[int64]int{11: 2, 12: 1},
+		},
+		{
+			name:        "last duplicate wins",
+			bindings:    []*biz.RolePermission{binding(11, read), binding(12, read), binding(11, readWrite)},
+			want:        biz.RolePermissionDiff{},
+			wantActions: map[int64]int{11: 2, 12: 1},
+		},
+		{
+			name:        "remove all",
+			bindings:    nil,
+			want:        biz.RolePermissionDiff{Removed: []int64{11, 12}},
+			wantActions: map[int64]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := uc.SetRolePermissions(ctx, roleID, tt.bindings)
+			if err != nil {
+				t.Fatalf("set: %v", err)
+			}
+			for _, ids := range [][]int64{diff.Added, diff.Updated, diff.Removed} {
+				slices.Sort(ids)
+			}
+			if !slices.Equal(diff.Added, tt.want.Added) || !slices.Equal(diff.Updated, tt.want.Updated) || !slices.Equal(diff.Removed, tt.want.Removed) {
+				t.Fatalf("diff = %+v, want %+v", *diff, tt.want)
+			}
+
+			bindings, err := roleRepo.SelectPermissions(ctx, roleID)
+			if err != nil {
+				t.Fatalf("select: %v", err)
+			}
+			got := lo.SliceToMap(bindings, func(item *biz.RolePermission) (int64, int) {
+				return item.PermID, len(item.Actions)
+			})
+			if !maps.Equal(got, tt.wantActions) {
+				t.Fatalf("bindings = %v, want %v", got, tt.wantActions)
+			}
+		})
+	}
+}
+
+// TestSetRolePermissionsNotFound 角色或权限不存在时不修改绑定
+func TestSetRolePermissionsNotFound(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), 1)
+	logger := log.NewStdLogger(io.Discard)
+	txm := newTestTxm(t)
+	roleRepo := NewRoleRepo(txm)
+	permissionRepo := NewPermissionRepo(txm)
+	authz := biz.NewAuthzCache(NewLRUCache(lruCacheSize), event.NewApplicationEventPublisher(), NewUserRepo(txm), roleRepo, NewMenuRepo(txm, logger), logger)
+	uc := biz.NewRoleUsecase(roleRepo, permissionRepo, NewRoleConstraintRepo(txm), authz, txm, logger)
+
+	if err := uc.CreateRole(ctx, &biz.Role{UID: 1, Name: "ops"}); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	if err := uc.CreateRole(tenant.NewContext(context.Background(), 2), &biz.Role{UID: 2, Name: "ops"}); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	if err := permissionRepo.Create(ctx, &biz.Permission{UID: 10, Name: "perm10"}); err != nil {
+		t.Fatalf("create permission: %v", err)
+	}
+
+	read := []*biz.Action{{Key: "READ"}}
+	tests := []struct {
+		name     string
+		roleID   int64
+		bindings []*biz.RolePermission
+		wantErr  error
+	}{
+		{"unknown role", 3, []*biz.RolePermission{{PermID: 10, Actions: read}}, biz.ErrRoleNotFound},
+		{"other tenant role", 2, []*biz.RolePermission{{PermID: 10, Actions: read}}, biz.ErrRoleNotFound},
+		{"unknown permission", 1, []*biz.RolePermission{{PermID: 10, Actions: read}, {PermID: 11, Actions: read}}, biz.ErrPermissionNotFound},
+		{"duplicate permission", 1, []*biz.RolePermission{{PermID: 10, Actions: read}, {PermID: 10, Actions: read}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := roleRepo.SelectPermissions(ctx, tt.roleID)
+			if err != nil {
+				t.Fatalf("select: %v", err)
+			}
+			if _, err := uc.SetRolePermissions(ctx, tt.roleID, tt.bindings); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("set error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+			after, err := roleRepo.SelectPermissions(ctx, tt.roleID)
+			if err != nil {
+				t.Fatalf("select: %v", err)
+			}
+			if len(after) != len(before) {
+				t.Fatalf("bindings changed from %d to %d", len(before), len(after))
+			}
+		})
+	}
+}
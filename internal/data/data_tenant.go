@@ -0,0 +1,71 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type tenantRepo struct {
+	txm orm.Transaction
+}
+
+func NewTenantRepo(txm orm.Transaction) biz.TenantRepo {
+	return &tenantRepo{
+		txm: txm,
+	}
+}
+
+func (r *tenantRepo) Create(ctx context.Context, t *biz.Tenant) error {
+	return r.txm.WithContext(ctx).Create(t).Error
+}
+
+func (r *tenantRepo) Update(ctx context.Context, uid int64, t *biz.Tenant) error {
+	return r.txm.WithContext(ctx).Model(&biz.Tenant{}).
+		Where("uid = ?", uid).
+		Select("name", "describe", "status").
+		Updates(t).Error
+}
+
+func (r *tenantRepo) Delete(ctx context.Context, uid int64) error {
+	return r.txm.WithContext(ctx).Where("uid = ?", uid).Delete(&biz.Tenant{}).Error
+}
+
+func (r *tenantRepo) SelectByUID(ctx context.Context, uid int64) (*biz.Tenant, error) {
+	return r.selectOne(ctx, "uid = ?", uid)
+}
+
+func (r *tenantRepo) SelectByCode(ctx context.Context, code string) (*biz.Tenant, error) {
+	return r.selectOne(ctx, "code = ?", code)
+}
+
+func (r *tenantRepo) SelectList(ctx context.Context, pagination *protobuf.Pagination) ([]*biz.Tenant, error) {
+	var tenants []*biz.Tenant
+
+	err := r.txm.WithContext(ctx).Model(&biz.Tenant{}).
+		Count(pagination.Count()).
+		Scopes(pagination.Paginate()).
+		Order("created_at DESC").
+		Find(&tenants).Error
+
+	return tenants, err
+}
+
+func (r *tenantRepo) selectOne(ctx context.Context, query string, args ...any) (*biz.Tenant, error) {
+	var t biz.Tenant
+	err := r.txm.WithContext(ctx).Model(&biz.Tenant{}).
+		Where(query, args...).
+		First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
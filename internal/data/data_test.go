@@ -0,0 +1,69 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/conf"
+	// intslice 序列化器, 程序中由 main 注册
+	_ "github.com/omalloc/kratos-admin/pkg/gorm-schema"
+)
+
+// 测试库默认使用 sqlite, 通过构建标签切换 (见 dialect_*_test.go):
+//
+//	go test ./internal/data/...                  # sqlite
+//	go test -tags postgres ./internal/data/...   # KRATOS_ADMIN_TEST_POSTGRES_DSN
+//	go test -tags mysql ./internal/data/...      # KRATOS_ADMIN_TEST_MYSQL_DSN
+
+var testDatabaseSeq atomic.Int64
+
+// testDatabaseName 每个测试独立的库 (schema) 名
+func testDatabaseName() string {
+	return fmt.Sprintf("kratos_admin_test_%d_%d", time.Now().Unix(), testDatabaseSeq.Add(1))
+}
+
+// openTestDB 连接当前方言的独立测试库, 未执行迁移
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := OpenDatabase(&conf.Data{Database: testDatabase(t)}, log.NewStdLogger(io.Discard))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// newTestMigrator 独立测试库上的迁移器
+func newTestMigrator(t *testing.T) (*gorm.DB, *Migrator) {
+	t.Helper()
+
+	db := openTestDB(t)
+	migrator, err := NewMigrator(db, log.NewStdLogger(io.Discard))
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+	return db, migrator
+}
+
+// newTestTxm 执行全部迁移后的测试库, 不写入初始化数据
+func newTestTxm(t *testing.T) orm.Transaction {
+	t.Helper()
+
+	db, migrator := newTestMigrator(t)
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return NewTransactionManager(&Data{db: db})
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/omalloc/contrib/kratos/orm"
 	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/omalloc/kratos-admin/internal/biz"
 )
@@ -35,6 +37,10 @@ func (r *userRepo) selectByField(ctx context.Context, field string, val any) (*b
 	return &user, err
 }
 
+func (r *userRepo) SelectUserAccount(ctx context.Context, uid int64) (*biz.User, error) {
+	return r.selectByField(ctx, "uid", uid)
+}
+
 func (r *userRepo) SelectUserByName(ctx context.Context, name string) (*biz.User, error) {
 	return r.selectByField(ctx, "username", name)
 }
@@ -67,8 +73,9 @@ func (r *userRepo) SelectUserByUID(ctx context.Context, uid int64) (*biz.UserInf
 	tx := r.txm.WithContext(ctx)
 
 	subQuery := tx.Model(&biz.UserRole{}).
-		Select("user_id, GROUP_CONCAT(role_id) AS role_ids").
+		Select("user_id, "+groupConcat(tx, "role_id")+" AS role_ids").
 		Where("user_id = ?", uid).
+		Where("(expires_at IS NULL OR expires_at > ?)", time.Now()).
 		Group("user_id")
 
 	err := tx.Model(&biz.UserInfo{}).
@@ -97,7 +104,9 @@ func (r *userRepo) SelectList(ctx context.Context, pagination *protobuf.Paginati
 		list []*biz.UserInfo
 		err  error
 	)
-	tx := r.txm.WithContext(ctx).Model(&biz.User{}).
+	db := r.txm.WithContext(ctx)
+	like := likeOperator(db)
+	tx := db.Model(&biz.User{}).
 		Select("users.uid",
 			"users.username",
 			"users.email",
@@ -106,46 +115,131 @@ func (r *userRepo) SelectList(ctx context.Context, pagination *protobuf.Paginati
 			"users.bio",
 			"users.status",
 			"users.last_login",
-			"GROUP_CONCAT(roles.uid) as role_ids").
+			"users.created_at",
+			"users.updated_at",
+			groupConcat(db, "roles.uid")+" as role_ids").
 		Omit("users.password").
-		Joins("LEFT JOIN users_bind_role ON users.uid = users_bind_role.user_id").
+		Joins("LEFT JOIN users_bind_role ON users.uid = users_bind_role.user_id AND (users_bind_role.expires_at IS NULL OR users_bind_role.expires_at > ?)", time.Now()).
 		Joins("LEFT JOIN roles ON users_bind_role.role_id = roles.uid")
-	if filter != nil {
-		if filter.Status > 0 {
-			tx = tx.Where("users.status = ?", filter.Status)
+	if filter == nil {
+		filter = &biz.UserQueryFilter{}
+	}
+	if filter.Status > 0 {
+		tx = tx.Where("users.status = ?", filter.Status)
+	}
+	if strings.Contains(filter.Username, "@") {
+		tx = tx.Where("users.email "+like+" ?", fmt.Sprintf("%%%s%%", filter.Username))
+	} else if filter.Username != "" {
+		tx = tx.Where("users.username "+like+" ?", fmt.Sprintf("%%%s%%", filter.Username))
+	}
+	if filter.Email != "" {
+		tx = tx.Where("users.email "+like+" ?", fmt.Sprintf("%%%s%%", filter.Email))
+	}
+	if filter.Keyword != "" {
+		keyword := fmt.Sprintf("%%%s%%", filter.Keyword)
+		tx = tx.Where(fmt.Sprintf("(users.username %[1]s ? OR users.email %[1]s ? OR users.nickname %[1]s ?)", like), keyword, keyword, keyword)
+	}
+	if len(filter.RoleIDs) > 0 {
+		members := r.txm.WithContext(ctx).Model(&biz.UserRole{}).
+			Select("users_bind_role.user_id").
+			Where("users_bind_role.role_id IN ?", filter.RoleIDs).
+			Where("users_bind_role.expires_at IS NULL OR users_bind_role.expires_at > ?", time.Now())
+		tx = tx.Where("users.uid IN (?)", members)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		tx = tx.Where("users.created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		tx = tx.Where("users.created_at < ?", filter.CreatedBefore)
+	}
+	if !filter.LastLoginAfter.IsZero() {
+		tx = tx.Where("users.last_login >= ?", filter.LastLoginAfter)
+	}
+	if !filter.LastLoginBefore.IsZero() {
+		tx = tx.Where("users.last_login < ?", filter.LastLoginBefore)
+	}
+	if filter.DepartmentID > 0 {
+		var path string
+		if err = r.txm.WithContext(ctx).Model(&biz.Department{}).
+			Where("uid = ?", filter.DepartmentID).
+			Select("path").
+			Scan(&path).Error; err != nil {
+			return nil, err
+		}
+		if path == "" {
+			return list, nil
 		}
-		if strings.Contains(filter.Username, "@") {
-			tx = tx.Where("users.email LIKE ?", fmt.Sprintf("%%%s%%", filter.Username))
-		} else if filter.Username != "" {
-			tx = tx.Where("users.username LIKE ?", fmt.Sprintf("%%%s%%", filter.Username))
+
+		members := r.txm.WithContext(ctx).Model(&biz.UserDepartment{}).
+			Select("users_bind_department.user_id").
+			Joins("INNER JOIN departments ON departments.uid = users_bind_department.department_id AND departments.deleted_at IS NULL").
+			Where("departments.path LIKE ?", path+"%")
+		tx = tx.Where("users.uid IN (?)", members)
+	}
+
+	// 排序字段由 biz 校验过白名单, 相同值时以 uid 保证顺序稳定
+	column := "users." + lo.Ternary(filter.OrderBy == "", biz.UserSortUID, filter.OrderBy)
+	dir, cmp := "DESC", "<"
+	if filter.Asc {
+		dir, cmp = "ASC", ">"
+	}
+	order := fmt.Sprintf("%s %s", column, dir)
+	if column != "users.uid" {
+		order = fmt.Sprintf("%s %s, users.uid %s", column, dir, dir)
+	}
+	tx = tx.
+		Group("users.uid, users.username, users.email, users.avatar_id, users.nickname, users.bio, users.status, users.last_login, users.created_at, users.updated_at").
+		Order(order)
+
+	if after := filter.After; after != nil {
+		if after.Value == nil {
+			tx = tx.Where(fmt.Sprintf("users.uid %s ?", cmp), after.UID)
+		} else {
+			tx = tx.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND users.uid %s ?))", column, cmp, column, cmp), after.Value, after.Value, after.UID)
 		}
+		err = tx.Limit(pagination.Limit()).Find(&list).Error
+		return list, err
 	}
 
 	err = tx.
-		Group("users.uid, users.username, users.email, users.avatar_id, users.nickname, users.bio, users.status, users.last_login").
 		Count(pagination.Count()).
 		Scopes(pagination.Paginate()).
 		Find(&list).Error
 	return list, err
 }
 
+// UpdateRole 全量设置用户角色, 保留仍然存在的授权 (包括其过期时间)
 func (r *userRepo) UpdateRole(ctx context.Context, userID int64, roleIDs []int64) error {
-	err := r.txm.WithContext(ctx).
-		Model(&biz.UserRole{}).
+	var existing []*biz.UserRole
+	if err := r.txm.WithContext(ctx).
 		Where("user_id = ?", userID).
-		Delete(&biz.UserRole{}).Error
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		Find(&existing).Error; err != nil {
 		return err
 	}
 
+	tx := r.txm.WithContext(ctx).Where("user_id = ?", userID)
 	if len(roleIDs) > 0 {
-		roles := make([]*biz.UserRole, 0, len(roleIDs))
-		for _, rid := range roleIDs {
-			roles = append(roles, &biz.UserRole{
-				UserID: userID,
-				RoleID: rid,
-			})
+		tx = tx.Where("role_id NOT IN (?)", roleIDs)
+	}
+	err := tx.Delete(&biz.UserRole{}).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	bound := lo.SliceToMap(existing, func(item *biz.UserRole) (int64, struct{}) {
+		return item.RoleID, struct{}{}
+	})
+	roles := make([]*biz.UserRole, 0, len(roleIDs))
+	for _, rid := range lo.Uniq(roleIDs) {
+		if _, ok := bound[rid]; ok {
+			continue
 		}
+		roles = append(roles, &biz.UserRole{
+			UserID: userID,
+			RoleID: rid,
+		})
+	}
+	if len(roles) > 0 {
 		if err := r.txm.WithContext(ctx).Create(&roles).Error; err != nil {
 			return err
 		}
@@ -154,27 +248,162 @@ func (r *userRepo) UpdateRole(ctx context.Context, userID int64, roleIDs []int64
 	return nil
 }
 
-func (r *userRepo) BindRole(ctx context.Context, userID int64, roleID int64) error {
-	return r.txm.WithContext(ctx).Create(&biz.UserRole{
-		UserID: userID,
-		RoleID: roleID,
-	}).Error
+// BindRole 绑定用户角色, 重复授权时刷新过期时间与授权人
+func (r *userRepo) BindRole(ctx context.Context, binding *biz.UserRole) error {
+	return r.txm.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at", "granted_by", "created_at"}),
+		}).
+		Create(binding).Error
+}
+
+// SelectRoleBindings 用户有效 (未过期) 的角色授权
+func (r *userRepo) SelectRoleBindings(ctx context.Context, uid int64) ([]*biz.UserRole, error) {
+	var bindings []*biz.UserRole
+	err := r.txm.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Where("(expires_at IS NULL OR expires_at > ?)", time.Now()).
+		Order("role_id").
+		Find(&bindings).Error
+	return bindings, err
 }
 
 func (r *userRepo) UnbindRole(ctx context.Context, userID int64, roleID int64) error {
 	return r.txm.WithContext(ctx).Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&biz.UserRole{}).Error
 }
 
+// DeleteExpiredRoles 删除已过期的角色授权, 返回被删除的记录
+func (r *userRepo) DeleteExpiredRoles(ctx context.Context, now time.Time) ([]*biz.UserRole, error) {
+	var expired []*biz.UserRole
+	if err := r.txm.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Find(&expired).Error; err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return expired, nil
+	}
+
+	ids := lo.Map(expired, func(item *biz.UserRole, _ int) int64 {
+		return item.ID
+	})
+	return expired, r.txm.WithContext(ctx).
+		Where("id IN (?)", ids).
+		Delete(&biz.UserRole{}).Error
+}
+
 // Create implements biz.UserRepo.
 func (r *userRepo) Create(ctx context.Context, user *biz.User) error {
 	return r.txm.WithContext(ctx).Create(user).Error
 }
 
 // Delete implements biz.UserRepo.
+// Delete 软删除, 同时将状态标记为已删除
 func (r *userRepo) Delete(ctx context.Context, uid int64) error {
+	if err := r.txm.WithContext(ctx).Model(&biz.User{}).
+		Where("uid = ?", uid).
+		Update("status", biz.UserStatusDeleted).Error; err != nil {
+		return err
+	}
 	return r.txm.WithContext(ctx).Where("uid = ?", uid).Delete(&biz.User{}).Error
 }
 
+func (r *userRepo) SelectDeletedList(ctx context.Context, pagination *protobuf.Pagination) ([]*biz.UserInfo, error) {
+	var list []*biz.UserInfo
+
+	tx := r.txm.WithContext(ctx)
+	subQuery := tx.Model(&biz.UserRole{}).
+		Select("user_id, " + groupConcat(tx, "role_id") + " AS role_ids").
+		Group("user_id")
+
+	err := tx.Unscoped().Model(&biz.User{}).
+		Select(
+			"users.uid",
+			"users.username",
+			"users.email",
+			"users.avatar_id",
+			"users.nickname",
+			"users.bio",
+			"users.status",
+			"users.last_login",
+			"users.created_at",
+			"users.updated_at",
+			"users.deleted_at",
+			"r.role_ids",
+		).
+		Joins("LEFT JOIN (?) AS r ON users.uid = r.user_id", subQuery).
+		Where("users.deleted_at IS NOT NULL AND users.purged_at IS NULL").
+		Order("users.deleted_at DESC").
+		Count(pagination.Count()).
+		Scopes(pagination.Paginate()).
+		Find(&list).Error
+	return list, err
+}
+
+func (r *userRepo) SelectDeletedUser(ctx context.Context, uid int64) (*biz.User, error) {
+	var user biz.User
+	err := r.txm.WithContext(ctx).Unscoped().Model(&biz.User{}).
+		Where("uid = ? AND deleted_at IS NOT NULL AND purged_at IS NULL", uid).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepo) SelectDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*biz.User, error) {
+	var users []*biz.User
+	err := r.txm.WithContext(ctx).Unscoped().Model(&biz.User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND purged_at IS NULL", before).
+		Order("deleted_at").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+func (r *userRepo) Restore(ctx context.Context, uid int64, status int64) error {
+	return r.txm.WithContext(ctx).Unscoped().Model(&biz.User{}).
+		Where("uid = ? AND deleted_at IS NOT NULL AND purged_at IS NULL", uid).
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"status":     status,
+		}).Error
+}
+
+// Purge 物理删除用户的关联数据, 用户记录仅保留 uid 并清空个人信息, 以便其它数据的引用保持有效
+func (r *userRepo) Purge(ctx context.Context, uid int64, purgedAt time.Time) error {
+	related := []any{
+		&biz.UserRole{},
+		&biz.UserDepartment{},
+		&biz.PasswordHistory{},
+		&biz.Invitation{},
+		&biz.EmailVerification{},
+	}
+	for _, model := range related {
+		if err := r.txm.WithContext(ctx).Unscoped().
+			Where("user_id = ?", uid).
+			Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return r.txm.WithContext(ctx).Unscoped().Model(&biz.User{}).
+		Where("uid = ?", uid).
+		Updates(map[string]any{
+			"username":  fmt.Sprintf("deleted-%d", uid),
+			"email":     "",
+			"nickname":  "",
+			"bio":       "",
+			"password":  "",
+			"avatar_id": 0,
+			"purged_at": purgedAt,
+		}).Error
+}
+
 // Update implements biz.UserRepo.
 func (r *userRepo) Update(ctx context.Context, uid int64, user *biz.User) error {
 	return r.txm.WithContext(ctx).Model(&biz.User{}).
@@ -188,6 +417,26 @@ func (r *userRepo) UpdateStatus(ctx context.Context, uid int64, status int64) er
 		Update("status", status).Error
 }
 
+func (r *userRepo) UpdateAvatar(ctx context.Context, uid int64, avatarID int64) error {
+	return r.txm.WithContext(ctx).Model(&biz.User{}).
+		Where("uid = ?", uid).
+		Update("avatar_id", avatarID).Error
+}
+
+func (r *userRepo) ClearAvatar(ctx context.Context, avatarID int64) error {
+	return r.txm.WithContext(ctx).Model(&biz.User{}).
+		Where("avatar_id = ?", avatarID).
+		Update("avatar_id", 0).Error
+}
+
+func (r *userRepo) CountByAvatar(ctx context.Context, avatarID int64) (int64, error) {
+	var count int64
+	err := r.txm.WithContext(ctx).Model(&biz.User{}).
+		Where("avatar_id = ?", avatarID).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *userRepo) UpdateLastLogin(ctx context.Context, uid int64) error {
 	return r.txm.WithContext(ctx).
 		Model(&biz.User{}).
@@ -0,0 +1,204 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/kratos/orm"
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+func newTestUserUsecase(txm orm.Transaction) *biz.UserUsecase {
+	logger := log.NewStdLogger(io.Discard)
+	repo := NewUserRepo(txm)
+	return biz.NewUserUsecase(repo, NewRoleRepo(txm), NewRoleConstraintRepo(txm), NewFileRepo(txm),
+		biz.NewPasswordManager(&biz.PasswordPolicy{}, NewPasswordHistoryRepo(txm)),
+		biz.NewAuthzCache(NewLRUCache(lruCacheSize), event.NewApplicationEventPublisher(), repo, NewRoleRepo(txm), NewMenuRepo(txm, logger), logger),
+		txm, logger)
+}
+
+// TestUserListCursor 按各排序字段逐页读取, 结果与一次读取全部一致
+func TestUserListCursor(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), 1)
+	txm := newTestTxm(t)
+	repo := NewUserRepo(txm)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, status := range []int64{2, 1, 2, 1, 1} {
+		user := &biz.User{
+			UID:       int64(i + 1),
+			Username:  string(rune('e' - i)),
+			Email:     string(rune('a'+i)) + "@example.com",
+			Status:    status,
+			LastLogin: base.Add(time.Duration(i%3) * time.Hour),
+		}
+		// 部分用户的创建时间相同, 由 uid 保证顺序
+		user.CreatedAt = base.Add(time.Duration(i/2) * time.Minute)
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	uc := newTestUserUsecase(txm)
+
+	for _, orderBy := range []string{biz.UserSortUID, biz.UserSortUsername, biz.UserSortEmail, biz.UserSortStatus, biz.UserSortLastLogin, biz.UserSortCreatedAt} {
+		for _, asc := range []bool{false, true} {
+			t.Run(orderBy+lo.Ternary(asc, " asc", " desc"), func(t *testing.T) {
+				all, _, err := uc.ListUser(ctx, protobuf.PageWrap(nil), &biz.UserQueryFilter{OrderBy: orderBy, Asc: asc}, "")
+				if err != nil {
+					t.Fatalf("list all: %v", err)
+				}
+				want := lo.Map(all, func(item *biz.UserInfo, _ int) int64 { return item.UID })
+				if len(want) != 5 {
+					t.Fatalf("listed %d users, want 5", len(want))
+				}
+
+				var (
+					got    []int64
+					cursor string
+				)
+				for page := 0; page < len(want); page++ {
+					list, next, err := uc.ListUser(ctx, protobuf.PageWrap(&protobuf.Pagination{PageSize: 2}), &biz.UserQueryFilter{OrderBy: orderBy, Asc: asc}, cursor)
+					if err != nil {
+						t.Fatalf("list page: %v", err)
+					}
+					for _, item := range list {
+						got = append(got, item.UID)
+					}
+					if next == "" {
+						break
+					}
+					cursor = next
+				}
+				if !slices.Equal(got, want) {
+					t.Fatalf("paged = %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+// TestUserBindingsTenant 不能修改其他租户用户的角色与部门
+func TestUserBindingsTenant(t *testing.T) {
+	txm := newTestTxm(t)
+	uc := newTestUserUsecase(txm)
+	departments := biz.NewDepartmentUsecase(NewDepartmentRepo(txm), NewUserRepo(txm), txm, log.NewStdLogger(io.Discard))
+	ctx := tenant.NewContext(context.Background(), 7)
+
+	for _, user := range []*biz.User{{UID: 1, TenantID: 7, Username: "a"}, {UID: 2, TenantID: 9, Username: "b"}} {
+		if err := NewUserRepo(txm).Create(tenant.NewContext(context.Background(), user.TenantID), user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	if err := NewRoleRepo(txm).Create(ctx, &biz.Role{UID: 10, Name: "ops"}); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	if err := departments.CreateDepartment(ctx, &biz.Department{UID: 20, Name: "dev"}); err != nil {
+		t.Fatalf("create department: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func(userID int64) error
+	}{
+		{"bind role", func(userID int64) error {
+			_, err := uc.BindRole(ctx, userID, 10, 0, 0)
+			return err
+		}},
+		{"update role", func(userID int64) error { return uc.UpdateRole(ctx, userID, []int64{10}) }},
+		{"unbind role", func(userID int64) error { return uc.UnbindRole(ctx, userID, 10) }},
+		{"set department", func(userID int64) error { return departments.SetUserDepartment(ctx, userID, 20, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(2); !errors.Is(err, biz.ErrUserNotFound) {
+				t.Fatalf("other tenant error = %v, want %v", err, biz.ErrUserNotFound)
+			}
+			if err := tt.call(1); err != nil {
+				t.Fatalf("own tenant: %v", err)
+			}
+		})
+	}
+}
+
+// TestUpdatePasswordTenant 重置密码只修改请求租户内该邮箱的用户
+func TestUpdatePasswordTenant(t *testing.T) {
+	txm := newTestTxm(t)
+	uc := newTestUserUsecase(txm)
+	repo := NewUserRepo(txm)
+	for _, user := range []*biz.User{{UID: 1, TenantID: 7, Username: "a"}, {UID: 2, TenantID: 9, Username: "b"}} {
+		user.Email = "same@example.com"
+		if err := repo.Create(tenant.NewContext(context.Background(), user.TenantID), user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	if err := uc.UpdatePassword(context.Background(), "same@example.com", "correct horse"); !errors.Is(err, biz.ErrTenantCodeEmpty) {
+		t.Fatalf("unscoped error = %v, want %v", err, biz.ErrTenantCodeEmpty)
+	}
+	if err := uc.UpdatePassword(tenant.NewContext(context.Background(), 9), "same@example.com", "correct horse"); err != nil {
+		t.Fatalf("update password: %v", err)
+	}
+
+	for _, tt := range []struct {
+		uid     int64
+		changed bool
+	}{{1, false}, {2, true}} {
+		user, err := repo.SelectUserAccount(context.Background(), tt.uid)
+		if err != nil {
+			t.Fatalf("select user: %v", err)
+		}
+		if changed := user.Password != ""; changed != tt.changed {
+			t.Errorf("user %d password changed = %v, want %v", tt.uid, changed, tt.changed)
+		}
+	}
+}
+
+// TestUpdateUserStatus 修改用户时状态只能是正常或禁用
+func TestUpdateUserStatus(t *testing.T) {
+	txm := newTestTxm(t)
+	uc := newTestUserUsecase(txm)
+	repo := NewUserRepo(txm)
+	ctx := tenant.NewContext(context.Background(), 7)
+	if err := repo.Create(ctx, &biz.User{UID: 1, Username: "a", Status: biz.UserStatusNormal}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		status  int64
+		wantErr error
+		want    int64
+	}{
+		{"disable", biz.UserStatusDisabled, nil, biz.UserStatusDisabled},
+		{"unchanged", 0, nil, biz.UserStatusDisabled},
+		{"enable", biz.UserStatusNormal, nil, biz.UserStatusNormal},
+		{"deleted", biz.UserStatusDeleted, biz.ErrUserStatusInvalid, biz.UserStatusNormal},
+		{"pending", biz.UserStatusPending, biz.ErrUserStatusInvalid, biz.UserStatusNormal},
+		{"awaiting approval", biz.UserStatusAwaitingApproval, biz.ErrUserStatusInvalid, biz.UserStatusNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := uc.UpdateUser(ctx, &biz.User{UID: 1, Status: tt.status}); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("update error = %v, want %v", err, tt.wantErr)
+			}
+			user, err := repo.SelectUserByUID(ctx, 1)
+			if err != nil {
+				t.Fatalf("select user: %v", err)
+			}
+			if user.Status != tt.want {
+				t.Fatalf("status = %d, want %d", user.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package data
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// groupConcat 聚合分组内的列为逗号分隔的字符串, 配合 intslice 序列化器使用
+func groupConcat(db *gorm.DB, column string) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return fmt.Sprintf("STRING_AGG(CAST(%s AS TEXT), ',')", column)
+	default:
+		return fmt.Sprintf("GROUP_CONCAT(%s)", column)
+	}
+}
+
+// likeOperator 不区分大小写的模糊匹配; mysql 默认排序规则和 sqlite 的 LIKE 已不区分 (ASCII) 大小写
+func likeOperator(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
@@ -0,0 +1,48 @@
+//go:build mysql
+
+package data
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/conf"
+)
+
+// testDatabase 每个测试新建数据库, 结束后删除.
+// KRATOS_ADMIN_TEST_MYSQL_DSN 不带库名, 例如 root:root@tcp(127.0.0.1:3306)/?parseTime=true
+func testDatabase(t *testing.T) *conf.Data_Database {
+	dsn := os.Getenv("KRATOS_ADMIN_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("KRATOS_ADMIN_TEST_MYSQL_DSN is not set")
+	}
+	base, query, _ := strings.Cut(dsn, "?")
+	if !strings.HasSuffix(base, "/") {
+		t.Fatalf("KRATOS_ADMIN_TEST_MYSQL_DSN must not contain a database name: %s", dsn)
+	}
+
+	admin, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("connect mysql: %v", err)
+	}
+	name := testDatabaseName()
+	if err := admin.Exec(fmt.Sprintf("CREATE DATABASE `%s`", name)).Error; err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = admin.Exec(fmt.Sprintf("DROP DATABASE `%s`", name)).Error
+		if sqlDB, err := admin.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	return &conf.Data_Database{
+		Driver: "mysql",
+		Source: base + name + "?" + query,
+	}
+}
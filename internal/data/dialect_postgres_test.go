@@ -0,0 +1,49 @@
+//go:build postgres
+
+package data
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/conf"
+)
+
+// testDatabase 每个测试在 KRATOS_ADMIN_TEST_POSTGRES_DSN 指向的库中新建 schema, 结束后删除
+func testDatabase(t *testing.T) *conf.Data_Database {
+	dsn := os.Getenv("KRATOS_ADMIN_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("KRATOS_ADMIN_TEST_POSTGRES_DSN is not set")
+	}
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	schema := testDatabaseName()
+	if err := admin.Exec(fmt.Sprintf("CREATE SCHEMA %q", schema)).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = admin.Exec(fmt.Sprintf("DROP SCHEMA %q CASCADE", schema)).Error
+		if sqlDB, err := admin.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	// DSN 可以是 URL 或 key=value 形式
+	source := dsn + " search_path=" + schema
+	if strings.Contains(dsn, "://") {
+		source = dsn + lo.Ternary(strings.Contains(dsn, "?"), "&", "?") + "search_path=" + schema
+	}
+	return &conf.Data_Database{
+		Driver: "postgres",
+		Source: source,
+	}
+}
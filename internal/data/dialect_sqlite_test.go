@@ -0,0 +1,17 @@
+//go:build !postgres && !mysql
+
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/omalloc/kratos-admin/internal/conf"
+)
+
+func testDatabase(t *testing.T) *conf.Data_Database {
+	return &conf.Data_Database{
+		Driver: "sqlite",
+		Source: filepath.Join(t.TempDir(), "kratos.db"),
+	}
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/omalloc/contrib/protobuf"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+// TestUserRoleAggregation 各方言下角色 ID 的聚合 (groupConcat + intslice) 与不区分大小写的模糊匹配 (likeOperator)
+func TestUserRoleAggregation(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), 1)
+	txm := newTestTxm(t)
+	repo := NewUserRepo(txm)
+
+	for _, role := range []*biz.Role{{UID: 11, Name: "admin"}, {UID: 12, Name: "auditor"}} {
+		if err := txm.WithContext(ctx).Create(role).Error; err != nil {
+			t.Fatalf("create role: %v", err)
+		}
+	}
+	for _, user := range []*biz.User{
+		{UID: 1, Username: "Alice", Email: "alice@example.com", Status: 1},
+		{UID: 2, Username: "bob", Email: "BOB@example.com", Status: 1},
+	} {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	for _, roleID := range []int64{11, 12} {
+		if err := repo.BindRole(ctx, &biz.UserRole{UserID: 1, RoleID: roleID}); err != nil {
+			t.Fatalf("bind role: %v", err)
+		}
+	}
+
+	info, err := repo.SelectUserByUID(ctx, 1)
+	if err != nil {
+		t.Fatalf("select user: %v", err)
+	}
+	slices.Sort(info.RoleIDs)
+	if !slices.Equal(info.RoleIDs, []int64{11, 12}) {
+		t.Errorf("SelectUserByUID role ids = %v, want [11 12]", info.RoleIDs)
+	}
+
+	tests := []struct {
+		name   string
+		filter *biz.UserQueryFilter
+		want   map[int64][]int64
+	}{
+		{"all", &biz.UserQueryFilter{}, map[int64][]int64{1: {11, 12}, 2: nil}},
+		{"username ignores case", &biz.UserQueryFilter{Username: "ALI"}, map[int64][]int64{1: {11, 12}}},
+		{"email ignores case", &biz.UserQueryFilter{Email: "bob@"}, map[int64][]int64{2: nil}},
+		{"keyword", &biz.UserQueryFilter{Keyword: "EXAMPLE"}, map[int64][]int64{1: {11, 12}, 2: nil}},
+		{"no match", &biz.UserQueryFilter{Username: "carol"}, map[int64][]int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := repo.SelectList(ctx, protobuf.PageWrap(nil), tt.filter)
+			if err != nil {
+				t.Fatalf("select list: %v", err)
+			}
+			if len(list) != len(tt.want) {
+				t.Fatalf("got %d users, want %d", len(list), len(tt.want))
+			}
+			for _, item := range list {
+				want, ok := tt.want[item.UID]
+				if !ok {
+					t.Fatalf("unexpected user %d", item.UID)
+				}
+				slices.Sort(item.RoleIDs)
+				if !slices.Equal(want, item.RoleIDs) {
+					t.Errorf("user %d role ids = %v, want %v", item.UID, item.RoleIDs, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// Migration 版本化的数据库迁移, Version 为创建时间 (yyyyMMddHHmmss), 按版本升序执行。
+// 迁移只能使用迁移文件内的表结构快照或 SQL, 不能引用 biz 中的模型, 否则模型变化后已有的迁移也会随之变化
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationStatus 迁移的执行状态, AppliedAt 为空表示未执行
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Unknown 数据库中已执行, 但当前程序中不存在的迁移 (通常是更新版本的程序执行的)
+	Unknown bool
+}
+
+type schemaMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+var migrations []*Migration
+
+// registerMigration 在迁移文件的 init 中注册
+func registerMigration(m *Migration) {
+	migrations = append(migrations, m)
+}
+
+// LatestMigrationVersion 程序期望的数据库版本
+func LatestMigrationVersion() int64 {
+	var latest int64
+	for _, m := range migrations {
+		latest = max(latest, m.Version)
+	}
+	return latest
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+	log        *log.Helper
+}
+
+func NewMigrator(db *gorm.DB, logger log.Logger) (*Migrator, error) {
+	sorted := append([]*Migration{}, migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Version == sorted[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", sorted[i].Version)
+		}
+	}
+
+	return &Migrator{
+		db:         db.Session(&gorm.Session{SkipHooks: true}),
+		migrations: sorted,
+		log:        log.NewHelper(logger),
+	}, nil
+}
+
+// Up 按版本顺序执行未执行的迁移, to 大于 0 时只执行到该版本 (含)
+func (m *Migrator) Up(to int64) ([]*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for _, migration := range m.migrations {
+		if to > 0 && migration.Version > to {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		m.log.Infof("migrate up %d_%s", migration.Version, migration.Name)
+		err = m.db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down 按版本倒序回滚最近执行的 steps 个迁移
+func (m *Migrator) Down(steps int) ([]*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		m.log.Infof("migrate down %d_%s", migration.Version, migration.Name)
+		err = m.db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Status 全部迁移的执行状态, 按版本升序
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &row.AppliedAt
+			delete(applied, migration.Version)
+		}
+		ret = append(ret, status)
+	}
+	for _, row := range applied {
+		ret = append(ret, &MigrationStatus{Version: row.Version, Name: row.Name, AppliedAt: &row.AppliedAt, Unknown: true})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Version < ret[j].Version
+	})
+	return ret, nil
+}
+
+// Check 存在未执行的迁移 (数据库落后于程序) 时返回错误
+func (m *Migrator) Check() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	var pending int
+	for _, item := range status {
+		if item.AppliedAt == nil {
+			pending++
+		}
+		if item.Unknown {
+			m.log.Warnf("database has migration %d_%s unknown to this binary", item.Version, item.Name)
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("database schema is behind: %d pending migration(s), expected version %d; run `migrate up` first", pending, LatestMigrationVersion())
+	}
+	return nil
+}
+
+func (m *Migrator) applied() (map[int64]*schemaMigration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var rows []*schemaMigration
+	if err := m.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	ret := make(map[int64]*schemaMigration, len(rows))
+	for _, row := range rows {
+		ret[row.Version] = row
+	}
+	return ret, nil
+}
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+const migrationTemplate = `package data
+
+import "gorm.io/gorm"
+
+func init() {
+	registerMigration(&Migration{
+		Version: %d,
+		Name:    %q,
+		Up: func(tx *gorm.DB) error {
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
+`
+
+// CreateMigration 在 dir 下生成新的迁移文件, 返回文件路径
+func CreateMigration(dir string, name string, now time.Time) (string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid migration name %q, only [a-z0-9_] allowed", name)
+	}
+
+	version := now.UTC().Format("20060102150405")
+	path := filepath.Join(dir, fmt.Sprintf("migration_%s_%s.go", version, name))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var v int64
+	_, _ = fmt.Sscan(version, &v)
+	if _, err = fmt.Fprintf(f, migrationTemplate, v, name); err != nil {
+		return "", err
+	}
+	return path, nil
+}
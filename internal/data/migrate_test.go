@@ -0,0 +1,253 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMigrateUpDown(t *testing.T) {
+	db, migrator := newTestMigrator(t)
+
+	done, err := migrator.Up(0)
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if len(done) != len(migrations) {
+		t.Fatalf("up applied %d migrations, want %d", len(done), len(migrations))
+	}
+	if err := migrator.Check(); err != nil {
+		t.Fatalf("check after up: %v", err)
+	}
+
+	if _, err := migrator.Down(len(migrations)); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	for _, model := range baselineModels {
+		if db.Migrator().HasTable(model) {
+			t.Errorf("table of %T still exists after down", model)
+		}
+	}
+	if err := migrator.Check(); err == nil {
+		t.Fatal("check after down: want pending migrations error")
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("up again: %v", err)
+	}
+	if err := migrator.Check(); err != nil {
+		t.Fatalf("check after up again: %v", err)
+	}
+}
+
+// TestMigratePortableSchema 早期 AutoMigrate 建出的库: 共用的 idx_uid_uk 索引被删除, mysql 上的 tinyint 列改为 boolean
+func TestMigratePortableSchema(t *testing.T) {
+	db, migrator := newTestMigrator(t)
+
+	if _, err := migrator.Up(20261019153935); err != nil {
+		t.Fatalf("up to add_version_columns: %v", err)
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX idx_uid_uk ON roles (uid)").Error; err != nil {
+		t.Fatalf("create legacy index: %v", err)
+	}
+	mysql := db.Dialector.Name() == "mysql"
+	if mysql {
+		if err := db.Exec("ALTER TABLE roles MODIFY is_template tinyint").Error; err != nil {
+			t.Fatalf("alter legacy column: %v", err)
+		}
+	}
+	if err := db.Exec("INSERT INTO roles (uid, name, is_template) VALUES (?, ?, ?)", 1, "template", true).Error; err != nil {
+		t.Fatalf("insert role: %v", err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	if db.Migrator().HasIndex(&baselineRole{}, legacyUIDIndex) {
+		t.Errorf("legacy index %s still exists", legacyUIDIndex)
+	}
+	if !db.Migrator().HasIndex(&baselineRole{}, "idx_roles_uid") {
+		t.Error("index idx_roles_uid is missing")
+	}
+	if mysql {
+		columns, err := db.Migrator().ColumnTypes(&baselineRole{})
+		if err != nil {
+			t.Fatalf("column types: %v", err)
+		}
+		for _, column := range columns {
+			if typ, _ := column.ColumnType(); column.Name() == "is_template" && typ != "tinyint(1)" {
+				t.Errorf("is_template type = %s, want tinyint(1)", typ)
+			}
+		}
+	}
+
+	var role baselineRole
+	if err := db.Where("uid = ?", 1).Take(&role).Error; err != nil {
+		t.Fatalf("select role: %v", err)
+	}
+	if !role.IsTemplate {
+		t.Error("is_template lost after migration")
+	}
+}
+
+// TestMigrateEnableSeededMenus 只启用早期初始化数据写入的平台菜单
+func TestMigrateEnableSeededMenus(t *testing.T) {
+	db, migrator := newTestMigrator(t)
+
+	if _, err := migrator.Up(20261019155626); err != nil {
+		t.Fatalf("up to portable_schema: %v", err)
+	}
+	menus := []struct {
+		uid      int64
+		tenantID int64
+		name     string
+		path     string
+		status   int32
+		want     int32
+	}{
+		{1, 0, "仪表盘", "/", 1, 0},
+		{2, 0, "用户", "/admin/user", 1, 0},
+		{3, 0, "用户管理", "/admin/user", 1, 1},
+		{4, 7, "用户", "/admin/user", 1, 1},
+		{5, 0, "报表", "/report", 1, 1},
+	}
+	for _, m := range menus {
+		if err := db.Exec("INSERT INTO menus (uid, tenant_id, name, path, status) VALUES (?, ?, ?, ?, ?)",
+			m.uid, m.tenantID, m.name, m.path, m.status).Error; err != nil {
+			t.Fatalf("insert menu: %v", err)
+		}
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	for _, m := range menus {
+		var status int32
+		if err := db.Table("menus").Where("uid = ?", m.uid).Select("status").Scan(&status).Error; err != nil {
+			t.Fatalf("select menu: %v", err)
+		}
+		if status != m.want {
+			t.Errorf("menu %d (%s %s) status = %d, want %d", m.uid, m.name, m.path, status, m.want)
+		}
+	}
+}
+
+// TestMigrateDefaultTenantSuperAdmin 平台租户的数据迁入默认租户, 只有绑定 root 角色的用户成为超级管理员
+func TestMigrateDefaultTenantSuperAdmin(t *testing.T) {
+	db, migrator := newTestMigrator(t)
+
+	if _, err := migrator.Up(20261019160618); err != nil {
+		t.Fatalf("up to add_user_sort_indexes: %v", err)
+	}
+	inserts := []struct {
+		sql  string
+		args []any
+	}{
+		{"INSERT INTO users (uid, tenant_id, username) VALUES (?, ?, ?)", []any{1, 0, "admin"}},
+		{"INSERT INTO users (uid, tenant_id, username) VALUES (?, ?, ?)", []any{2, 0, "alice"}},
+		{"INSERT INTO users (uid, tenant_id, username) VALUES (?, ?, ?)", []any{3, 7, "bob"}},
+		{"INSERT INTO roles (uid, tenant_id, name) VALUES (?, ?, ?)", []any{10, 0, "root"}},
+		{"INSERT INTO roles (uid, tenant_id, name) VALUES (?, ?, ?)", []any{11, 0, "user"}},
+		{"INSERT INTO users_bind_role (user_id, role_id) VALUES (?, ?)", []any{1, 10}},
+		{"INSERT INTO users_bind_role (user_id, role_id) VALUES (?, ?)", []any{2, 11}},
+		{"INSERT INTO menus (uid, tenant_id, name) VALUES (?, ?, ?)", []any{20, 0, "仪表盘"}},
+	}
+	for _, insert := range inserts {
+		if err := db.Exec(insert.sql, insert.args...).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	var defaultTenant baselineTenant
+	if err := db.Where("code = ?", "default").Take(&defaultTenant).Error; err != nil {
+		t.Fatalf("select default tenant: %v", err)
+	}
+	tests := []struct {
+		table      string
+		uid        int64
+		tenantID   int64
+		superAdmin bool
+	}{
+		{"users", 1, defaultTenant.UID, true},
+		{"users", 2, defaultTenant.UID, false},
+		{"users", 3, 7, false},
+		{"roles", 10, defaultTenant.UID, false},
+		{"roles", 11, defaultTenant.UID, false},
+		{"menus", 20, 0, false},
+	}
+	for _, tt := range tests {
+		var row struct {
+			TenantID   int64
+			SuperAdmin bool
+		}
+		columns := "tenant_id"
+		if tt.table == "users" {
+			columns = "tenant_id, super_admin"
+		}
+		if err := db.Table(tt.table).Select(columns).Where("uid = ?", tt.uid).Take(&row).Error; err != nil {
+			t.Fatalf("select %s %d: %v", tt.table, tt.uid, err)
+		}
+		if row.TenantID != tt.tenantID || row.SuperAdmin != tt.superAdmin {
+			t.Errorf("%s %d tenant_id = %d super_admin = %v, want %d %v", tt.table, tt.uid, row.TenantID, row.SuperAdmin, tt.tenantID, tt.superAdmin)
+		}
+	}
+}
+
+// TestMigrateAccessRequestPermission 已有数据的库补充审批权限并绑定到 root 与 admin 角色, 空库交给初始化数据
+func TestMigrateAccessRequestPermission(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  bool
+		wantPerm  int64
+		wantBound []int64
+	}{
+		{"existing install", true, 1, []int64{10, 11}},
+		{"empty database", false, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, migrator := newTestMigrator(t)
+			if _, err := migrator.Up(20261019161305); err != nil {
+				t.Fatalf("up to default_tenant_super_admin: %v", err)
+			}
+			if tt.existing {
+				for _, insert := range []string{
+					"INSERT INTO users (uid, tenant_id, username) VALUES (1, 7, 'admin')",
+					"INSERT INTO roles (uid, tenant_id, name) VALUES (10, 7, 'root'), (11, 7, 'admin'), (12, 7, 'user')",
+				} {
+					if err := db.Exec(insert).Error; err != nil {
+						t.Fatalf("insert: %v", err)
+					}
+				}
+			}
+
+			if _, err := migrator.Up(0); err != nil {
+				t.Fatalf("up: %v", err)
+			}
+
+			var perms []*baselinePermission
+			if err := db.Where("name = ?", "access_request").Find(&perms).Error; err != nil {
+				t.Fatalf("select permission: %v", err)
+			}
+			if int64(len(perms)) != tt.wantPerm {
+				t.Fatalf("%d access_request permissions, want %d", len(perms), tt.wantPerm)
+			}
+			if len(perms) == 0 {
+				return
+			}
+			var bound []int64
+			if err := db.Table("roles_bind_permission").Where("perm_id = ? AND tenant_id = ?", perms[0].UID, 7).
+				Order("role_id").Pluck("role_id", &bound).Error; err != nil {
+				t.Fatalf("select bindings: %v", err)
+			}
+			if !slices.Equal(bound, tt.wantBound) {
+				t.Fatalf("bound roles = %v, want %v", bound, tt.wantBound)
+			}
+		})
+	}
+}
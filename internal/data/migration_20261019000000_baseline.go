@@ -0,0 +1,331 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 基线迁移的表结构快照, 即引入版本化迁移时的表结构.
+// 快照不随 biz 中的模型变化, 之后的表结构变更需要新建迁移.
+
+// baselineModel 同 orm.DBModel; 类型未导出, 以具名字段 embedded 方式嵌入, 匿名嵌入会被 gorm 忽略
+type baselineModel struct {
+	CreatedAt time.Time      `gorm:"column:created_at;comment:创建时间"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;comment:更新时间"`
+	DeletedAt gorm.DeletedAt `gorm:"index;column:deleted_at;comment:删除时间"`
+}
+
+type baselineUser struct {
+	ID                int64      `gorm:"primaryKey;type:BIGINT;autoIncrement;"`
+	UID               int64      `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID          int64      `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Username          string     `gorm:"column:username;type:varchar(64);index;comment:用户名"`
+	Password          string     `gorm:"column:password;type:varchar(64);comment:密码"`
+	Email             string     `gorm:"column:email;type:varchar(64);index;comment:邮箱"`
+	Nickname          string     `gorm:"column:nickname;type:varchar(64);comment:昵称"`
+	Bio               string     `gorm:"column:bio;type:varchar(255);comment:个人简介"`
+	AvatarID          int64      `gorm:"column:avatar_id;comment:头像"`
+	Status            int64      `gorm:"column:status;type:int;comment:状态"`
+	LastLogin         time.Time  `gorm:"column:last_login;index;comment:上次登录时间"`
+	PasswordChangedAt *time.Time `gorm:"column:password_changed_at;comment:密码修改时间"`
+	UsernameChangedAt *time.Time `gorm:"column:username_changed_at;comment:用户名修改时间"`
+	PurgedAt          *time.Time `gorm:"column:purged_at;comment:清除时间"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselineRole struct {
+	ID         int64  `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID        int64  `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID   int64  `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Name       string `gorm:"column:name;type:varchar(64);comment:角色唯一标识"`
+	Alias      string `gorm:"column:alias;type:varchar(64);comment:角色别名"`
+	Describe   string `gorm:"column:describe;type:varchar(255);comment:描述"`
+	Status     int64  `gorm:"column:status;type:int;comment:状态"`
+	IsTemplate bool   `gorm:"column:is_template;type:boolean;comment:是否模板角色"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineRole) TableName() string { return "roles" }
+
+type baselinePermission struct {
+	ID       int64  `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	Name     string `gorm:"column:name;type:varchar(64);comment:权限名"`
+	Alias    string `gorm:"column:alias;type:varchar(64);comment:别名,展示名"`
+	Describe string `gorm:"column:describe;type:varchar(255);comment:描述"`
+	Tags     string `gorm:"column:tags;type:json;comment:标签"`
+	Actions  string `gorm:"column:actions;type:json;comment:操作"`
+	Status   int64  `gorm:"column:status;type:int;comment:状态"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselinePermission) TableName() string { return "permissions" }
+
+type baselineRolePermission struct {
+	ID         int64     `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	TenantID   int64     `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	RoleID     int64     `gorm:"column:role_id;type:BIGINT;uniqueIndex:idx_unique_role_perm;comment:角色ID"`
+	PermID     int64     `gorm:"column:perm_id;type:BIGINT;uniqueIndex:idx_unique_role_perm;comment:权限ID"`
+	Actions    string    `gorm:"column:actions;type:json;comment:操作"`
+	DataAccess string    `gorm:"column:data_access;type:json;comment:数据权限"`
+	CreatedAt  time.Time `gorm:"column:created_at;comment:创建时间"`
+	Name       string    `gorm:"column:name"`
+	Alias      string    `gorm:"column:alias"`
+}
+
+func (baselineRolePermission) TableName() string { return "roles_bind_permission" }
+
+type baselineUserRole struct {
+	ID        int64      `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UserID    int64      `gorm:"column:user_id;type:BIGINT;uniqueIndex:idx_unique_user_role;comment:用户ID"`
+	RoleID    int64      `gorm:"column:role_id;type:BIGINT;uniqueIndex:idx_unique_user_role;comment:角色ID"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;index;comment:过期时间"`
+	GrantedBy int64      `gorm:"column:granted_by;type:BIGINT;comment:授权人"`
+	CreatedAt time.Time  `gorm:"column:created_at;comment:创建时间"`
+}
+
+func (baselineUserRole) TableName() string { return "users_bind_role" }
+
+type baselineRoleConstraint struct {
+	ID       int64  `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID int64  `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Name     string `gorm:"column:name;type:varchar(64);comment:约束名称"`
+	Describe string `gorm:"column:describe;type:varchar(255);comment:描述"`
+	RoleA    int64  `gorm:"column:role_a;type:BIGINT;uniqueIndex:idx_unique_role_constraint;comment:角色A"`
+	RoleB    int64  `gorm:"column:role_b;type:BIGINT;uniqueIndex:idx_unique_role_constraint;comment:角色B"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineRoleConstraint) TableName() string { return "roles_constraint" }
+
+type baselineMenu struct {
+	ID           int64          `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID          int64          `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID     int64          `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	PID          int64          `gorm:"column:pid;type:BIGINT;comment:父级ID"`
+	PermissionID int64          `gorm:"column:permission_id;type:BIGINT;comment:权限ID"`
+	Name         string         `gorm:"column:name;type:varchar(255);comment:名称"`
+	Icon         string         `gorm:"column:icon;type:varchar(255);comment:图标"`
+	Path         string         `gorm:"column:path;type:varchar(255);comment:路径"`
+	SortBy       int64          `gorm:"column:sort_by;type:int;comment:排序"`
+	Hidden       bool           `gorm:"column:hidden;type:boolean;comment:是否隐藏=0显示1隐藏"`
+	Titles       string         `gorm:"column:titles;type:json;comment:多语言标题"`
+	Component    string         `gorm:"column:component;type:varchar(255);comment:前端组件路径"`
+	Redirect     string         `gorm:"column:redirect;type:varchar(255);comment:重定向路径"`
+	Target       string         `gorm:"column:target;type:varchar(16);comment:外部链接打开方式"`
+	KeepAlive    bool           `gorm:"column:keep_alive;type:boolean;comment:是否缓存页面"`
+	Badge        string         `gorm:"column:badge;type:varchar(32);comment:角标"`
+	Status       int32          `gorm:"column:status;type:int;comment:状态"`
+	CreatedAt    time.Time      `gorm:"column:created_at;comment:创建时间"`
+	UpdatedAt    time.Time      `gorm:"column:updated_at;comment:更新时间"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at"`
+}
+
+func (baselineMenu) TableName() string { return "menus" }
+
+type baselineCrontab struct {
+	ID        int64      `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID       int64      `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID  int64      `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	Name      string     `gorm:"column:name;type:varchar(255);comment:任务名称;not null"`
+	Expr      string     `gorm:"column:expr;type:varchar(255);comment:Cron表达式;not null"`
+	Action    string     `gorm:"column:action;type:text;comment:任务动作;not null"`
+	Describe  string     `gorm:"column:describe;type:varchar(500);comment:任务描述"`
+	LastRunAt *time.Time `gorm:"column:last_run_at;comment:上次执行时间"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineCrontab) TableName() string { return "crontabs" }
+
+type baselineAccessRequest struct {
+	ID            int64      `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID           int64      `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID      int64      `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	UserID        int64      `gorm:"column:user_id;type:BIGINT;index;comment:申请人"`
+	RoleID        int64      `gorm:"column:role_id;type:BIGINT;comment:申请的角色"`
+	Duration      int64      `gorm:"column:duration;type:BIGINT;comment:申请时长(秒)"`
+	Reason        string     `gorm:"column:reason;type:varchar(500);comment:申请理由"`
+	Status        int64      `gorm:"column:status;type:int;index;comment:状态"`
+	ReviewerID    int64      `gorm:"column:reviewer_id;type:BIGINT;comment:审批人"`
+	ReviewComment string     `gorm:"column:review_comment;type:varchar(500);comment:审批意见"`
+	ReviewedAt    *time.Time `gorm:"column:reviewed_at;comment:审批时间"`
+	ExpiresAt     *time.Time `gorm:"column:expires_at;comment:授权到期时间"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineAccessRequest) TableName() string { return "access_requests" }
+
+type baselineDepartment struct {
+	ID       int64  `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID int64  `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	PID      int64  `gorm:"column:pid;type:BIGINT;index;comment:上级部门"`
+	Name     string `gorm:"column:name;type:varchar(64);comment:部门名称"`
+	Describe string `gorm:"column:describe;type:varchar(255);comment:描述"`
+	SortBy   int64  `gorm:"column:sort_by;type:int;comment:排序"`
+	Path     string `gorm:"column:path;type:varchar(512);index;comment:物化路径"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineDepartment) TableName() string { return "departments" }
+
+type baselineUserDepartment struct {
+	ID           int64     `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UserID       int64     `gorm:"column:user_id;type:BIGINT;uniqueIndex:idx_unique_user_department;comment:用户ID"`
+	DepartmentID int64     `gorm:"column:department_id;type:BIGINT;uniqueIndex:idx_unique_user_department;index;comment:部门ID"`
+	IsPrimary    bool      `gorm:"column:is_primary;type:boolean;comment:是否主部门"`
+	CreatedAt    time.Time `gorm:"column:created_at;comment:创建时间"`
+}
+
+func (baselineUserDepartment) TableName() string { return "users_bind_department" }
+
+type baselineTenant struct {
+	ID       int64  `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	Code     string `gorm:"column:code;type:varchar(64);uniqueIndex:idx_unique_tenant_code;comment:租户编码"`
+	Name     string `gorm:"column:name;type:varchar(64);comment:租户名称"`
+	Describe string `gorm:"column:describe;type:varchar(255);comment:描述"`
+	Status   int64  `gorm:"column:status;type:int;comment:状态"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineTenant) TableName() string { return "tenants" }
+
+type baselineInvitation struct {
+	ID         int64      `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID        int64      `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID   int64      `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	UserID     int64      `gorm:"column:user_id;type:BIGINT;index;comment:被邀请的用户"`
+	Digest     string     `gorm:"column:digest;type:varchar(64);uniqueIndex;comment:令牌摘要"`
+	InvitedBy  int64      `gorm:"column:invited_by;type:BIGINT;comment:邀请人"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;comment:过期时间"`
+	AcceptedAt *time.Time `gorm:"column:accepted_at;comment:激活时间"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineInvitation) TableName() string { return "users_invitation" }
+
+type baselinePasswordHistory struct {
+	ID        int64     `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UserID    int64     `gorm:"column:user_id;type:BIGINT;index;comment:用户ID"`
+	Password  string    `gorm:"column:password;type:varchar(64);comment:密码"`
+	CreatedAt time.Time `gorm:"column:created_at;comment:创建时间"`
+}
+
+func (baselinePasswordHistory) TableName() string { return "users_password_history" }
+
+type baselineFile struct {
+	ID       int64  `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID      int64  `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID int64  `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	OwnerID  int64  `gorm:"column:owner_id;type:BIGINT;index;comment:上传者"`
+	Name     string `gorm:"column:name;type:varchar(255);comment:文件名"`
+	Hash     string `gorm:"column:hash;type:varchar(64);index;comment:内容sha256"`
+	Mime     string `gorm:"column:mime;type:varchar(128);comment:文件类型"`
+	Size     int64  `gorm:"column:size;type:BIGINT;comment:文件大小"`
+	Purpose  int    `gorm:"column:purpose;type:int;comment:用途"`
+	Key      string `gorm:"column:storage_key;type:varchar(255);index;comment:存储key"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineFile) TableName() string { return "files" }
+
+type baselineEmailVerification struct {
+	ID         int64      `gorm:"primaryKey;type:BIGINT;autoIncrement"`
+	UID        int64      `gorm:"column:uid;type:BIGINT;uniqueIndex"`
+	TenantID   int64      `gorm:"column:tenant_id;type:BIGINT;index;comment:租户ID"`
+	UserID     int64      `gorm:"column:user_id;type:BIGINT;index;comment:用户ID"`
+	Email      string     `gorm:"column:email;type:varchar(64);comment:验证的邮箱"`
+	Digest     string     `gorm:"column:digest;type:varchar(64);uniqueIndex;comment:令牌摘要"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;comment:过期时间"`
+	VerifiedAt *time.Time `gorm:"column:verified_at;comment:验证时间"`
+
+	Model baselineModel `gorm:"embedded"`
+}
+
+func (baselineEmailVerification) TableName() string { return "users_email_verification" }
+
+var baselineModels = []any{
+	&baselineUser{},
+	&baselineRole{},
+	&baselinePermission{},
+	&baselineRolePermission{},
+	&baselineUserRole{},
+	&baselineRoleConstraint{},
+	&baselineMenu{},
+	&baselineCrontab{},
+	&baselineAccessRequest{},
+	&baselineDepartment{},
+	&baselineUserDepartment{},
+	&baselineTenant{},
+	&baselineInvitation{},
+	&baselinePasswordHistory{},
+	&baselineFile{},
+	&baselineEmailVerification{},
+}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019000000,
+		Name:    "baseline",
+		Up: func(tx *gorm.DB) error {
+			for _, model := range baselineModels {
+				if err := createOrPatchTable(tx, model); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			models := slices.Clone(baselineModels)
+			slices.Reverse(models)
+			return tx.Migrator().DropTable(models...)
+		},
+	})
+}
+
+// createOrPatchTable 表不存在时按快照建表; 已存在时 (引入版本化迁移之前由 AutoMigrate 维护的数据库) 只补齐缺少的列和索引,
+// 不修改已有列, 已有列的类型变更由之后的迁移显式执行
+func createOrPatchTable(tx *gorm.DB, model any) error {
+	migrator := tx.Migrator()
+	if !migrator.HasTable(model) {
+		return migrator.CreateTable(model)
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || migrator.HasColumn(model, field.DBName) {
+			continue
+		}
+		if err := migrator.AddColumn(model, field.Name); err != nil {
+			return err
+		}
+	}
+	for name := range stmt.Schema.ParseIndexes() {
+		if migrator.HasIndex(model, name) {
+			continue
+		}
+		if err := migrator.CreateIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
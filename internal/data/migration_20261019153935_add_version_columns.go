@@ -0,0 +1,34 @@
+package data
+
+import "gorm.io/gorm"
+
+// versionColumn 乐观锁的版本号列, 已有数据的版本号为默认值 1
+type versionColumn struct {
+	Version int64 `gorm:"column:version;type:BIGINT;not null;default:1;comment:版本号"`
+}
+
+// versionedTables 更新时做乐观锁校验的表
+var versionedTables = []string{"roles", "permissions", "menus"}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019153935,
+		Name:    "add_version_columns",
+		Up: func(tx *gorm.DB) error {
+			for _, table := range versionedTables {
+				if err := tx.Table(table).Migrator().AddColumn(&versionColumn{}, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, table := range versionedTables {
+				if err := tx.Table(table).Migrator().DropColumn(&versionColumn{}, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package data
+
+import "gorm.io/gorm"
+
+// legacyUIDIndex 早期各表 uid 唯一索引共用的名称, mysql 按表区分索引名所以都建成功了;
+// 基线迁移已按表生成 idx_<table>_uid, 旧索引保留只会重复校验
+const legacyUIDIndex = "idx_uid_uk"
+
+// legacyUIDTables 早期使用 idx_uid_uk 的表
+var legacyUIDTables = []any{
+	&baselineRole{},
+	&baselinePermission{},
+	&baselineRoleConstraint{},
+	&baselineMenu{},
+	&baselineCrontab{},
+	&baselineAccessRequest{},
+	&baselineDepartment{},
+	&baselineTenant{},
+	&baselineInvitation{},
+	&baselineFile{},
+	&baselineEmailVerification{},
+}
+
+// legacyColumns 早期声明为 tinyint/datetime 的列, 按基线快照中的类型 (boolean, dialect 默认的时间类型) 修改.
+// 只有 mysql 上存在旧库 (postgres 不支持这两种类型), sqlite 的列类型不影响存取, 不做修改
+var legacyColumns = []struct {
+	model  any
+	fields []string
+}{
+	{&baselineRole{}, []string{"IsTemplate"}},
+	{&baselineUserDepartment{}, []string{"IsPrimary", "CreatedAt"}},
+	{&baselineMenu{}, []string{"Hidden", "KeepAlive", "CreatedAt", "UpdatedAt"}},
+	{&baselineAccessRequest{}, []string{"ReviewedAt", "ExpiresAt"}},
+	{&baselineCrontab{}, []string{"LastRunAt"}},
+	{&baselineInvitation{}, []string{"ExpiresAt", "AcceptedAt"}},
+	{&baselinePasswordHistory{}, []string{"CreatedAt"}},
+	{&baselineEmailVerification{}, []string{"ExpiresAt", "VerifiedAt"}},
+	{&baselineRolePermission{}, []string{"CreatedAt"}},
+	{&baselineUserRole{}, []string{"ExpiresAt", "CreatedAt"}},
+}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019155626,
+		Name:    "portable_schema",
+		Up: func(tx *gorm.DB) error {
+			migrator := tx.Migrator()
+			for _, model := range legacyUIDTables {
+				if !migrator.HasIndex(model, legacyUIDIndex) {
+					continue
+				}
+				if err := migrator.DropIndex(model, legacyUIDIndex); err != nil {
+					return err
+				}
+			}
+
+			if tx.Dialector.Name() != "mysql" {
+				return nil
+			}
+			for _, item := range legacyColumns {
+				for _, field := range item.fields {
+					if err := migrator.AlterColumn(item.model, field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		// 旧的索引与列类型只是重复或不可移植, 回滚不再恢复
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
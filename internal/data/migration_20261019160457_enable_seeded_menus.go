@@ -0,0 +1,36 @@
+package data
+
+import "gorm.io/gorm"
+
+// seededMenus 早期初始化数据写入的平台菜单 (path -> name).
+// 这些菜单的状态被写成了 1 (DISABLE), 当时状态不参与授权所以仍然可见; 菜单授权过滤禁用的菜单后需改为 0 (ENABLE)
+var seededMenus = map[string]string{
+	"/":                 "仪表盘",
+	"/admin":            "系统管理",
+	"/admin/user":       "用户",
+	"/admin/role":       "角色",
+	"/admin/permission": "权限",
+	"/admin/menu":       "菜单",
+	"/admin/crontab":    "定时任务",
+}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019160457,
+		Name:    "enable_seeded_menus",
+		Up: func(tx *gorm.DB) error {
+			for path, name := range seededMenus {
+				if err := tx.Table("menus").
+					Where("tenant_id = ? AND path = ? AND name = ? AND status = ?", 0, path, name, 1).
+					Update("status", 0).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		// 无法区分哪些菜单是本迁移启用的, 回滚不恢复
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
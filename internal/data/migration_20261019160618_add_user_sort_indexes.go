@@ -0,0 +1,44 @@
+package data
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// userSortIndexes 用户列表新增的排序字段
+type userSortIndexes struct {
+	Status    int64     `gorm:"column:status;index:idx_users_status"`
+	CreatedAt time.Time `gorm:"column:created_at;index:idx_users_created_at"`
+}
+
+func (userSortIndexes) TableName() string { return "users" }
+
+var userSortIndexNames = []string{"idx_users_status", "idx_users_created_at"}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019160618,
+		Name:    "add_user_sort_indexes",
+		Up: func(tx *gorm.DB) error {
+			migrator := tx.Migrator()
+			for _, name := range userSortIndexNames {
+				if migrator.HasIndex(&userSortIndexes{}, name) {
+					continue
+				}
+				if err := migrator.CreateIndex(&userSortIndexes{}, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, name := range userSortIndexNames {
+				if err := tx.Migrator().DropIndex(&userSortIndexes{}, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package data
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+)
+
+// userSuperAdmin 超级管理员改由用户标记决定, 不再是 tenant_id 为 0 的用户
+type userSuperAdmin struct {
+	SuperAdmin bool `gorm:"column:super_admin;not null;default:false;comment:是否超级管理员"`
+}
+
+func (userSuperAdmin) TableName() string { return "users" }
+
+// tenantScopedTables 按租户隔离的表, 其中 tenant_id 为 0 的数据迁入默认租户. 菜单属于平台共享, 不迁移
+var tenantScopedTables = []string{
+	"users",
+	"roles",
+	"roles_bind_permission",
+	"roles_constraint",
+	"crontabs",
+	"access_requests",
+	"departments",
+	"users_invitation",
+	"files",
+	"users_email_verification",
+}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019161305,
+		Name:    "default_tenant_super_admin",
+		Up: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&userSuperAdmin{}, "super_admin") {
+				if err := tx.Migrator().AddColumn(&userSuperAdmin{}, "SuperAdmin"); err != nil {
+					return err
+				}
+			}
+
+			// 原来平台租户 (tenant_id 为 0) 的用户都是超级管理员, 只保留绑定了 root 角色的用户
+			if err := tx.Table("users").
+				Where("uid IN (?)", tx.Table("users_bind_role").Select("users_bind_role.user_id").
+					Joins("JOIN roles ON roles.uid = users_bind_role.role_id").
+					Where("roles.name = ? AND roles.deleted_at IS NULL", "root")).
+				Update("super_admin", true).Error; err != nil {
+				return err
+			}
+
+			var tenantID int64
+			for _, table := range tenantScopedTables {
+				var count int64
+				if err := tx.Table(table).Where("tenant_id = ?", 0).Count(&count).Error; err != nil {
+					return err
+				}
+				if count == 0 {
+					continue
+				}
+				if tenantID == 0 {
+					t := &baselineTenant{UID: idgen.NextId(), Code: "default", Name: "默认租户", Describe: "初始化数据创建的默认租户", Status: 1}
+					if err := tx.Where(&baselineTenant{Code: t.Code}).FirstOrCreate(t).Error; err != nil {
+						return err
+					}
+					tenantID = t.UID
+				}
+				if err := tx.Table(table).Where("tenant_id = ?", 0).Update("tenant_id", tenantID).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		// 迁入默认租户的数据无法与原有的默认租户数据区分, 回滚不恢复.
+		// sqlite 驱动的 DropColumn 会重建表并丢失索引, 直接使用 ALTER TABLE
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: "users"}, clause.Column{Name: "super_admin"}).Error
+		},
+	})
+}
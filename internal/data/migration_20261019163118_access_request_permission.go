@@ -0,0 +1,80 @@
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+)
+
+// accessRequestPermission 临时提权审批权限, 早期的库初始化时还没有该权限.
+// 与初始化数据一致, 绑定到 root 与 admin 角色
+var accessRequestPermission = struct {
+	Name     string
+	Alias    string
+	Describe string
+	Tags     string
+	Actions  string
+	Roles    []string
+}{
+	Name:     "access_request",
+	Alias:    "临时提权审批",
+	Describe: "审批用户的临时提权申请",
+	Tags:     `["root","admin"]`,
+	Actions:  `[{"key":"READ","describe":"查看申请","checked":true},{"key":"APPROVE","describe":"审批申请","checked":true}]`,
+	Roles:    []string{"root", "admin"},
+}
+
+func init() {
+	registerMigration(&Migration{
+		Version: 20261019163118,
+		Name:    "access_request_permission",
+		Up: func(tx *gorm.DB) error {
+			// 空库由初始化数据创建, 避免重复
+			var users int64
+			if err := tx.Table("users").Count(&users).Error; err != nil {
+				return err
+			}
+			if users == 0 {
+				return nil
+			}
+
+			p := accessRequestPermission
+			var perm baselinePermission
+			err := tx.Where("name = ?", p.Name).Take(&perm).Error
+			switch {
+			case err == nil:
+				return nil
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			}
+			perm = baselinePermission{UID: idgen.NextId(), Name: p.Name, Alias: p.Alias, Describe: p.Describe, Tags: p.Tags, Actions: p.Actions, Status: 1}
+			if err := tx.Create(&perm).Error; err != nil {
+				return err
+			}
+
+			var roles []*baselineRole
+			if err := tx.Where("name IN ?", p.Roles).Find(&roles).Error; err != nil {
+				return err
+			}
+			for _, role := range roles {
+				if err := tx.Omit("data_access").Create(&baselineRolePermission{
+					TenantID:  role.TenantID,
+					RoleID:    role.UID,
+					PermID:    perm.UID,
+					Actions:   p.Actions,
+					CreatedAt: time.Now(),
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		// 无法区分权限是本迁移创建的还是之后手工创建的, 回滚不删除
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
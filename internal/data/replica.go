@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replicaPingTimeout 单个副本健康探测的超时时间
+const replicaPingTimeout = time.Second
+
+// connDialectors 基于已建立的连接池构造 Dialector, 使 dbresolver 与健康检查共用同一个连接池
+var connDialectors = map[string]func(conn gorm.ConnPool) gorm.Dialector{
+	"sqlite": func(conn gorm.ConnPool) gorm.Dialector {
+		return &sqlite.Dialector{Conn: conn}
+	},
+	"mysql": func(conn gorm.ConnPool) gorm.Dialector {
+		return mysql.New(mysql.Config{Conn: conn})
+	},
+	"postgres": func(conn gorm.ConnPool) gorm.Dialector {
+		return postgres.New(postgres.Config{Conn: conn})
+	},
+}
+
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// Replicas 只读副本.
+// 事务外的查询按轮询路由到健康的副本, 写入及事务内的语句始终使用主库;
+// 副本的可用性由健康检查 (见 server.NewChecker) 探测, 全部不可用时读请求回落到主库.
+type Replicas struct {
+	primary gorm.ConnPool
+	nodes   []*replica
+	next    atomic.Uint64
+	// degraded 全部副本不可用
+	degraded atomic.Bool
+	log      *log.Helper
+}
+
+// useReplicas 为 db 注册只读副本, 未配置或全部无法连接时返回 nil
+func useReplicas(db *gorm.DB, driver string, sources []string, logger log.Logger) (*Replicas, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	open, ok := driverSupported[driver]
+	if !ok {
+		driver, open = "sqlite", sqlite.Open
+	}
+
+	r := &Replicas{
+		primary: db.Config.ConnPool,
+		log:     log.NewHelper(logger),
+	}
+	for i, source := range sources {
+		name := fmt.Sprintf("replica#%d", i)
+		// 启动时无法连接的副本不参与路由, 避免因副本故障导致服务无法启动
+		conn, err := gorm.Open(open(source), &gorm.Config{Logger: gormlogger.Discard})
+		if err != nil {
+			r.log.Warnf("skip database %s: %v", name, err)
+			continue
+		}
+		sqlDB, err := conn.DB()
+		if err != nil {
+			return nil, err
+		}
+		node := &replica{name: name, db: sqlDB}
+		node.healthy.Store(true)
+		r.nodes = append(r.nodes, node)
+	}
+	if len(r.nodes) == 0 {
+		r.log.Warn("no database replica available, all queries use the primary")
+		return nil, nil
+	}
+
+	// 主库作为最后一个副本注册, 只在全部副本不可用时使用;
+	// 同时保证副本数大于 1, dbresolver 只有一个副本时不会经过 Policy
+	dialectors := make([]gorm.Dialector, 0, len(r.nodes)+1)
+	for _, node := range r.nodes {
+		dialectors = append(dialectors, connDialectors[driver](node.db))
+	}
+	dialectors = append(dialectors, connDialectors[driver](r.primary))
+
+	err := db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   r,
+	}))
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Resolve 实现 dbresolver.Policy, 轮询选择健康的副本
+func (r *Replicas) Resolve([]gorm.ConnPool) gorm.ConnPool {
+	n := uint64(len(r.nodes))
+	start := r.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if node := r.nodes[(start+i)%n]; node.healthy.Load() {
+			return node.db
+		}
+	}
+	return r.primary
+}
+
+// Check 探测全部副本并更新其可用状态.
+// 副本不可用时读请求回落到主库, 服务仍然可用, 因此只记录日志而不返回错误, 避免就绪检查失败
+func (r *Replicas) Check(ctx context.Context) error {
+	var healthy int
+	for _, node := range r.nodes {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := node.db.PingContext(pingCtx)
+		cancel()
+
+		if was := node.healthy.Swap(err == nil); was != (err == nil) {
+			if err != nil {
+				r.log.Warnf("database %s is down: %v", node.name, err)
+			} else {
+				r.log.Infof("database %s is up", node.name)
+			}
+		}
+		if err == nil {
+			healthy++
+		}
+	}
+
+	if degraded := healthy == 0; r.degraded.Swap(degraded) != degraded {
+		if degraded {
+			r.log.Warnf("all %d database replicas are down, queries fall back to the primary", len(r.nodes))
+		} else {
+			r.log.Infof("%d of %d database replicas are up", healthy, len(r.nodes))
+		}
+	}
+	return nil
+}
+
+func (r *Replicas) Close() {
+	for _, node := range r.nodes {
+		_ = node.db.Close()
+	}
+}
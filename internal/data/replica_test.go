@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// TestReplicasCheck 副本全部不可用时读请求回落到主库, 健康检查不失败
+func TestReplicasCheck(t *testing.T) {
+	dir := t.TempDir()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "primary.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open primary: %v", err)
+	}
+	sources := []string{filepath.Join(dir, "replica0.db"), filepath.Join(dir, "replica1.db")}
+	replicas, err := useReplicas(db, "sqlite", sources, log.NewStdLogger(io.Discard))
+	if err != nil {
+		t.Fatalf("use replicas: %v", err)
+	}
+	t.Cleanup(replicas.Close)
+
+	ctx := context.Background()
+	if err := replicas.Check(ctx); err != nil {
+		t.Fatalf("check healthy replicas: %v", err)
+	}
+	if pool := replicas.Resolve(nil); pool == replicas.primary {
+		t.Fatal("resolved the primary while replicas are healthy")
+	}
+
+	_ = replicas.nodes[0].db.Close()
+	if err := replicas.Check(ctx); err != nil {
+		t.Fatalf("check with one replica down: %v", err)
+	}
+	for i := 0; i < len(replicas.nodes); i++ {
+		if pool := replicas.Resolve(nil); pool != replicas.nodes[1].db {
+			t.Fatal("resolved a replica that is down")
+		}
+	}
+
+	_ = replicas.nodes[1].db.Close()
+	if err := replicas.Check(ctx); err != nil {
+		t.Fatalf("check with all replicas down: %v", err)
+	}
+	if !replicas.degraded.Load() {
+		t.Error("replicas not marked as degraded")
+	}
+	if pool := replicas.Resolve(nil); pool != replicas.primary {
+		t.Fatal("did not fall back to the primary")
+	}
+}
@@ -45,29 +45,35 @@ func SeedData(db *gorm.DB) error {
 
 	// 开始事务
 	return db.Transaction(func(tx *gorm.DB) error {
-		// 1. 初始化权限
+		// 1. 初始化默认租户
+		tenantID, err := sd.seedTenant(tx)
+		if err != nil {
+			return err
+		}
+
+		// 2. 初始化权限
 		permissions, err := sd.seedPermissions(tx)
 		if err != nil {
 			return err
 		}
 
-		// 2. 初始化角色
-		roles, err := sd.seedRoles(tx)
+		// 3. 初始化角色
+		roles, err := sd.seedRoles(tenantID, tx)
 		if err != nil {
 			return err
 		}
 
-		// 3. 初始化角色权限关联
+		// 4. 初始化角色权限关联
 		if err := sd.seedRolePermissions(roles, permissions, tx); err != nil {
 			return err
 		}
 
-		// 4. 初始化管理员用户
-		if err := sd.seedAdminUser(roles, tx); err != nil {
+		// 5. 初始化管理员用户
+		if err := sd.seedAdminUser(tenantID, roles, tx); err != nil {
 			return err
 		}
 
-		// 5. 初始化菜单
+		// 6. 初始化菜单, 菜单属于平台, 对所有租户共享
 		if err := sd.seedMenus(permissions, tx); err != nil {
 			return err
 		}
@@ -90,6 +96,24 @@ func (sd *Seed) Check() (bool, error) {
 	return false, nil
 }
 
+// seedTenant 初始化默认租户, 已存在时直接使用
+func (sd *Seed) seedTenant(tx *gorm.DB) (int64, error) {
+	t := &biz.Tenant{
+		UID:      sd.idGen.Generate().Int64(),
+		Code:     biz.DefaultTenantCode,
+		Name:     "默认租户",
+		Describe: "初始化数据创建的默认租户",
+		Status:   1,
+	}
+	if err := tx.Where(&biz.Tenant{Code: t.Code}).FirstOrCreate(t).Error; err != nil {
+		log.Errorf("默认租户初始化失败: %v", err)
+		return 0, err
+	}
+
+	log.Info("默认租户初始化完成")
+	return t.UID, nil
+}
+
 // seedPermissions 初始化权限数据
 func (sd *Seed) seedPermissions(tx *gorm.DB) ([]*biz.Permission, error) {
 	permissions := []*biz.Permission{
@@ -175,6 +199,18 @@ func (sd *Seed) seedPermissions(tx *gorm.DB) ([]*biz.Permission, error) {
 				{Key: "DELETE", Describe: "删除定时任务", Checked: true},
 			},
 		},
+		{
+			UID:      sd.idGen.Generate().Int64(),
+			Name:     biz.AccessRequestPermission,
+			Alias:    "临时提权审批",
+			Describe: "审批用户的临时提权申请",
+			Status:   1,
+			Tags:     []string{"root", "admin"},
+			Actions: []*biz.Action{
+				{Key: "READ", Describe: "查看申请", Checked: true},
+				{Key: biz.AccessRequestApproveAction, Describe: "审批申请", Checked: true},
+			},
+		},
 	}
 
 	if err := tx.CreateInBatches(permissions, 100).Error; err != nil {
@@ -187,7 +223,7 @@ func (sd *Seed) seedPermissions(tx *gorm.DB) ([]*biz.Permission, error) {
 }
 
 // seedRoles 初始化角色数据
-func (sd *Seed) seedRoles(tx *gorm.DB) ([]*biz.Role, error) {
+func (sd *Seed) seedRoles(tenantID int64, tx *gorm.DB) ([]*biz.Role, error) {
 	roles := []*biz.Role{
 		{
 			UID:      sd.idGen.Generate().Int64(),
@@ -212,6 +248,9 @@ func (sd *Seed) seedRoles(tx *gorm.DB) ([]*biz.Role, error) {
 		},
 	}
 
+	for _, role := range roles {
+		role.TenantID = tenantID
+	}
 	if err := tx.CreateInBatches(roles, 100).Error; err != nil {
 		log.Errorf("角色数据初始化失败: %v", err)
 		return nil, err
@@ -230,6 +269,7 @@ func (sd *Seed) seedRolePermissions(roles []*biz.Role, permissions []*biz.Permis
 			}
 
 			bindings = append(bindings, &biz.RolePermission{
+				TenantID:   role.TenantID,
 				RoleID:     role.UID,
 				PermID:     permission.UID,
 				Actions:    permission.Actions,
@@ -250,16 +290,18 @@ func (sd *Seed) seedRolePermissions(roles []*biz.Role, permissions []*biz.Permis
 }
 
 // seedAdminUser 初始化管理员用户
-func (sd *Seed) seedAdminUser(roles []*biz.Role, tx *gorm.DB) error {
+func (sd *Seed) seedAdminUser(tenantID int64, roles []*biz.Role, tx *gorm.DB) error {
 	// 创建超级管理员用户
 	adminUser := &biz.User{
-		UID:      sd.idGen.Generate().Int64(),
-		Username: "admin",
-		Password: "12346578",
-		Email:    "admin@example.com",
-		Nickname: "超级管理员",
-		Bio:      "系统超级管理员",
-		Status:   int64(administration.UserStatus_NORMAL),
+		UID:        sd.idGen.Generate().Int64(),
+		TenantID:   tenantID,
+		Username:   "admin",
+		Password:   "12346578",
+		Email:      "admin@example.com",
+		Nickname:   "超级管理员",
+		Bio:        "系统超级管理员",
+		Status:     int64(administration.UserStatus_NORMAL),
+		SuperAdmin: true,
 	}
 
 	if err := tx.Create(adminUser).Error; err != nil {
@@ -294,46 +336,54 @@ func (sd *Seed) seedMenus(permissions []*biz.Permission, tx *gorm.DB) error {
 	menus := make([]*biz.Menu, 0)
 
 	type MenuResource struct {
-		Name string
-		Icon string
-		Path string
+		Name  string
+		Title string // 英文标题
+		Icon  string
+		Path  string
 	}
 	// resource
 	mapResource := map[string]MenuResource{
 		"dashboard": {
-			Name: "仪表盘",
-			Icon: "icon-app-box",
-			Path: "/",
+			Name:  "仪表盘",
+			Title: "Dashboard",
+			Icon:  "icon-app-box",
+			Path:  "/",
 		},
 		"system": {
-			Name: "系统管理",
-			Icon: "icon-config",
-			Path: "/admin",
+			Name:  "系统管理",
+			Title: "System",
+			Icon:  "icon-config",
+			Path:  "/admin",
 		},
 		"user": {
-			Name: "用户",
-			Icon: "icon-user",
-			Path: "/admin/user",
+			Name:  "用户",
+			Title: "Users",
+			Icon:  "icon-user",
+			Path:  "/admin/user",
 		},
 		"role": {
-			Name: "角色",
-			Icon: "icon-addteam",
-			Path: "/admin/role",
+			Name:  "角色",
+			Title: "Roles",
+			Icon:  "icon-addteam",
+			Path:  "/admin/role",
 		},
 		"permission": {
-			Name: "权限",
-			Icon: "icon-securityscan",
-			Path: "/admin/permission",
+			Name:  "权限",
+			Title: "Permissions",
+			Icon:  "icon-securityscan",
+			Path:  "/admin/permission",
 		},
 		"menu": {
-			Name: "菜单",
-			Icon: "icon-resource",
-			Path: "/admin/menu",
+			Name:  "菜单",
+			Title: "Menus",
+			Icon:  "icon-resource",
+			Path:  "/admin/menu",
 		},
 		"crontab": {
-			Name: "定时任务",
-			Icon: "icon-schedule",
-			Path: "/admin/crontab",
+			Name:  "定时任务",
+			Title: "Crontab",
+			Icon:  "icon-schedule",
+			Path:  "/admin/crontab",
 		},
 	}
 	// dashboard
@@ -342,11 +392,12 @@ func (sd *Seed) seedMenus(permissions []*biz.Permission, tx *gorm.DB) error {
 		PID:          0,
 		PermissionID: 0,
 		Name:         "仪表盘",
+		Titles:       map[string]string{"en": "Dashboard"},
 		Icon:         "icon-app-box",
 		Path:         "/",
 		SortBy:       1,
 		Hidden:       false,
-		Status:       1,
+		Status:       biz.MenuStatusEnable,
 	}
 	menus = append(menus, dashboard)
 
@@ -356,11 +407,12 @@ func (sd *Seed) seedMenus(permissions []*biz.Permission, tx *gorm.DB) error {
 		PID:          0,
 		PermissionID: 0,
 		Name:         "系统管理",
+		Titles:       map[string]string{"en": "System"},
 		Icon:         "icon-config",
 		Path:         "/admin",
 		SortBy:       2,
 		Hidden:       false,
-		Status:       1,
+		Status:       biz.MenuStatusEnable,
 	}
 	menus = append(menus, system)
 	for i, permission := range permissions {
@@ -374,10 +426,11 @@ func (sd *Seed) seedMenus(permissions []*biz.Permission, tx *gorm.DB) error {
 				PID:          system.PID,
 				PermissionID: permission.UID,
 				Name:         item.Name,
+				Titles:       map[string]string{"en": item.Title},
 				Icon:         item.Icon,
 				Path:         item.Path,
 				Hidden:       false,
-				Status:       1,
+				Status:       biz.MenuStatusEnable,
 				SortBy:       int64(i + 2),
 			})
 		}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+// TestSeedDefaultTenant 初始化数据写入默认租户, 菜单属于平台
+func TestSeedDefaultTenant(t *testing.T) {
+	db, migrator := newTestMigrator(t)
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	if err := SeedData(db); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	var defaultTenant biz.Tenant
+	if err := db.Where("code = ?", biz.DefaultTenantCode).Take(&defaultTenant).Error; err != nil {
+		t.Fatalf("select default tenant: %v", err)
+	}
+	var admin biz.User
+	if err := db.Where("username = ?", "admin").Take(&admin).Error; err != nil {
+		t.Fatalf("select admin: %v", err)
+	}
+	if admin.TenantID != defaultTenant.UID || !admin.SuperAdmin {
+		t.Fatalf("admin tenant_id = %d super_admin = %v, want %d true", admin.TenantID, admin.SuperAdmin, defaultTenant.UID)
+	}
+
+	tests := []struct {
+		model any
+		want  int64
+	}{
+		{&biz.Role{}, defaultTenant.UID},
+		{&biz.RolePermission{}, defaultTenant.UID},
+		{&biz.Menu{}, 0},
+	}
+	for _, tt := range tests {
+		var count int64
+		if err := db.Model(tt.model).Where("tenant_id <> ?", tt.want).Count(&count).Error; err != nil {
+			t.Fatalf("count %T: %v", tt.model, err)
+		}
+		if count != 0 {
+			t.Errorf("%d rows of %T not in tenant %d", count, tt.model, tt.want)
+		}
+	}
+}
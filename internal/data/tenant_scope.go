@@ -0,0 +1,134 @@
+package data
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+const tenantColumn = "tenant_id"
+
+// tenantShared 平台租户的数据对所有租户只读可见
+type tenantShared interface {
+	TenantShared() bool
+}
+
+// registerTenantScope 为包含 tenant_id 字段的模型自动限定租户.
+//
+// 租户取自 context (见 tenant.NewContext), context 中没有租户时不做限定,
+// 例如超级管理员、后台任务以及初始化数据.
+func registerTenantScope(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", tenantCreate); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", tenantQuery); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:row", tenantQuery); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", tenantWrite); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("tenant:delete", tenantWrite)
+}
+
+func tenantField(db *gorm.DB) (*schema.Field, int64, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+	field, ok := db.Statement.Schema.FieldsByDBName[tenantColumn]
+	if !ok {
+		return nil, 0, false
+	}
+	tenantID, ok := tenant.FromContext(db.Statement.Context)
+	return field, tenantID, ok
+}
+
+// tenantCreate 新建的数据归属当前租户.
+// context 中没有租户时 (超级管理员未指定 X-Tenant-ID, 后台任务等) 需要调用方显式设置 tenant_id,
+// 除共享模型外不允许写入 tenant_id 为 0 的数据
+func tenantCreate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	field, ok := db.Statement.Schema.FieldsByDBName[tenantColumn]
+	if !ok {
+		return
+	}
+
+	ctx := db.Statement.Context
+	tenantID, scoped := tenant.FromContext(ctx)
+	shared, _ := reflect.New(db.Statement.Schema.ModelType).Interface().(tenantShared)
+	apply := func(rv reflect.Value) error {
+		if scoped {
+			return field.Set(ctx, rv, tenantID)
+		}
+		if _, zero := field.ValueOf(ctx, rv); zero && (shared == nil || !shared.TenantShared()) {
+			return tenant.ErrInvalidTenant
+		}
+		return nil
+	}
+
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := apply(reflect.Indirect(rv.Index(i))); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := apply(rv); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
+
+// tenantQuery 查询限定当前租户, 共享模型额外包含平台租户的数据
+func tenantQuery(db *gorm.DB) {
+	_, tenantID, ok := tenantField(db)
+	if !ok {
+		return
+	}
+
+	column := clause.Column{Table: clause.CurrentTable, Name: tenantColumn}
+	if shared, ok := reflect.New(db.Statement.Schema.ModelType).Interface().(tenantShared); ok && shared.TenantShared() && tenantID != tenant.PlatformID {
+		addTenantCondition(db.Statement, clause.IN{Column: column, Values: []any{tenant.PlatformID, tenantID}})
+		return
+	}
+	addTenantCondition(db.Statement, clause.Eq{Column: column, Value: tenantID})
+}
+
+// tenantWrite 更新和删除仅限当前租户自己的数据
+func tenantWrite(db *gorm.DB) {
+	_, tenantID, ok := tenantField(db)
+	if !ok {
+		return
+	}
+
+	addTenantCondition(db.Statement, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: tenantID})
+}
+
+// addTenantCondition 追加租户条件, 已有条件中包含 OR 时先整体加括号 (同 gorm 软删除的处理方式)
+func addTenantCondition(stmt *gorm.Statement, expr clause.Expression) {
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) >= 1 {
+			for _, e := range where.Exprs {
+				if orCond, ok := e.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					stmt.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{expr}})
+}
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+func newTestTenantDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, migrator := newTestMigrator(t)
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return db
+}
+
+func TestTenantScope(t *testing.T) {
+	db := newTestTenantDB(t)
+	ctx7 := tenant.NewContext(context.Background(), 7)
+
+	// 新建数据归属 context 中的租户, 忽略调用方传入的 tenant_id
+	if err := db.WithContext(ctx7).Create(&biz.Role{UID: 1, Name: "a", TenantID: 9}).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := db.WithContext(ctx7).Create([]*biz.Role{{UID: 2, Name: "b"}, {UID: 3, Name: "c"}}).Error; err != nil {
+		t.Fatalf("create in batches: %v", err)
+	}
+	if err := db.Create(&biz.Role{UID: 4, Name: "d", TenantID: 9}).Error; err != nil {
+		t.Fatalf("create without tenant: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []int64
+	}{
+		{"tenant 7", ctx7, []int64{1, 2, 3}},
+		{"tenant 9", tenant.NewContext(context.Background(), 9), []int64{4}},
+		{"unscoped", context.Background(), []int64{1, 2, 3, 4}},
+		{"without tenant", tenant.WithoutTenant(ctx7), []int64{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var roles []*biz.Role
+			if err := db.WithContext(tt.ctx).Order("uid").Find(&roles).Error; err != nil {
+				t.Fatalf("find: %v", err)
+			}
+			if got := lo.Map(roles, func(r *biz.Role, _ int) int64 { return r.UID }); !slices.Equal(got, tt.want) {
+				t.Fatalf("roles = %v, want %v", got, tt.want)
+			}
+
+			var count int64
+			if err := db.WithContext(tt.ctx).Model(&biz.Role{}).Count(&count).Error; err != nil {
+				t.Fatalf("count: %v", err)
+			}
+			if count != int64(len(tt.want)) {
+				t.Fatalf("count = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+
+	// 已有条件包含 OR 时整体加括号, 不会查到其他租户的数据
+	t.Run("or conditions", func(t *testing.T) {
+		var roles []*biz.Role
+		if err := db.WithContext(ctx7).Where("name = ?", "a").Or("name = ?", "d").Find(&roles).Error; err != nil {
+			t.Fatalf("find: %v", err)
+		}
+		if len(roles) != 1 || roles[0].UID != 1 {
+			t.Fatalf("roles = %v, want only uid 1", lo.Map(roles, func(r *biz.Role, _ int) int64 { return r.UID }))
+		}
+	})
+
+	// 更新和删除其他租户的数据不生效
+	t.Run("write", func(t *testing.T) {
+		res := db.WithContext(ctx7).Model(&biz.Role{}).Where("uid = ?", 4).Update("alias", "x")
+		if res.Error != nil || res.RowsAffected != 0 {
+			t.Fatalf("update other tenant: affected %d err %v", res.RowsAffected, res.Error)
+		}
+		res = db.WithContext(ctx7).Where("uid = ?", 4).Delete(&biz.Role{})
+		if res.Error != nil || res.RowsAffected != 0 {
+			t.Fatalf("delete other tenant: affected %d err %v", res.RowsAffected, res.Error)
+		}
+		res = db.WithContext(ctx7).Where("uid = ?", 1).Delete(&biz.Role{})
+		if res.Error != nil || res.RowsAffected != 1 {
+			t.Fatalf("delete own: affected %d err %v", res.RowsAffected, res.Error)
+		}
+	})
+}
+
+// TestTenantScopeSharedMenus 平台菜单对所有租户可见, 但租户不能修改
+func TestTenantScopeSharedMenus(t *testing.T) {
+	db := newTestTenantDB(t)
+	for _, m := range []*biz.Menu{{UID: 1, Name: "platform"}, {UID: 2, TenantID: 7, Name: "t7"}, {UID: 3, TenantID: 9, Name: "t9"}} {
+		if err := db.Create(m).Error; err != nil {
+			t.Fatalf("create menu: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tenantID int64
+		want     []int64
+	}{
+		{"platform", tenant.PlatformID, []int64{1}},
+		{"tenant 7", 7, []int64{1, 2}},
+		{"tenant 9", 9, []int64{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tenant.NewContext(context.Background(), tt.tenantID)
+			var menus []*biz.Menu
+			if err := db.WithContext(ctx).Order("uid").Find(&menus).Error; err != nil {
+				t.Fatalf("find: %v", err)
+			}
+			if got := lo.Map(menus, func(m *biz.Menu, _ int) int64 { return m.UID }); !slices.Equal(got, tt.want) {
+				t.Fatalf("menus = %v, want %v", got, tt.want)
+			}
+
+			res := db.WithContext(ctx).Model(&biz.Menu{}).Where("uid = ?", 1).Update("name", "changed")
+			if res.Error != nil {
+				t.Fatalf("update: %v", res.Error)
+			}
+			if want := lo.Ternary(tt.tenantID == tenant.PlatformID, int64(1), 0); res.RowsAffected != want {
+				t.Fatalf("update platform menu affected %d, want %d", res.RowsAffected, want)
+			}
+		})
+	}
+}
+
+// TestTenantCreateWithoutTenant context 中没有租户时必须显式指定 tenant_id, 共享的菜单除外
+func TestTenantCreateWithoutTenant(t *testing.T) {
+	db := newTestTenantDB(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		value   any
+		wantErr error
+	}{
+		{"role without tenant", &biz.Role{UID: 1, Name: "a"}, tenant.ErrInvalidTenant},
+		{"batch with one missing", []*biz.Role{{UID: 2, Name: "b", TenantID: 7}, {UID: 3, Name: "c"}}, tenant.ErrInvalidTenant},
+		{"user without tenant", &biz.User{UID: 1, Username: "a"}, tenant.ErrInvalidTenant},
+		{"explicit tenant", &biz.Role{UID: 4, Name: "d", TenantID: 7}, nil},
+		{"platform menu", &biz.Menu{UID: 1, Name: "m"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.WithContext(ctx).Create(tt.value).Error; !errors.Is(err, tt.wantErr) {
+				t.Fatalf("create error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var count int64
+	if err := db.Model(&biz.Role{}).Where("tenant_id = ?", 0).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("%d roles created in tenant 0", count)
+	}
+}
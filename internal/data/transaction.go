@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+
+	"github.com/omalloc/contrib/kratos/orm"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+type txContextKey struct{}
+
+type transactionManager struct {
+	dsm orm.DataSourceManager
+}
+
+// NewTransactionManager 与 orm.NewTransactionManager 相同, 但事务内的语句保留调用方的 context,
+// 使租户限定 (见 tenant_scope.go) 及链路追踪在事务内同样生效; 事务外 ctx 标记了 biz.WithPrimary 时读取主库.
+func NewTransactionManager(dsm orm.DataSourceManager) orm.Transaction {
+	return &transactionManager{
+		dsm: dsm,
+	}
+}
+
+func (tm *transactionManager) WithContext(ctx context.Context) *gorm.DB {
+	if ctx == nil {
+		return tm.dsm.GetDataSource()
+	}
+
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	db := tm.dsm.GetDataSource().WithContext(ctx)
+	if biz.ReadPrimary(ctx) {
+		// 新建会话使后续链式调用各自复制语句, 与 WithContext 的返回值一样可以复用
+		db = db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+	}
+	return db
+}
+
+func (tm *transactionManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return tm.dsm.GetDataSource().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/conf"
+	"github.com/omalloc/kratos-admin/internal/event"
+)
+
+// TestReadPrimary 从库尚未同步时, 回填缓存读取主库
+func TestReadPrimary(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	dir := t.TempDir()
+	open := func(name string) *Data {
+		db, err := OpenDatabase(&conf.Data{Database: &conf.Data_Database{Driver: "sqlite", Source: filepath.Join(dir, name)}}, logger)
+		if err != nil {
+			t.Fatalf("open %s: %v", name, err)
+		}
+		migrator, err := NewMigrator(db, logger)
+		if err != nil {
+			t.Fatalf("new migrator: %v", err)
+		}
+		if _, err = migrator.Up(0); err != nil {
+			t.Fatalf("migrate %s: %v", name, err)
+		}
+		return &Data{db: db}
+	}
+	// 副本与主库结构相同, 但没有新写入的数据
+	_ = open("replica.db")
+	primary := open("primary.db")
+	replicas, err := useReplicas(primary.db, "sqlite", []string{filepath.Join(dir, "replica.db")}, logger)
+	if err != nil {
+		t.Fatalf("use replicas: %v", err)
+	}
+	t.Cleanup(replicas.Close)
+
+	ctx := context.Background()
+	txm := NewTransactionManager(primary)
+	menuRepo := NewMenuRepo(txm, logger)
+	if err := menuRepo.Create(ctx, &biz.Menu{UID: 1, Name: "dashboard"}); err != nil {
+		t.Fatalf("create menu: %v", err)
+	}
+
+	menus, err := menuRepo.SelectAll(ctx)
+	if err != nil {
+		t.Fatalf("select from replica: %v", err)
+	}
+	if len(menus) != 0 {
+		t.Fatalf("read %d menus from the stale replica, want 0", len(menus))
+	}
+
+	authz := biz.NewAuthzCache(NewLRUCache(lruCacheSize), event.NewApplicationEventPublisher(), NewUserRepo(txm), NewRoleRepo(txm), menuRepo, logger)
+	menus, err = authz.Menus(ctx)
+	if err != nil {
+		t.Fatalf("authz menus: %v", err)
+	}
+	if len(menus) != 1 {
+		t.Fatalf("cache refilled %d menus, want 1 from the primary", len(menus))
+	}
+}
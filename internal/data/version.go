@@ -0,0 +1,30 @@
+package data
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+)
+
+// checkVersioned 检查按 uid 和版本号执行的更新结果; 没有更新到行时区分记录不存在 (返回 notFound) 与版本号已变化.
+// 回查走主库, 避免从库延迟把冲突误判为不存在
+func checkVersioned(db *gorm.DB, result *gorm.DB, model any, uid int64, notFound error) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := db.Model(model).Clauses(dbresolver.Write).
+		Where("uid = ?", uid).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return notFound
+	}
+	return biz.ErrVersionConflict
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+)
+
+func TestCheckVersioned(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), 1)
+	db := newTestTxm(t).WithContext(ctx)
+	if err := db.Create(&biz.Role{UID: 1, Name: "admin"}).Error; err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+
+	update := func(uid, version int64) *gorm.DB {
+		return db.Model(&biz.Role{}).
+			Where("uid = ? AND version = ?", uid, version).
+			Updates(map[string]any{"alias": "x", "version": gorm.Expr("version + 1")})
+	}
+	failed := db.Exec("UPDATE no_such_table SET version = 1")
+
+	tests := []struct {
+		name    string
+		result  *gorm.DB
+		uid     int64
+		wantErr error
+	}{
+		{"updated", update(1, 1), 1, nil},
+		{"version changed", update(1, 1), 1, biz.ErrVersionConflict},
+		{"not found", update(2, 1), 2, biz.ErrRoleNotFound},
+		{"update error", failed, 1, failed.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVersioned(db, tt.result, &biz.Role{}, tt.uid, biz.ErrRoleNotFound)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkVersioned() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,20 +3,49 @@ package server
 import (
 	"context"
 
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/robfig/cron/v3"
+
+	"github.com/omalloc/kratos-admin/internal/service"
 )
 
 var _ transport.Server = (*BackgroundTaskManager)(nil)
 
+const (
+	// sweepExpiredRolesSpec 每分钟清理一次过期的角色授权
+	sweepExpiredRolesSpec = "0 * * * * *"
+	// purgeDeletedUsersSpec 每小时清除一次超过保留期的已删除用户
+	purgeDeletedUsersSpec = "0 30 * * * *"
+)
+
 type BackgroundTaskManager struct {
 	cron *cron.Cron
+	log  *log.Helper
 }
 
-func NewBackgroundTaskManager() *BackgroundTaskManager {
-	return &BackgroundTaskManager{
+func NewBackgroundTaskManager(logger log.Logger, user *service.UserService) (*BackgroundTaskManager, error) {
+	r := &BackgroundTaskManager{
 		cron: cron.New(cron.WithSeconds()),
+		log:  log.NewHelper(logger),
 	}
+
+	if _, err := r.cron.AddFunc(sweepExpiredRolesSpec, func() {
+		if err := user.SweepExpiredRoles(context.Background()); err != nil {
+			r.log.Errorf("sweep expired user roles failed: %v", err)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := r.cron.AddFunc(purgeDeletedUsersSpec, func() {
+		if err := user.PurgeDeletedUsers(context.Background()); err != nil {
+			r.log.Errorf("purge deleted users failed: %v", err)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return r, nil
 }
 
 // Start implements transport.Server.
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+)
+
+var _ transport.Server = (*CacheEvictor)(nil)
+
+// cacheEvents 需要清除缓存的变更事件
+var cacheEvents = []string{
+	biz.EventUserRolesChanged,
+	biz.EventRolesChanged,
+	biz.EventMenusChanged,
+}
+
+// CacheEvictor 订阅角色, 授权及菜单的变更事件, 删除对应的缓存
+type CacheEvictor struct {
+	publisher *event.ApplicationEventPublisher
+	authz     *biz.AuthzCache
+	log       *log.Helper
+}
+
+func NewCacheEvictor(publisher *event.ApplicationEventPublisher, authz *biz.AuthzCache, logger log.Logger) *CacheEvictor {
+	return &CacheEvictor{
+		publisher: publisher,
+		authz:     authz,
+		log:       log.NewHelper(logger),
+	}
+}
+
+// Start implements transport.Server.
+func (e *CacheEvictor) Start(ctx context.Context) error {
+	for _, topic := range cacheEvents {
+		messages, err := e.publisher.Subscribe(ctx, topic)
+		if err != nil {
+			return err
+		}
+		go e.consume(topic, messages)
+	}
+	return nil
+}
+
+// Stop implements transport.Server.
+// 订阅随 Start 的 context 或事件发布器关闭而结束
+func (e *CacheEvictor) Stop(context.Context) error {
+	return nil
+}
+
+func (e *CacheEvictor) consume(topic string, messages <-chan *message.Message) {
+	for msg := range messages {
+		var ids []int64
+		if err := event.Unmarshal(msg.Payload, &ids); err != nil {
+			e.log.Errorf("invalid %s event: %v", topic, err)
+		} else if err = e.authz.Evict(context.Background(), topic, ids); err != nil {
+			e.log.Errorf("evict cache on %s failed: %v", topic, err)
+		}
+		msg.Ack()
+	}
+}
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"context"
+
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/logging"
 	"github.com/go-kratos/kratos/v2/middleware/metadata"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
@@ -9,6 +12,7 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	jwtv5 "github.com/golang-jwt/jwt/v5"
+	ggrpc "google.golang.org/grpc"
 
 	consolepb "github.com/omalloc/kratos-admin/api/console"
 	adminpb "github.com/omalloc/kratos-admin/api/console/administration"
@@ -16,6 +20,7 @@ import (
 	"github.com/omalloc/kratos-admin/internal/conf"
 	"github.com/omalloc/kratos-admin/internal/service"
 	"github.com/omalloc/kratos-admin/pkg/jwt"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
 )
 
 // NewGRPCServer new a gRPC server.
@@ -27,23 +32,30 @@ func NewGRPCServer(c *conf.Server, passportc *conf.Passport, logger log.Logger,
 	permission *service.PermissionService,
 	passport *service.PassportService,
 	menu *service.MenuService,
+	accessRequest *service.AccessRequestService,
+	department *service.DepartmentService,
+	tenantsrv *service.TenantService,
 	crontab *service.CrontabService,
+	file *service.FileService,
 ) *grpc.Server {
+	middlewares := []middleware.Middleware{
+		recovery.Recovery(),
+		metadata.Server(),
+		tracing.Server(),
+		logging.Server(logger),
+		// JWT
+		selector.Server(
+			jwt.Server(func(token *jwtv5.Token) (any, error) {
+				return []byte(passportc.Secret), nil
+			}),
+		).
+			Match(NewWhiteListMatcher()).
+			Build(),
+		tenant.Server(),
+	}
 	opts := []grpc.ServerOption{
-		grpc.Middleware(
-			recovery.Recovery(),
-			metadata.Server(),
-			tracing.Server(),
-			logging.Server(logger),
-			// JWT
-			selector.Server(
-				jwt.Server(func(token *jwtv5.Token) (any, error) {
-					return []byte(passportc.Secret), nil
-				}),
-			).
-				Match(NewWhiteListMatcher()).
-				Build(),
-		),
+		grpc.Middleware(middlewares...),
+		grpc.StreamInterceptor(streamServerMiddleware(middlewares...)),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -61,7 +73,33 @@ func NewGRPCServer(c *conf.Server, passportc *conf.Passport, logger log.Logger,
 	adminpb.RegisterRoleServer(srv, role)
 	adminpb.RegisterPermissionServer(srv, permission)
 	adminpb.RegisterMenuServer(srv, menu)
+	adminpb.RegisterAccessRequestServer(srv, accessRequest)
+	adminpb.RegisterDepartmentServer(srv, department)
+	adminpb.RegisterTenantServer(srv, tenantsrv)
 	adminpb.RegisterCrontabServer(srv, crontab)
+	adminpb.RegisterFileServer(srv, file)
 	passportpb.RegisterPassportServer(srv, passport)
 	return srv
 }
+
+// streamServerMiddleware 为流式接口执行与普通接口相同的中间件 (鉴权, 租户等),
+// kratos 的中间件只作用于 unary 请求.
+func streamServerMiddleware(m ...middleware.Middleware) ggrpc.StreamServerInterceptor {
+	return func(srv any, ss ggrpc.ServerStream, _ *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) error {
+		h := func(ctx context.Context, _ any) (any, error) {
+			return nil, handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		}
+		_, err := middleware.Chain(m...)(h)(ss.Context(), nil)
+		return err
+	}
+}
+
+// serverStream 替换 stream 的 context, 使 handler 能读取中间件写入的信息
+type serverStream struct {
+	ggrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
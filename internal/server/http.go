@@ -18,6 +18,7 @@ import (
 	"github.com/omalloc/kratos-admin/internal/conf"
 	"github.com/omalloc/kratos-admin/internal/service"
 	"github.com/omalloc/kratos-admin/pkg/jwt"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
 )
 
 func NewWhiteListMatcher() selector.MatchFunc {
@@ -28,6 +29,10 @@ func NewWhiteListMatcher() selector.MatchFunc {
 	whiteList[passportpb.OperationPassportResetPassword] = struct{}{}
 	whiteList[passportpb.OperationPassportSendCaptcha] = struct{}{}
 	whiteList[passportpb.OperationPassportSendResetPassword] = struct{}{}
+	whiteList[passportpb.OperationPassportAcceptInvitation] = struct{}{}
+	whiteList[passportpb.OperationPassportVerifyEmail] = struct{}{}
+	whiteList[passportpb.OperationPassportResendVerification] = struct{}{}
+	whiteList[service.OperationFileDownloadAvatar] = struct{}{}
 	return func(ctx context.Context, operation string) bool {
 		if _, ok := whiteList[operation]; ok {
 			return false
@@ -44,7 +49,11 @@ func NewHTTPServer(c *conf.Server, passportc *conf.Passport, logger log.Logger,
 	permission *service.PermissionService,
 	passport *service.PassportService,
 	menu *service.MenuService,
+	accessRequest *service.AccessRequestService,
+	department *service.DepartmentService,
+	tenantsrv *service.TenantService,
 	crontab *service.CrontabService,
+	file *service.FileService,
 ) *http.Server {
 	opts := []http.ServerOption{
 		http.Middleware(
@@ -56,6 +65,7 @@ func NewHTTPServer(c *conf.Server, passportc *conf.Passport, logger log.Logger,
 			selector.Server(jwt.Server(func(token *jwtv5.Token) (any, error) {
 				return []byte(passportc.Secret), nil
 			})).Match(NewWhiteListMatcher()).Build(),
+			tenant.Server(),
 		),
 	}
 	if c.Http.Network != "" {
@@ -70,11 +80,20 @@ func NewHTTPServer(c *conf.Server, passportc *conf.Passport, logger log.Logger,
 	srv := http.NewServer(opts...)
 	srv.HandlePrefix("/q/", openapiv2.NewHandler())
 
+	// 需要先于 /api/console/user/{uid} 注册
+	srv.Route("/").GET("/api/console/user/export", user.ExportUsersHTTP)
+	srv.Route("/").POST("/api/console/file", file.UploadFileHTTP)
+	srv.Route("/").GET("/api/console/file/{uid}/content", file.DownloadFileHTTP)
+	srv.Route("/").GET("/api/console/avatar/{uid}", file.DownloadAvatarHTTP)
 	adminpb.RegisterUserHTTPServer(srv, user)
 	adminpb.RegisterRoleHTTPServer(srv, role)
 	adminpb.RegisterPermissionHTTPServer(srv, permission)
 	adminpb.RegisterMenuHTTPServer(srv, menu)
+	adminpb.RegisterAccessRequestHTTPServer(srv, accessRequest)
+	adminpb.RegisterDepartmentHTTPServer(srv, department)
+	adminpb.RegisterTenantHTTPServer(srv, tenantsrv)
 	adminpb.RegisterCrontabHTTPServer(srv, crontab)
+	adminpb.RegisterFileHTTPServer(srv, file)
 	passportpb.RegisterPassportHTTPServer(srv, passport)
 	return srv
 }
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 	"github.com/omalloc/contrib/kratos/health"
 	"github.com/omalloc/contrib/kratos/registry"
 	"github.com/omalloc/contrib/protobuf"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
+	"github.com/omalloc/kratos-admin/internal/biz"
 	"github.com/omalloc/kratos-admin/internal/conf"
 	"github.com/omalloc/kratos-admin/internal/data"
+	"github.com/omalloc/kratos-admin/pkg/mailer"
 )
 
 // ProviderSet is server providers.
@@ -30,6 +33,10 @@ var ProviderSet = wire.NewSet(
 	health.NewServer,
 
 	NewBackgroundTaskManager,
+	NewCacheEvictor,
+	NewMailer,
+	NewPasswordPolicy,
+	NewRegistrationPolicy,
 )
 
 func NewRegistryConfig(bc *conf.Bootstrap) *protobuf.Registry {
@@ -40,9 +47,59 @@ func NewTracingConfig(bc *conf.Bootstrap) *protobuf.Tracing {
 	return bc.Tracing
 }
 
+// NewMailer 未配置 SMTP 时邮件仅打印到日志
+func NewMailer(bc *conf.Bootstrap, logger log.Logger) mailer.Mailer {
+	c := bc.Mail
+	if c == nil || c.Host == "" {
+		return mailer.NewLog(logger)
+	}
+	return mailer.NewSMTP(c.Host, int(c.Port), c.Username, c.Password, c.From)
+}
+
+// NewPasswordPolicy 未配置时使用默认策略
+func NewPasswordPolicy(c *conf.Passport) *biz.PasswordPolicy {
+	p := c.GetPasswordPolicy()
+	if p == nil {
+		return &biz.PasswordPolicy{MinLength: biz.DefaultPasswordMinLength}
+	}
+	return &biz.PasswordPolicy{
+		MinLength:     int(p.MinLength),
+		RequireUpper:  p.RequireUpper,
+		RequireLower:  p.RequireLower,
+		RequireDigit:  p.RequireDigit,
+		RequireSymbol: p.RequireSymbol,
+		DenyList:      p.DenyList,
+		AllowUsername: p.AllowUsername,
+		MaxAge:        p.MaxAge.AsDuration(),
+		History:       int(p.History),
+	}
+}
+
+// NewRegistrationPolicy 未配置时不允许注册
+func NewRegistrationPolicy(c *conf.Passport) *biz.RegistrationPolicy {
+	r := c.GetRegistration()
+	if r == nil {
+		return &biz.RegistrationPolicy{Mode: biz.RegistrationModeDisabled}
+	}
+	policy := &biz.RegistrationPolicy{
+		Mode:         r.Mode,
+		DefaultRoles: r.DefaultRoles,
+		VerifyTTL:    biz.DefaultVerificationTTL,
+	}
+	if r.VerifyTtl != nil && r.VerifyTtl.AsDuration() > 0 {
+		policy.VerifyTTL = r.VerifyTtl.AsDuration()
+	}
+	return policy
+}
+
 func NewChecker(c1 *data.Data, cli *clientv3.Client) []health.Checker {
 	etcdChecker := &Etcd{cli}
-	return []health.Checker{c1, etcdChecker}
+	checkers := []health.Checker{c1, etcdChecker}
+	// 只读副本的健康检查同时负责故障切换, 未配置副本时不注册
+	if replicas := c1.Replicas(); replicas != nil {
+		checkers = append(checkers, replicas)
+	}
+	return checkers
 }
 
 type Etcd struct {
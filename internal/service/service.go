@@ -11,6 +11,10 @@ var ProviderSet = wire.NewSet(
 	NewPermissionService,
 	NewPassportService,
 	NewMenuService,
+	NewAccessRequestService,
+	NewDepartmentService,
+	NewTenantService,
+	NewFileService,
 	// others
 	NewCrontabService,
 )
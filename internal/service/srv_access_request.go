@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/omalloc/kratos-admin/api/console/administration"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+	"github.com/omalloc/kratos-admin/pkg/jwt"
+)
+
+type AccessRequestService struct {
+	pb.UnimplementedAccessRequestServer
+
+	log                       *log.Helper
+	usecase                   *biz.AccessRequestUsecase
+	applicationEventPublisher *event.ApplicationEventPublisher
+}
+
+func NewAccessRequestService(usecase *biz.AccessRequestUsecase, applicationEventPublisher *event.ApplicationEventPublisher, logger log.Logger) *AccessRequestService {
+	return &AccessRequestService{
+		log:                       log.NewHelper(logger),
+		usecase:                   usecase,
+		applicationEventPublisher: applicationEventPublisher,
+	}
+}
+
+func (s *AccessRequestService) CreateAccessRequest(ctx context.Context, req *pb.CreateAccessRequestRequest) (*pb.CreateAccessRequestReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	request := &biz.AccessRequest{
+		UID:      idgen.NextId(),
+		UserID:   claims.UID,
+		RoleID:   req.RoleId,
+		Duration: req.Duration,
+		Reason:   req.Reason,
+	}
+	if err := s.usecase.CreateAccessRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "access_request.created", request)
+	return &pb.CreateAccessRequestReply{
+		Uid: request.UID,
+	}, nil
+}
+
+func (s *AccessRequestService) ListPendingAccessRequest(ctx context.Context, req *pb.ListPendingAccessRequestRequest) (*pb.ListPendingAccessRequestReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+	pagination := protobuf.PageWrap(req.Pagination)
+
+	requests, err := s.usecase.ListPendingAccessRequest(ctx, claims.UID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListPendingAccessRequestReply{
+		Pagination: pagination.Resp(),
+		Data:       lo.Map(requests, toAccessRequestProto),
+	}, nil
+}
+
+func (s *AccessRequestService) ApproveAccessRequest(ctx context.Context, req *pb.ApproveAccessRequestRequest) (*pb.ApproveAccessRequestReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	request, err := s.usecase.ApproveAccessRequest(ctx, req.Uid, claims.UID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "access_request.approved", request)
+	return &pb.ApproveAccessRequestReply{
+		ExpiresAt: timestamppb.New(lo.FromPtr(request.ExpiresAt)),
+	}, nil
+}
+
+func (s *AccessRequestService) DenyAccessRequest(ctx context.Context, req *pb.DenyAccessRequestRequest) (*pb.DenyAccessRequestReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	request, err := s.usecase.DenyAccessRequest(ctx, req.Uid, claims.UID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "access_request.denied", request)
+	return &pb.DenyAccessRequestReply{}, nil
+}
+
+func (s *AccessRequestService) CancelAccessRequest(ctx context.Context, req *pb.CancelAccessRequestRequest) (*pb.CancelAccessRequestReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	request, err := s.usecase.CancelAccessRequest(ctx, req.Uid, claims.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "access_request.cancelled", request)
+	return &pb.CancelAccessRequestReply{}, nil
+}
+
+// publish 通知申请人及审批人
+func (s *AccessRequestService) publish(ctx context.Context, topic string, request *biz.AccessRequest) {
+	s.log.Infof("%s: request %d user %d role %d", topic, request.UID, request.UserID, request.RoleID)
+	s.applicationEventPublisher.Publish(ctx, topic, event.NewMessage(event.NewUUID(), event.Marshal(request)))
+}
+
+func toAccessRequestProto(request *biz.AccessRequest, _ int) *pb.AccessRequestInfo {
+	return &pb.AccessRequestInfo{
+		Uid:           request.UID,
+		UserId:        request.UserID,
+		RoleId:        request.RoleID,
+		Duration:      request.Duration,
+		Reason:        request.Reason,
+		Status:        pb.AccessRequestStatus(request.Status),
+		ReviewerId:    request.ReviewerID,
+		ReviewComment: request.ReviewComment,
+		ReviewedAt:    lo.Ternary(request.ReviewedAt == nil, nil, timestamppb.New(lo.FromPtr(request.ReviewedAt))),
+		ExpiresAt:     lo.Ternary(request.ExpiresAt == nil, nil, timestamppb.New(lo.FromPtr(request.ExpiresAt))),
+		CreatedAt:     timestamppb.New(request.CreatedAt),
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/omalloc/kratos-admin/api/console/administration"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+)
+
+type DepartmentService struct {
+	pb.UnimplementedDepartmentServer
+
+	usecase *biz.DepartmentUsecase
+}
+
+func NewDepartmentService(usecase *biz.DepartmentUsecase) *DepartmentService {
+	return &DepartmentService{
+		usecase: usecase,
+	}
+}
+
+func (s *DepartmentService) CreateDepartment(ctx context.Context, req *pb.CreateDepartmentRequest) (*pb.CreateDepartmentReply, error) {
+	department := &biz.Department{
+		UID:      idgen.NextId(),
+		PID:      req.Pid,
+		Name:     req.Name,
+		Describe: req.Describe,
+		SortBy:   req.SortBy,
+	}
+	if err := s.usecase.CreateDepartment(ctx, department); err != nil {
+		return nil, err
+	}
+	return &pb.CreateDepartmentReply{
+		Uid: department.UID,
+	}, nil
+}
+
+func (s *DepartmentService) UpdateDepartment(ctx context.Context, req *pb.UpdateDepartmentRequest) (*pb.UpdateDepartmentReply, error) {
+	if err := s.usecase.UpdateDepartment(ctx, &biz.Department{
+		UID:      req.Uid,
+		Name:     req.Name,
+		Describe: req.Describe,
+		SortBy:   req.SortBy,
+	}); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateDepartmentReply{}, nil
+}
+
+func (s *DepartmentService) DeleteDepartment(ctx context.Context, req *pb.DeleteDepartmentRequest) (*pb.DeleteDepartmentReply, error) {
+	if err := s.usecase.DeleteDepartment(ctx, req.Uid); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteDepartmentReply{}, nil
+}
+
+func (s *DepartmentService) GetDepartment(ctx context.Context, req *pb.GetDepartmentRequest) (*pb.GetDepartmentReply, error) {
+	department, err := s.usecase.GetDepartment(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetDepartmentReply{
+		Data: toDepartmentProto(department, 0),
+	}, nil
+}
+
+func (s *DepartmentService) ListDepartment(ctx context.Context, req *pb.ListDepartmentRequest) (*pb.ListDepartmentReply, error) {
+	departments, err := s.usecase.ListDepartment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListDepartmentReply{
+		Data: lo.Map(departments, toDepartmentProto),
+	}, nil
+}
+
+func (s *DepartmentService) MoveDepartment(ctx context.Context, req *pb.MoveDepartmentRequest) (*pb.MoveDepartmentReply, error) {
+	if err := s.usecase.MoveDepartment(ctx, req.Uid, req.Pid); err != nil {
+		return nil, err
+	}
+	return &pb.MoveDepartmentReply{}, nil
+}
+
+func (s *DepartmentService) SetUserDepartment(ctx context.Context, req *pb.SetUserDepartmentRequest) (*pb.SetUserDepartmentReply, error) {
+	if err := s.usecase.SetUserDepartment(ctx, req.UserId, req.Primary, req.Secondary); err != nil {
+		return nil, err
+	}
+	return &pb.SetUserDepartmentReply{}, nil
+}
+
+func (s *DepartmentService) GetUserDepartment(ctx context.Context, req *pb.GetUserDepartmentRequest) (*pb.GetUserDepartmentReply, error) {
+	primary, secondary, err := s.usecase.GetUserDepartment(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.GetUserDepartmentReply{
+		Secondary: lo.Map(secondary, toDepartmentProto),
+	}
+	if primary != nil {
+		reply.Primary = toDepartmentProto(primary, 0)
+	}
+	return reply, nil
+}
+
+func toDepartmentProto(department *biz.Department, _ int) *pb.DepartmentInfo {
+	return &pb.DepartmentInfo{
+		Uid:       department.UID,
+		Pid:       department.PID,
+		Name:      department.Name,
+		Describe:  department.Describe,
+		SortBy:    department.SortBy,
+		Path:      department.Path,
+		CreatedAt: timestamppb.New(department.CreatedAt),
+		UpdatedAt: timestamppb.New(department.UpdatedAt),
+	}
+}
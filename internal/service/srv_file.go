@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/omalloc/kratos-admin/api/console/administration"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+	"github.com/omalloc/kratos-admin/pkg/jwt"
+)
+
+// maxFileNameLength 文件名的最大长度
+const maxFileNameLength = 255
+
+// OperationFileDownloadAvatar 公开下载头像的 HTTP 入口, 在白名单中不需要登录
+const OperationFileDownloadAvatar = "/api.console.administration.File/DownloadAvatar"
+
+var ErrFileMissing = errors.New(400, "FILE_MISSING", "缺少上传的文件")
+
+type FileService struct {
+	pb.UnimplementedFileServer
+
+	log     *log.Helper
+	usecase *biz.FileUsecase
+}
+
+func NewFileService(usecase *biz.FileUsecase, logger log.Logger) *FileService {
+	return &FileService{
+		log:     log.NewHelper(logger),
+		usecase: usecase,
+	}
+}
+
+func (s *FileService) UploadFile(ctx context.Context, req *pb.UploadFileRequest) (*pb.UploadFileReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	file := &biz.File{
+		UID:     idgen.NextId(),
+		OwnerID: claims.UID,
+		Name:    cleanFileName(req.Name),
+		Purpose: int(req.Purpose),
+	}
+	if err := s.usecase.Upload(ctx, file, req.Content); err != nil {
+		return nil, err
+	}
+	return &pb.UploadFileReply{
+		Data: toFileProto(file),
+	}, nil
+}
+
+// UploadFileHTTP 上传文件的 HTTP 入口, 接收 multipart/form-data
+func (s *FileService) UploadFileHTTP(ctx khttp.Context) error {
+	req := ctx.Request()
+	// 预留 1MB 给 multipart 的其它字段
+	req.Body = http.MaxBytesReader(ctx.Response(), req.Body, biz.MaxFileSize+1<<20)
+
+	f, header, err := req.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return biz.ErrFileTooLarge.WithMetadata(map[string]string{
+				"max_size": strconv.Itoa(biz.MaxFileSize),
+			})
+		}
+		return ErrFileMissing
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, biz.MaxFileSize+1))
+	if err != nil {
+		return err
+	}
+	in := &pb.UploadFileRequest{
+		Name:    header.Filename,
+		Content: content,
+		Purpose: parseFilePurpose(req.FormValue("purpose")),
+	}
+
+	khttp.SetOperation(ctx, pb.File_UploadFile_FullMethodName)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		return s.UploadFile(c, req.(*pb.UploadFileRequest))
+	})
+	out, err := h(ctx, in)
+	if err != nil {
+		return err
+	}
+	return ctx.Result(http.StatusOK, out)
+}
+
+func (s *FileService) GetFile(ctx context.Context, req *pb.GetFileRequest) (*pb.GetFileReply, error) {
+	file, err := s.usecase.GetFile(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetFileReply{
+		Data: toFileProto(file),
+	}, nil
+}
+
+func (s *FileService) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest) (*pb.DeleteFileReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	if err := s.usecase.DeleteFile(ctx, req.Uid, claims.UID); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteFileReply{}, nil
+}
+
+// DownloadFile 通过 gRPC 流下载文件
+func (s *FileService) DownloadFile(req *pb.DownloadFileRequest, stream pb.File_DownloadFileServer) error {
+	_, rc, err := s.usecase.Open(stream.Context(), req.Uid)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.CopyBuffer(&fileStreamWriter{stream: stream}, rc, make([]byte, 32<<10))
+	return err
+}
+
+// DownloadFileHTTP 下载文件的 HTTP 入口
+func (s *FileService) DownloadFileHTTP(ctx khttp.Context) error {
+	var in pb.DownloadFileRequest
+	if err := ctx.BindVars(&in); err != nil {
+		return err
+	}
+
+	khttp.SetOperation(ctx, pb.File_DownloadFile_FullMethodName)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		file, rc, err := s.usecase.Open(c, req.(*pb.DownloadFileRequest).Uid)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return nil, writeFile(ctx, file, rc, "private, max-age=86400")
+	})
+	_, err := h(ctx, &in)
+	return err
+}
+
+// DownloadAvatarHTTP 下载头像的 HTTP 入口, 不需要登录, 供 <img> 直接引用
+func (s *FileService) DownloadAvatarHTTP(ctx khttp.Context) error {
+	var in pb.DownloadFileRequest
+	if err := ctx.BindVars(&in); err != nil {
+		return err
+	}
+
+	khttp.SetOperation(ctx, OperationFileDownloadAvatar)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		file, rc, err := s.usecase.OpenAvatar(c, req.(*pb.DownloadFileRequest).Uid)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		// 更换头像后地址随之变化, 可以由共享缓存缓存
+		return nil, writeFile(ctx, file, rc, "public, max-age=86400")
+	})
+	_, err := h(ctx, &in)
+	return err
+}
+
+// writeFile 写出文件内容, 以内容 hash 作为 ETag
+func writeFile(ctx khttp.Context, file *biz.File, rc io.Reader, cacheControl string) error {
+	w := ctx.Response()
+	etag := strconv.Quote(file.Hash)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+	if ctx.Request().Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	// 仅图片允许内联展示, 其它类型一律作为附件下载
+	disposition := lo.Ternary(strings.HasPrefix(file.Mime, "image/"), "inline", "attachment")
+	w.Header().Set("Content-Type", file.Mime)
+	w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": file.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, err := io.Copy(w, rc)
+	return err
+}
+
+// fileStreamWriter 将文件内容按块写入 gRPC 流
+type fileStreamWriter struct {
+	stream pb.File_DownloadFileServer
+}
+
+func (w *fileStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.DownloadFileReply{Chunk: bytes.Clone(p)}); err != nil {
+		return 0, fmt.Errorf("send file chunk: %w", err)
+	}
+	return len(p), nil
+}
+
+// parseFilePurpose 支持 avatar 或 FILE_PURPOSE_AVATAR 两种写法, 无法识别时为通用文件
+func parseFilePurpose(v string) pb.FilePurpose {
+	name := strings.ToUpper(strings.TrimSpace(v))
+	if !strings.HasPrefix(name, "FILE_PURPOSE_") {
+		name = "FILE_PURPOSE_" + name
+	}
+	return pb.FilePurpose(pb.FilePurpose_value[name])
+}
+
+// cleanFileName 去掉客户端上传时可能携带的路径
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if r := []rune(name); len(r) > maxFileNameLength {
+		name = string(r[len(r)-maxFileNameLength:])
+	}
+	return name
+}
+
+func toFileProto(file *biz.File) *pb.FileInfo {
+	return &pb.FileInfo{
+		Uid:       file.UID,
+		Name:      file.Name,
+		Mime:      file.Mime,
+		Size:      file.Size,
+		Hash:      file.Hash,
+		Purpose:   pb.FilePurpose(file.Purpose),
+		OwnerId:   file.OwnerID,
+		Url:       biz.FileURL(file.UID),
+		CreatedAt: timestamppb.New(file.CreatedAt),
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/omalloc/kratos-admin/api/console/administration"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+	"github.com/omalloc/kratos-admin/pkg/jwt"
+	"github.com/omalloc/kratos-admin/pkg/mailer"
+	"github.com/omalloc/kratos-admin/pkg/tokener"
+)
+
+// InviteUser 邀请用户, 用户通过激活邮件中的链接设置密码
+func (s *UserService) InviteUser(ctx context.Context, req *pb.InviteUserRequest) (*pb.InviteUserReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	token, invitation, err := s.newInvitation(claims.UID)
+	if err != nil {
+		return nil, err
+	}
+	user := &biz.User{
+		UID:       idgen.NextId(),
+		Username:  req.Username,
+		Email:     req.Email,
+		Nickname:  req.Nickname,
+		LastLogin: time.Now(),
+	}
+	if err := s.invitationUsecase.InviteUser(ctx, user, req.RoleIds, invitation); err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "user.invited", event.NewMessage(event.NewUUID(), event.Marshal(invitation)))
+	return &pb.InviteUserReply{
+		Uid:       user.UID,
+		ExpiresAt: timestamppb.New(invitation.ExpiresAt),
+		MailSent:  s.sendInvitation(ctx, user, token, invitation),
+	}, nil
+}
+
+// ResendInvitation 重新发送邀请
+func (s *UserService) ResendInvitation(ctx context.Context, req *pb.ResendInvitationRequest) (*pb.ResendInvitationReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	token, invitation, err := s.newInvitation(claims.UID)
+	if err != nil {
+		return nil, err
+	}
+	invitation.UserID = req.Uid
+	user, err := s.invitationUsecase.ResendInvitation(ctx, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "user.invited", event.NewMessage(event.NewUUID(), event.Marshal(invitation)))
+	return &pb.ResendInvitationReply{
+		ExpiresAt: timestamppb.New(invitation.ExpiresAt),
+		MailSent:  s.sendInvitation(ctx, user, token, invitation),
+	}, nil
+}
+
+func (s *UserService) newInvitation(invitedBy int64) (string, *biz.Invitation, error) {
+	token, digest, err := tokener.NewInvitationToken(s.passportc.Secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ttl := biz.DefaultInvitationTTL
+	if s.passportc.InvitationTtl != nil && s.passportc.InvitationTtl.AsDuration() > 0 {
+		ttl = s.passportc.InvitationTtl.AsDuration()
+	}
+	return token, &biz.Invitation{
+		UID:       idgen.NextId(),
+		Digest:    digest,
+		InvitedBy: invitedBy,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// sendInvitation 发送激活邮件, 发送失败不影响邀请本身, 可重新发送
+func (s *UserService) sendInvitation(ctx context.Context, user *biz.User, token string, invitation *biz.Invitation) bool {
+	link := tokenLink(s.passportc.InvitationUrl, token)
+	err := s.mailer.Send(ctx, &mailer.Message{
+		To:      []string{user.Email},
+		Subject: "账号激活邀请",
+		Body: fmt.Sprintf("%s 您好:\n\n您已被邀请加入系统, 请在 %s 前通过以下链接设置密码并激活账号:\n\n%s\n",
+			lo.Ternary(user.Nickname != "", user.Nickname, user.Username), invitation.ExpiresAt.Format(time.DateTime), link),
+	})
+	if err != nil {
+		s.log.Errorf("send invitation to user %d failed: %v", user.UID, err)
+		return false
+	}
+	return true
+}
+
+// tokenLink 将令牌以 token 参数追加到链接后, 未配置链接时返回令牌本身
+func tokenLink(base string, token string) string {
+	if base == "" {
+		return token
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return token
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/omalloc/contrib/protobuf"
 	"github.com/omalloc/kratos-admin/pkg/idgen"
 	"github.com/samber/lo"
+	"golang.org/x/text/language"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/omalloc/kratos-admin/api/console/administration"
@@ -24,14 +26,21 @@ func NewMenuService(usecase *biz.MenuUsecase) *MenuService {
 
 func (s *MenuService) CreateMenu(ctx context.Context, req *pb.CreateMenuRequest) (*pb.CreateMenuReply, error) {
 	m := &biz.Menu{
-		UID:    idgen.NextId(),
-		PID:    req.Pid,
-		Name:   req.Name,
-		Icon:   req.Icon,
-		Path:   req.Path,
-		SortBy: req.SortBy,
-		Hidden: req.Hidden,
-		Status: int32(req.Status),
+		UID:          idgen.NextId(),
+		PID:          req.Pid,
+		PermissionID: req.PermissionId,
+		Name:         req.Name,
+		Icon:         req.Icon,
+		Path:         req.Path,
+		SortBy:       req.SortBy,
+		Hidden:       req.Hidden,
+		Status:       int32(req.Status),
+		Titles:       req.Titles,
+		Component:    req.Component,
+		Redirect:     req.Redirect,
+		Target:       req.Target,
+		KeepAlive:    req.KeepAlive,
+		Badge:        req.Badge,
 	}
 
 	if err := s.usecase.Create(ctx, m); err != nil {
@@ -54,6 +63,13 @@ func (s *MenuService) UpdateMenu(ctx context.Context, req *pb.UpdateMenuRequest)
 		Hidden:       req.Hidden,
 		PermissionID: req.PermissionId,
 		Status:       int32(req.Status),
+		Titles:       req.Titles,
+		Component:    req.Component,
+		Redirect:     req.Redirect,
+		Target:       req.Target,
+		KeepAlive:    req.KeepAlive,
+		Badge:        req.Badge,
+		Version:      req.Version,
 	}
 
 	if err := s.usecase.Update(ctx, m); err != nil {
@@ -64,11 +80,65 @@ func (s *MenuService) UpdateMenu(ctx context.Context, req *pb.UpdateMenuRequest)
 }
 
 func (s *MenuService) DeleteMenu(ctx context.Context, req *pb.DeleteMenuRequest) (*pb.DeleteMenuReply, error) {
-	if err := s.usecase.Delete(ctx, req.Uid); err != nil {
+	deleted, err := s.usecase.Delete(ctx, req.Uid, biz.MenuDeleteMode(req.Mode))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteMenuReply{Deleted: deleted}, nil
+}
+
+func (s *MenuService) RepairMenus(ctx context.Context, req *pb.RepairMenusRequest) (*pb.RepairMenusReply, error) {
+	issues, err := s.usecase.Repair(ctx, req.DryRun, req.DeleteOrphans)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RepairMenusReply{
+		Issues: lo.Map(issues, func(item *biz.MenuIssue, _ int) *pb.MenuIssue {
+			return &pb.MenuIssue{
+				Uid:          item.UID,
+				Name:         item.Name,
+				Pid:          item.PID,
+				PermissionId: item.PermissionID,
+				Type:         pb.MenuIssueType(item.Type),
+			}
+		}),
+		Repaired: !req.DryRun && len(issues) > 0,
+	}, nil
+}
+
+func (s *MenuService) MoveMenu(ctx context.Context, req *pb.MoveMenuRequest) (*pb.MoveMenuReply, error) {
+	if err := s.usecase.Move(ctx, req.Uid, req.Pid, int(req.Position)); err != nil {
+		return nil, err
+	}
+
+	return &pb.MoveMenuReply{}, nil
+}
+
+func (s *MenuService) ReorderMenus(ctx context.Context, req *pb.ReorderMenusRequest) (*pb.ReorderMenusReply, error) {
+	if err := s.usecase.Reorder(ctx, req.Pid, req.Uids); err != nil {
+		return nil, err
+	}
+
+	return &pb.ReorderMenusReply{}, nil
+}
+
+func (s *MenuService) GetMenuTree(ctx context.Context, req *pb.GetMenuTreeRequest) (*pb.GetMenuTreeReply, error) {
+	var allow func(m *biz.Menu) bool
+	if req.EnabledOnly {
+		allow = func(m *biz.Menu) bool {
+			return m.Status == biz.MenuStatusEnable
+		}
+	}
+	tree, err := s.usecase.SelectTree(ctx, allow)
+	if err != nil {
 		return nil, err
 	}
 
-	return &pb.DeleteMenuReply{}, nil
+	return &pb.GetMenuTreeReply{
+		Data: localizeMenus(lo.Map(tree, toMenuNodeProto), acceptLanguages(ctx)),
+	}, nil
 }
 
 func (s *MenuService) GetMenu(ctx context.Context, req *pb.GetMenuRequest) (*pb.GetMenuReply, error) {
@@ -78,7 +148,7 @@ func (s *MenuService) GetMenu(ctx context.Context, req *pb.GetMenuRequest) (*pb.
 	}
 
 	return &pb.GetMenuReply{
-		Data: toMenuProto(m, 0),
+		Data: localizeMenus([]*pb.MenuInfo{toMenuProto(m, 0)}, acceptLanguages(ctx))[0],
 	}, nil
 }
 
@@ -90,7 +160,7 @@ func (s *MenuService) ListMenu(ctx context.Context, req *pb.ListMenuRequest) (*p
 	}
 
 	return &pb.ListMenuReply{
-		Data:       lo.Map(menus, toMenuProto),
+		Data:       localizeMenus(lo.Map(menus, toMenuProto), acceptLanguages(ctx)),
 		Pagination: pagination.Resp(),
 	}, nil
 }
@@ -108,5 +178,44 @@ func toMenuProto(m *biz.Menu, _ int) *pb.MenuInfo {
 		Status:       pb.MenuStatus(m.Status),
 		CreatedAt:    timestamppb.New(m.CreatedAt),
 		UpdatedAt:    timestamppb.New(m.UpdatedAt),
+		Title:        m.Name,
+		Titles:       m.Titles,
+		Component:    m.Component,
+		Redirect:     m.Redirect,
+		Target:       m.Target,
+		KeepAlive:    m.KeepAlive,
+		Badge:        m.Badge,
+		Version:      m.Version,
+	}
+}
+
+func toMenuNodeProto(node *biz.MenuNode, _ int) *pb.MenuInfo {
+	m := toMenuProto(node.Menu, 0)
+	m.Actions = node.Actions
+	m.Children = lo.Map(node.Children, toMenuNodeProto)
+	return m
+}
+
+// localizeMenus 按语言优先级填充菜单及其子菜单的 title
+func localizeMenus(menus []*pb.MenuInfo, langs []string) []*pb.MenuInfo {
+	for _, m := range menus {
+		m.Title = biz.LocalizedTitle(m.Titles, m.Name, langs)
+		localizeMenus(m.Children, langs)
+	}
+	return menus
+}
+
+// acceptLanguages 请求头 Accept-Language 中的语言, 按权重降序
+func acceptLanguages(ctx context.Context) []string {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(tr.RequestHeader().Get("Accept-Language"))
+	if err != nil {
+		return nil
 	}
+	return lo.Map(tags, func(item language.Tag, _ int) string {
+		return item.String()
+	})
 }
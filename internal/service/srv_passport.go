@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"math/rand"
+	"net/mail"
 	"strconv"
 	"time"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/samber/lo"
@@ -18,48 +21,82 @@ import (
 	"github.com/omalloc/kratos-admin/internal/biz"
 	"github.com/omalloc/kratos-admin/internal/conf"
 	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
 	"github.com/omalloc/kratos-admin/pkg/jwt"
+	"github.com/omalloc/kratos-admin/pkg/mailer"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
 	"github.com/omalloc/kratos-admin/pkg/tokener"
 )
 
-const defaultCaptchaLen = 6
+const (
+	defaultCaptchaLen = 6
+	// captchaTTL 验证码有效期 (秒)
+	captchaTTL = 300
+)
+
+var (
+	ErrCaptchaInvalid   = errors.New(400, "CAPTCHA_INVALID", "验证码错误或已过期, 请重新获取")
+	ErrProfileForbidden = errors.New(403, "PROFILE_FORBIDDEN", "只能修改自己的信息")
+)
 
 type PassportService struct {
 	pb.UnimplementedPassportServer
 
 	userUsecase               *biz.UserUsecase
 	menuUsecase               *biz.MenuUsecase
+	tenantUsecase             *biz.TenantUsecase
+	invitationUsecase         *biz.InvitationUsecase
+	registrationUsecase       *biz.RegistrationUsecase
+	secret                    string
+	verifyURL                 string
 	tokenizer                 tokener.AppToken
 	etcdClient                *clientv3.Client
+	mailer                    mailer.Mailer
 	applicationEventPublisher *event.ApplicationEventPublisher
 }
 
 func NewPassportService(c *conf.Bootstrap, applicationEventPublisher *event.ApplicationEventPublisher,
 	userUsecase *biz.UserUsecase,
 	menuUsecase *biz.MenuUsecase,
+	tenantUsecase *biz.TenantUsecase,
+	invitationUsecase *biz.InvitationUsecase,
+	registrationUsecase *biz.RegistrationUsecase,
 	etcdClient *clientv3.Client,
+	mailer mailer.Mailer,
 ) *PassportService {
 	return &PassportService{
 		applicationEventPublisher: applicationEventPublisher,
 		userUsecase:               userUsecase,
 		menuUsecase:               menuUsecase,
+		tenantUsecase:             tenantUsecase,
+		invitationUsecase:         invitationUsecase,
+		registrationUsecase:       registrationUsecase,
+		verifyURL:                 c.Passport.GetRegistration().GetVerifyUrl(),
+		secret:                    c.Passport.Secret,
 		tokenizer: tokener.NewTokener(
 			tokener.WithTTL(time.Hour),
 			tokener.WithSecret(c.Passport.Secret),
 		),
 		etcdClient: etcdClient,
+		mailer:     mailer,
 	}
 }
 
 // Login 登录
 func (s *PassportService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginReply, error) {
-	user, err := s.userUsecase.Login(ctx, req.Username, req.Password, req.AutoLogin)
+	tenantID, err := s.tenantUsecase.Resolve(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	// 用户名在租户内唯一, 登录时限定在指定租户内查找
+	user, err := s.userUsecase.Login(tenant.NewContext(ctx, tenantID), req.Username, req.Password, req.NewPassword, req.AutoLogin)
 	if err != nil {
 		s.applicationEventPublisher.Publish(ctx, "passport.login.failed", event.NewMessage(event.NewUUID(), []byte(err.Error())))
 		return nil, err
 	}
 
-	token, err := s.tokenizer.Generate(user.UID)
+	token, err := s.tokenizer.Generate(user.UID, user.TenantID, user.SuperAdmin)
 	if err != nil {
 		return nil, err
 	}
@@ -83,58 +120,82 @@ func (s *PassportService) Logout(ctx context.Context, req *pb.LogoutRequest) (*p
 	return &pb.LogoutReply{}, nil
 }
 
-// 发送验证码 (登录的用户)
+// 发送验证码 (登录的用户), 验证码与接收的邮箱或手机号绑定
 func (s *PassportService) SendCaptcha(ctx context.Context, req *pb.SendCaptchaRequest) (*pb.SendCaptchaReply, error) {
 	claims, _ := jwt.FromContext(ctx)
 	code := generateCaptcha(defaultCaptchaLen)
 
-	switch req.Type {
-	case pb.CaptchaType_CAPTCHA_TYPE_SMS:
-		// TODO: send sms captcha
-	case pb.CaptchaType_CAPTCHA_TYPE_EMAIL:
-		// TODO: send email captcha
+	if req.Type == pb.CaptchaType_CAPTCHA_TYPE_EMAIL {
+		if addr, err := mail.ParseAddress(req.From); err != nil || addr.Address != req.From {
+			return nil, biz.ErrEmailInvalid
+		}
 	}
 
-	key := s.fmtPassportCaptchaKey(claims.UID)
-	_, err := s.etcdClient.Put(ctx, key, code, clientv3.WithLease(clientv3.LeaseID(0)))
+	// 设置过期时间 5 分钟
+	lease, err := s.etcdClient.Grant(ctx, captchaTTL)
 	if err != nil {
 		return nil, err
 	}
-	// 设置过期时间 5 分钟
-	lease, err := s.etcdClient.Grant(ctx, 300)
-	if err == nil {
-		_, _ = s.etcdClient.Put(ctx, key, code, clientv3.WithLease(lease.ID))
+	if _, err := s.etcdClient.Put(ctx, s.fmtPassportCaptchaKey(claims.UID, req.From), code, clientv3.WithLease(lease.ID)); err != nil {
+		return nil, err
 	}
 
-	log.Infof("send captcha to user: %d, code: %s", claims.UID, code)
+	switch req.Type {
+	case pb.CaptchaType_CAPTCHA_TYPE_SMS:
+		// TODO: send sms captcha
+		log.Infof("send captcha to user: %d, code: %s", claims.UID, code)
+	case pb.CaptchaType_CAPTCHA_TYPE_EMAIL:
+		err := s.mailer.Send(ctx, &mailer.Message{
+			To:      []string{req.From},
+			Subject: "邮箱验证码",
+			Body:    fmt.Sprintf("您的验证码是 %s, %d 分钟内有效.\n", code, captchaTTL/60),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &pb.SendCaptchaReply{}, nil
 }
 
-// 注册
+// 注册, 用户需要通过邮件中的链接验证邮箱
 func (s *PassportService) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterReply, error) {
-	// 假设 req 里有 phone 和 captcha 字段
-	if req.Email == "" || req.Password == "" {
-		return nil, fmt.Errorf("email and password are required")
+	tenantID, err := s.tenantUsecase.Resolve(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
 	}
+	ctx = tenant.NewContext(ctx, tenantID)
 
-	nickname := generateCaptcha(6) // 游客用户
-	s.userUsecase.CreateUser(ctx, &biz.User{
-		Username: req.Username,
-		Email:    req.Email,
-		Nickname: fmt.Sprintf("游客用户%s", nickname),
-		Password: req.Password,
-	})
+	token, verification, err := s.newVerification()
+	if err != nil {
+		return nil, err
+	}
+	user := &biz.User{
+		UID:       idgen.NextId(),
+		Username:  req.Username,
+		Email:     req.Email,
+		Nickname:  lo.Ternary(req.Nickname != "", req.Nickname, fmt.Sprintf("游客用户%s", generateCaptcha(6))),
+		LastLogin: time.Now(),
+	}
+	if err := s.registrationUsecase.Register(ctx, user, req.Password, verification); err != nil {
+		return nil, err
+	}
 
+	s.applicationEventPublisher.Publish(ctx, "passport.register.success", event.NewMessage(event.NewUUID(), event.Marshal(user)))
+	s.sendVerification(ctx, user, token, verification)
 	return &pb.RegisterReply{}, nil
 }
 
 // 发送重置密码验证码到邮箱或短信SMS
 func (s *PassportService) SendResetPassword(ctx context.Context, req *pb.SendResetPasswordCaptchaRequest) (*pb.SendResetPasswordCaptchaReply, error) {
-	key := s.fmtPassportResetKey(req.Email)
+	tenantID, err := s.tenantUsecase.Resolve(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	key := s.fmtPassportResetKey(tenantID, req.Email)
 
 	code := generateCaptcha(defaultCaptchaLen)
-	_, err := s.etcdClient.Put(ctx, key, code)
+	_, err = s.etcdClient.Put(ctx, key, code)
 	if err != nil {
 		return nil, err
 	}
@@ -152,8 +213,15 @@ func (s *PassportService) ResetPassword(ctx context.Context, req *pb.ResetPasswo
 		return nil, fmt.Errorf("email, new-password and token are required")
 	}
 
+	// 邮箱只在租户内唯一, 验证码与用户都限定在请求的租户内
+	tenantID, err := s.tenantUsecase.Resolve(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.NewContext(ctx, tenantID)
+
 	// match email token
-	key := s.fmtPassportResetKey(req.Email)
+	key := s.fmtPassportResetKey(tenantID, req.Email)
 	resp, err := s.etcdClient.Get(ctx, key)
 	if err != nil || len(resp.Kvs) == 0 {
 		return nil, fmt.Errorf("captcha expired or not found")
@@ -174,16 +242,84 @@ func (s *PassportService) ResetPassword(ctx context.Context, req *pb.ResetPasswo
 	return &pb.ResetPasswordReply{}, nil
 }
 
+// AcceptInvitation 接受邀请, 设置密码并激活用户
+func (s *PassportService) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.AcceptInvitationReply, error) {
+	if req.Password != req.RePassword {
+		return nil, ErrPasswordMismatch
+	}
+
+	digest, err := tokener.VerifyInvitationToken(s.secret, req.Token)
+	if err != nil {
+		return nil, biz.ErrInvitationNotFound
+	}
+	user, err := s.invitationUsecase.AcceptInvitation(ctx, digest, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "passport.invitation.accepted", event.NewMessage(event.NewUUID(), event.Marshal(user)))
+	return &pb.AcceptInvitationReply{}, nil
+}
+
 // 更新用户名
 func (s *PassportService) UpdateUsername(ctx context.Context, req *pb.UpdateUsernameRequest) (*pb.UpdateUsernameReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+	if req.Id != claims.UID {
+		return nil, ErrProfileForbidden
+	}
+
+	if err := s.userUsecase.UpdateUsername(ctx, claims.UID, req.Username); err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "passport.username.changed", event.NewMessage(event.NewUUID(), event.Marshal(req)))
 	return &pb.UpdateUsernameReply{}, nil
 }
 
-// 更新用户信息
+// 更新用户信息, 修改邮箱时需要新邮箱收到的验证码
 func (s *PassportService) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+
+	var captchaKey string
+	if req.Email != "" {
+		captchaKey = s.fmtPassportCaptchaKey(claims.UID, req.Email)
+		if err := s.verifyCaptcha(ctx, captchaKey, req.Captcha); err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.userUsecase.UpdateProfile(ctx, claims.UID, &biz.Profile{
+		Nickname: req.Nickname,
+		Bio:      req.Bio,
+		AvatarID: req.AvatarId,
+		Email:    req.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if captchaKey != "" {
+		_, _ = s.etcdClient.Delete(ctx, captchaKey)
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "passport.profile.updated", event.NewMessage(event.NewUUID(), []byte(strconv.FormatInt(claims.UID, 10))))
 	return &pb.UpdateProfileReply{}, nil
 }
 
+// 修改密码
+func (s *PassportService) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordReply, error) {
+	claims, _ := jwt.FromContext(ctx)
+	if req.NewPassword != req.RePassword {
+		return nil, ErrPasswordMismatch
+	}
+
+	if err := s.userUsecase.ChangePassword(ctx, claims.UID, req.OldPassword, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "passport.password.changed", event.NewMessage(event.NewUUID(), []byte(strconv.FormatInt(claims.UID, 10))))
+	return &pb.ChangePasswordReply{}, nil
+}
+
 // 获取当前用户信息
 func (s *PassportService) CurrentUser(ctx context.Context, req *pb.CurrentUserRequest) (*pb.CurrentUserReply, error) {
 	claims, _ := jwt.FromContext(ctx)
@@ -224,21 +360,17 @@ func (s *PassportService) CurrentUser(ctx context.Context, req *pb.CurrentUserRe
 		}
 	}
 
-	menus, err := s.menuUsecase.SelectAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	userMenus := lo.Filter(menus, func(item *biz.Menu, _ int) bool {
+	menuTree, err := s.menuUsecase.SelectTree(ctx, func(item *biz.Menu) bool {
 		if item.PermissionID == 0 {
 			return true
 		}
 
-		if _, exist := permissionMap[item.PermissionID]; exist {
-			return true
-		}
-		return false
+		_, exist := permissionMap[item.PermissionID]
+		return exist
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &pb.CurrentUserReply{
 		User: &adminpb.UserInfo{
@@ -246,26 +378,53 @@ func (s *PassportService) CurrentUser(ctx context.Context, req *pb.CurrentUserRe
 			Username:  user.Username,
 			Nickname:  user.Nickname,
 			Email:     user.Email,
+			Avatar:    biz.AvatarURL(user.AvatarID),
 			Status:    adminpb.UserStatus(user.Status),
 			CreatedAt: timestamppb.New(user.CreatedAt),
 			UpdatedAt: timestamppb.New(user.UpdatedAt),
 		},
 		Roles:      roleMap,
-		AllowMenus: lo.Map(userMenus, toMenuProto),
+		AllowMenus: localizeMenus(lo.Map(biz.FlattenMenuTree(menuTree), toMenuProto), acceptLanguages(ctx)),
+		MenuTree:   localizeMenus(lo.Map(menuTree, toMenuNodeProto), acceptLanguages(ctx)),
 	}, nil
 }
 
 // 获取授权的菜单
 func (s *PassportService) AuthorizeMenu(ctx context.Context, req *pb.AuthorizeMenuRequest) (*pb.AuthorizeMenuReply, error) {
-	return &pb.AuthorizeMenuReply{}, nil
+	claims, _ := jwt.FromContext(ctx)
+
+	tree, err := s.menuUsecase.Authorize(ctx, claims.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthorizeMenuReply{
+		Data: localizeMenus(lo.Map(tree, toMenuNodeProto), acceptLanguages(ctx)),
+	}, nil
+}
+
+func (s *PassportService) fmtPassportCaptchaKey(uid int64, target string) string {
+	return fmt.Sprintf("/app/passport/captcha/%d/%s", uid, target)
 }
 
-func (s *PassportService) fmtPassportCaptchaKey(uid int64) string {
-	return fmt.Sprintf("/app/passport/captcha/%d", uid)
+// verifyCaptcha 校验验证码, 验证码错误时立即失效, 防止暴力尝试
+func (s *PassportService) verifyCaptcha(ctx context.Context, key string, code string) error {
+	resp, err := s.etcdClient.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) == 0 || code == "" {
+		return ErrCaptchaInvalid
+	}
+	if subtle.ConstantTimeCompare(resp.Kvs[0].Value, []byte(code)) != 1 {
+		_, _ = s.etcdClient.Delete(ctx, key)
+		return ErrCaptchaInvalid
+	}
+	return nil
 }
 
-func (s *PassportService) fmtPassportResetKey(emailOrPhone string) string {
-	return fmt.Sprintf("/app/passport/reset/%s", emailOrPhone)
+func (s *PassportService) fmtPassportResetKey(tenantID int64, emailOrPhone string) string {
+	return fmt.Sprintf("/app/passport/reset/%d/%s", tenantID, emailOrPhone)
 }
 
 // 生成6位随机数字验证码
@@ -43,6 +43,7 @@ func (s *PermissionService) UpdatePermission(ctx context.Context, req *pb.Update
 		Describe: req.Describe,
 		Status:   int64(req.Status),
 		Actions:  lo.Map(req.Actions, toAction),
+		Version:  req.Version,
 	}); err != nil {
 		return nil, err
 	}
@@ -69,6 +70,7 @@ func (s *PermissionService) GetPermission(ctx context.Context, req *pb.GetPermis
 		Describe: permission.Describe,
 		Actions:  lo.Map(permission.Actions, fromAction),
 		Status:   pb.PermissionStatus(permission.Status),
+		Version:  permission.Version,
 	}, nil
 }
 func (s *PermissionService) ListPermission(ctx context.Context, req *pb.ListPermissionRequest) (*pb.ListPermissionReply, error) {
@@ -104,6 +106,7 @@ func (s *PermissionService) toMap(permission *biz.Permission, _ int) *pb.Permiss
 		AllowDelete: len(permission.Tags) <= 0, // 没有 tag 才可以删除
 		Actions:     lo.Map(permission.Actions, fromAction),
 		Status:      pb.PermissionStatus(permission.Status),
+		Version:     permission.Version,
 	}
 }
 
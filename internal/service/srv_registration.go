@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+
+	adminpb "github.com/omalloc/kratos-admin/api/console/administration"
+	pb "github.com/omalloc/kratos-admin/api/console/passport"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+	"github.com/omalloc/kratos-admin/pkg/mailer"
+	"github.com/omalloc/kratos-admin/pkg/tenant"
+	"github.com/omalloc/kratos-admin/pkg/tokener"
+)
+
+// VerifyEmail 验证注册邮箱
+func (s *PassportService) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailReply, error) {
+	digest, err := tokener.VerifyInvitationToken(s.secret, req.Token)
+	if err != nil {
+		return nil, biz.ErrVerificationNotFound
+	}
+	user, err := s.registrationUsecase.VerifyEmail(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "passport.email.verified", event.NewMessage(event.NewUUID(), event.Marshal(user)))
+	return &pb.VerifyEmailReply{
+		AwaitingApproval: user.Status == biz.UserStatusAwaitingApproval,
+	}, nil
+}
+
+// ResendVerification 重新发送验证邮件, 邮箱未注册或已验证时同样返回成功, 避免泄露注册信息
+func (s *PassportService) ResendVerification(ctx context.Context, req *pb.ResendVerificationRequest) (*pb.ResendVerificationReply, error) {
+	tenantID, err := s.tenantUsecase.Resolve(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	token, verification, err := s.newVerification()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.registrationUsecase.ResendVerification(tenant.NewContext(ctx, tenantID), req.Email, verification)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		s.sendVerification(ctx, user, token, verification)
+	}
+	return &pb.ResendVerificationReply{}, nil
+}
+
+func (s *PassportService) newVerification() (string, *biz.EmailVerification, error) {
+	// 与邀请令牌格式相同, 摘要保存在不同的表中
+	token, digest, err := tokener.NewInvitationToken(s.secret)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &biz.EmailVerification{
+		UID:       idgen.NextId(),
+		Digest:    digest,
+		ExpiresAt: time.Now().Add(s.registrationUsecase.Policy().VerifyTTL),
+	}, nil
+}
+
+// sendVerification 发送验证邮件, 发送失败时用户可以重新发送
+func (s *PassportService) sendVerification(ctx context.Context, user *biz.User, token string, verification *biz.EmailVerification) {
+	err := s.mailer.Send(ctx, &mailer.Message{
+		To:      []string{user.Email},
+		Subject: "验证注册邮箱",
+		Body: fmt.Sprintf("%s 您好:\n\n感谢注册, 请在 %s 前通过以下链接验证邮箱:\n\n%s\n",
+			user.Username, verification.ExpiresAt.Format(time.DateTime), tokenLink(s.verifyURL, token)),
+	})
+	if err != nil {
+		log.Errorf("send verification to user %d failed: %v", user.UID, err)
+	}
+}
+
+// ListRegistrations 待审批的注册申请
+func (s *UserService) ListRegistrations(ctx context.Context, req *adminpb.ListRegistrationsRequest) (*adminpb.ListRegistrationsReply, error) {
+	pagination := protobuf.PageWrap(req.Pagination)
+	users, err := s.registrationUsecase.ListPending(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &adminpb.ListRegistrationsReply{
+		Pagination: pagination.Resp(),
+		Data:       lo.Map(users, toMap),
+	}, nil
+}
+
+// ApproveRegistration 通过注册申请并通知用户
+func (s *UserService) ApproveRegistration(ctx context.Context, req *adminpb.ApproveRegistrationRequest) (*adminpb.ApproveRegistrationReply, error) {
+	user, err := s.registrationUsecase.Approve(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "user.registration.approved", event.NewMessage(event.NewUUID(), event.Marshal(user)))
+	return &adminpb.ApproveRegistrationReply{
+		MailSent: s.sendRegistrationResult(ctx, user, "您的注册申请已通过, 现在可以登录了.\n"),
+	}, nil
+}
+
+// RejectRegistration 拒绝注册申请并通知用户
+func (s *UserService) RejectRegistration(ctx context.Context, req *adminpb.RejectRegistrationRequest) (*adminpb.RejectRegistrationReply, error) {
+	user, err := s.registrationUsecase.Reject(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	body := "很抱歉, 您的注册申请未通过.\n"
+	if req.Reason != "" {
+		body += fmt.Sprintf("\n原因: %s\n", req.Reason)
+	}
+	s.applicationEventPublisher.Publish(ctx, "user.registration.rejected", event.NewMessage(event.NewUUID(), event.Marshal(user)))
+	return &adminpb.RejectRegistrationReply{
+		MailSent: s.sendRegistrationResult(ctx, user, body),
+	}, nil
+}
+
+func (s *UserService) sendRegistrationResult(ctx context.Context, user *biz.User, body string) bool {
+	err := s.mailer.Send(ctx, &mailer.Message{
+		To:      []string{user.Email},
+		Subject: "注册审批结果",
+		Body:    fmt.Sprintf("%s 您好:\n\n%s", user.Username, body),
+	})
+	if err != nil {
+		s.log.Errorf("send registration result to user %d failed: %v", user.UID, err)
+		return false
+	}
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/omalloc/contrib/protobuf"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
 	"github.com/samber/lo"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -25,23 +26,42 @@ func NewRoleService(usecase *biz.RoleUsecase) *RoleService {
 
 func (s *RoleService) CreateRole(ctx context.Context, req *pb.CreateRoleRequest) (*pb.CreateRoleReply, error) {
 	if err := s.usecase.CreateRole(ctx, &biz.Role{
-		Name:     req.Name,
-		Describe: req.Describe,
-		Alias:    req.Alias,
-		Status:   int64(req.Status),
+		Name:       req.Name,
+		Describe:   req.Describe,
+		Alias:      req.Alias,
+		Status:     int64(req.Status),
+		IsTemplate: req.IsTemplate,
 	}); err != nil {
 		return nil, err
 	}
 	return &pb.CreateRoleReply{}, nil
 }
 func (s *RoleService) UpdateRole(ctx context.Context, req *pb.UpdateRoleRequest) (*pb.UpdateRoleReply, error) {
+	var fields []string
+	if req.Name != nil {
+		fields = append(fields, "name")
+	}
+	if req.Alias != nil {
+		fields = append(fields, "alias")
+	}
+	if req.Describe != nil {
+		fields = append(fields, "describe")
+	}
+	if req.Status != nil {
+		fields = append(fields, "status")
+	}
+	if req.IsTemplate != nil {
+		fields = append(fields, "is_template")
+	}
 	if err := s.usecase.UpdateRole(ctx, &biz.Role{
-		UID:      req.Uid,
-		Name:     req.Name,
-		Alias:    req.Alias,
-		Describe: req.Describe,
-		Status:   int64(req.Status),
-	}); err != nil {
+		UID:        req.Uid,
+		Name:       req.GetName(),
+		Alias:      req.GetAlias(),
+		Describe:   req.GetDescribe(),
+		Status:     int64(req.GetStatus()),
+		IsTemplate: req.GetIsTemplate(),
+		Version:    req.Version,
+	}, fields...); err != nil {
 		return nil, err
 	}
 	return &pb.UpdateRoleReply{}, nil
@@ -58,11 +78,13 @@ func (s *RoleService) GetRole(ctx context.Context, req *pb.GetRoleRequest) (*pb.
 		return nil, err
 	}
 	return &pb.GetRoleReply{
-		Uid:      role.UID,
-		Name:     role.Name,
-		Alias:    role.Alias,
-		Describe: role.Describe,
-		Status:   int32(role.Status),
+		Uid:        role.UID,
+		Name:       role.Name,
+		Alias:      role.Alias,
+		Describe:   role.Describe,
+		Status:     int32(role.Status),
+		IsTemplate: role.IsTemplate,
+		Version:    role.Version,
 		Permissions: lo.Map(role.Permissions, func(item *biz.RolePermission, _ int) *pb.RolePermission {
 			return &pb.RolePermission{
 				RoleId:     item.RoleID,
@@ -97,6 +119,27 @@ func (s *RoleService) BindPermission(ctx context.Context, req *pb.BindPermission
 
 	return &pb.BindPermissionReply{}, nil
 }
+func (s *RoleService) SetRolePermissions(ctx context.Context, req *pb.SetRolePermissionsRequest) (*pb.SetRolePermissionsReply, error) {
+	bindings := lo.Map(req.Data, func(item *pb.BindPermissionRequest_BindPermissionBody, _ int) *biz.RolePermission {
+		return &biz.RolePermission{
+			RoleID:     req.Uid,
+			PermID:     item.PermissionId,
+			Actions:    lo.Map(item.Actions, toAction),
+			DataAccess: lo.Map(item.DataAccess, toAction),
+		}
+	})
+
+	diff, err := s.usecase.SetRolePermissions(ctx, req.Uid, bindings)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetRolePermissionsReply{
+		Added:   diff.Added,
+		Updated: diff.Updated,
+		Removed: diff.Removed,
+	}, nil
+}
+
 func (s *RoleService) UnbindPermission(ctx context.Context, req *pb.UnbindPermissionRequest) (*pb.UnbindPermissionReply, error) {
 	if err := s.usecase.UnbindPermission(ctx, req.Uid, req.PermissionId); err != nil {
 		return nil, err
@@ -105,7 +148,7 @@ func (s *RoleService) UnbindPermission(ctx context.Context, req *pb.UnbindPermis
 }
 
 func (s *RoleService) GetAll(ctx context.Context, req *pb.GetAllRequest) (*pb.GetAllReply, error) {
-	roles, err := s.usecase.GetAll(ctx)
+	roles, err := s.usecase.GetAll(ctx, req.IncludeTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +157,88 @@ func (s *RoleService) GetAll(ctx context.Context, req *pb.GetAllRequest) (*pb.Ge
 	}, nil
 }
 
+func (s *RoleService) CloneRole(ctx context.Context, req *pb.CloneRoleRequest) (*pb.CloneRoleReply, error) {
+	role := &biz.Role{
+		UID:        idgen.NextId(),
+		Name:       req.Name,
+		Alias:      req.Alias,
+		Describe:   req.Describe,
+		IsTemplate: req.IsTemplate,
+	}
+	if err := s.usecase.CloneRole(ctx, req.Uid, role); err != nil {
+		return nil, err
+	}
+	return &pb.CloneRoleReply{
+		Uid: role.UID,
+	}, nil
+}
+
+func (s *RoleService) CreateRoleConstraint(ctx context.Context, req *pb.CreateRoleConstraintRequest) (*pb.CreateRoleConstraintReply, error) {
+	constraint := &biz.RoleConstraint{
+		UID:      idgen.NextId(),
+		Name:     req.Name,
+		Describe: req.Describe,
+		RoleA:    req.RoleA,
+		RoleB:    req.RoleB,
+	}
+	violations, err := s.usecase.CreateRoleConstraint(ctx, constraint)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateRoleConstraintReply{
+		Uid:        constraint.UID,
+		Violations: int64(violations),
+	}, nil
+}
+
+func (s *RoleService) DeleteRoleConstraint(ctx context.Context, req *pb.DeleteRoleConstraintRequest) (*pb.DeleteRoleConstraintReply, error) {
+	if err := s.usecase.DeleteRoleConstraint(ctx, req.Uid); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteRoleConstraintReply{}, nil
+}
+
+func (s *RoleService) ListRoleConstraint(ctx context.Context, req *pb.ListRoleConstraintRequest) (*pb.ListRoleConstraintReply, error) {
+	pagination := protobuf.PageWrap(req.Pagination)
+
+	constraints, err := s.usecase.ListRoleConstraint(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListRoleConstraintReply{
+		Pagination: pagination.Resp(),
+		Data:       lo.Map(constraints, s.toConstraintMap),
+	}, nil
+}
+
+func (s *RoleService) ListRoleConstraintViolation(ctx context.Context, req *pb.ListRoleConstraintViolationRequest) (*pb.ListRoleConstraintViolationReply, error) {
+	constraint, violations, err := s.usecase.ListRoleConstraintViolation(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListRoleConstraintViolationReply{
+		Constraint: s.toConstraintMap(constraint, 0),
+		Data: lo.Map(violations, func(item *biz.RoleConstraintViolation, _ int) *pb.ListRoleConstraintViolationReply_Violation {
+			return &pb.ListRoleConstraintViolationReply_Violation{
+				UserId:   item.UserID,
+				Username: item.Username,
+				Nickname: item.Nickname,
+			}
+		}),
+	}, nil
+}
+
+func (s *RoleService) toConstraintMap(item *biz.RoleConstraint, _ int) *pb.RoleConstraintInfo {
+	return &pb.RoleConstraintInfo{
+		Uid:       item.UID,
+		Name:      item.Name,
+		Describe:  item.Describe,
+		RoleA:     item.RoleA,
+		RoleB:     item.RoleB,
+		CreatedAt: timestamppb.New(item.CreatedAt),
+	}
+}
+
 func (s *RoleService) toRoleMap(item *biz.Role, _ int) *pb.RoleInfo {
 	return &pb.RoleInfo{
 		Uid:         item.UID,
@@ -121,6 +246,8 @@ func (s *RoleService) toRoleMap(item *biz.Role, _ int) *pb.RoleInfo {
 		Describe:    item.Describe,
 		Alias:       item.Alias,
 		Status:      int32(item.Status),
+		IsTemplate:  item.IsTemplate,
+		Version:     item.Version,
 		Permissions: lo.Map(item.Permissions, s.toPermission),
 	}
 }
@@ -132,6 +259,8 @@ func (s *RoleService) toRoleJoinedMap(item *biz.RoleJoinPermission, _ int) *pb.R
 		Describe:    item.Describe,
 		Alias:       item.Alias,
 		Status:      int32(item.Status),
+		IsTemplate:  item.IsTemplate,
+		Version:     item.Version,
 		Permissions: lo.Map(item.Permissions, s.toPermission),
 	}
 }
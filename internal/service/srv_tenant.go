@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+
+	"github.com/omalloc/contrib/protobuf"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/omalloc/kratos-admin/api/console/administration"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+)
+
+type TenantService struct {
+	pb.UnimplementedTenantServer
+
+	usecase *biz.TenantUsecase
+}
+
+func NewTenantService(usecase *biz.TenantUsecase) *TenantService {
+	return &TenantService{
+		usecase: usecase,
+	}
+}
+
+func (s *TenantService) CreateTenant(ctx context.Context, req *pb.CreateTenantRequest) (*pb.CreateTenantReply, error) {
+	t := &biz.Tenant{
+		UID:      idgen.NextId(),
+		Code:     req.Code,
+		Name:     req.Name,
+		Describe: req.Describe,
+		Status:   int64(req.Status),
+	}
+	if err := s.usecase.CreateTenant(ctx, t); err != nil {
+		return nil, err
+	}
+	return &pb.CreateTenantReply{
+		Uid: t.UID,
+	}, nil
+}
+
+func (s *TenantService) UpdateTenant(ctx context.Context, req *pb.UpdateTenantRequest) (*pb.UpdateTenantReply, error) {
+	if err := s.usecase.UpdateTenant(ctx, &biz.Tenant{
+		UID:      req.Uid,
+		Name:     req.Name,
+		Describe: req.Describe,
+		Status:   int64(req.Status),
+	}); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateTenantReply{}, nil
+}
+
+func (s *TenantService) DeleteTenant(ctx context.Context, req *pb.DeleteTenantRequest) (*pb.DeleteTenantReply, error) {
+	if err := s.usecase.DeleteTenant(ctx, req.Uid); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteTenantReply{}, nil
+}
+
+func (s *TenantService) GetTenant(ctx context.Context, req *pb.GetTenantRequest) (*pb.GetTenantReply, error) {
+	t, err := s.usecase.GetTenant(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetTenantReply{
+		Data: toTenantProto(t, 0),
+	}, nil
+}
+
+func (s *TenantService) ListTenant(ctx context.Context, req *pb.ListTenantRequest) (*pb.ListTenantReply, error) {
+	pagination := protobuf.PageWrap(req.Pagination)
+
+	tenants, err := s.usecase.ListTenant(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListTenantReply{
+		Pagination: pagination.Resp(),
+		Data:       lo.Map(tenants, toTenantProto),
+	}, nil
+}
+
+func toTenantProto(t *biz.Tenant, _ int) *pb.TenantInfo {
+	return &pb.TenantInfo{
+		Uid:       t.UID,
+		Code:      t.Code,
+		Name:      t.Name,
+		Describe:  t.Describe,
+		Status:    int32(t.Status),
+		CreatedAt: timestamppb.New(t.CreatedAt),
+		UpdatedAt: timestamppb.New(t.UpdatedAt),
+	}
+}
@@ -10,25 +10,42 @@ import (
 	"github.com/omalloc/contrib/protobuf"
 	"github.com/omalloc/kratos-admin/pkg/idgen"
 	"github.com/samber/lo"
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/omalloc/kratos-admin/api/console/administration"
 	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/internal/conf"
+	"github.com/omalloc/kratos-admin/internal/event"
+	"github.com/omalloc/kratos-admin/pkg/jwt"
+	"github.com/omalloc/kratos-admin/pkg/mailer"
 )
 
 var ErrPasswordMismatch = errors.New(400, "re-password mismatch", "两次密码不匹配")
 
 type UserService struct {
 	pb.UnimplementedUserServer
-	log     *log.Helper
-	usecase *biz.UserUsecase
+	log                       *log.Helper
+	usecase                   *biz.UserUsecase
+	invitationUsecase         *biz.InvitationUsecase
+	fileUsecase               *biz.FileUsecase
+	registrationUsecase       *biz.RegistrationUsecase
+	applicationEventPublisher *event.ApplicationEventPublisher
+	passportc                 *conf.Passport
+	mailer                    mailer.Mailer
 }
 
-func NewUserService(usecase *biz.UserUsecase, logger log.Logger) *UserService {
+func NewUserService(usecase *biz.UserUsecase, invitationUsecase *biz.InvitationUsecase, fileUsecase *biz.FileUsecase,
+	registrationUsecase *biz.RegistrationUsecase, applicationEventPublisher *event.ApplicationEventPublisher,
+	passportc *conf.Passport, mailer mailer.Mailer, logger log.Logger) *UserService {
 	return &UserService{
-		log:     log.NewHelper(logger),
-		usecase: usecase,
+		log:                       log.NewHelper(logger),
+		usecase:                   usecase,
+		invitationUsecase:         invitationUsecase,
+		fileUsecase:               fileUsecase,
+		registrationUsecase:       registrationUsecase,
+		applicationEventPublisher: applicationEventPublisher,
+		passportc:                 passportc,
+		mailer:                    mailer,
 	}
 }
 
@@ -46,13 +63,10 @@ func (s *UserService) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		Status:    int64(req.Status),
 		LastLogin: time.Now(),
 	}
-	if err := s.usecase.CreateUser(ctx, user); err != nil {
-		return nil, err
-	}
 	roleIDs := lo.Map(req.RoleIds, func(item string, _ int) int64 {
 		return lo.Must(strconv.ParseInt(item, 10, 64))
 	})
-	if err := s.usecase.UpdateRole(ctx, user.UID, roleIDs); err != nil {
+	if err := s.usecase.CreateUser(ctx, user, roleIDs); err != nil {
 		return nil, err
 	}
 	return &pb.CreateUserReply{
@@ -81,12 +95,7 @@ func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		if req.Password != req.RePassword {
 			return nil, ErrPasswordMismatch
 		}
-
-		hp, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return nil, err
-		}
-		user.Password = string(hp)
+		user.Password = req.Password
 	}
 
 	if err := s.usecase.UpdateUser(ctx, user); err != nil {
@@ -111,6 +120,43 @@ func (s *UserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 	return &pb.DeleteUserReply{}, nil
 }
 
+func (s *UserService) ListDeletedUsers(ctx context.Context, req *pb.ListDeletedUsersRequest) (*pb.ListDeletedUsersReply, error) {
+	pagination := protobuf.PageWrap(req.Pagination)
+	users, err := s.usecase.ListDeletedUsers(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListDeletedUsersReply{
+		Pagination: pagination.Resp(),
+		Data:       lo.Map(users, toMap),
+	}, nil
+}
+
+func (s *UserService) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserReply, error) {
+	user, err := s.usecase.RestoreUser(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applicationEventPublisher.Publish(ctx, "user.restored", event.NewMessage(event.NewUUID(), event.Marshal(user)))
+	return &pb.RestoreUserReply{}, nil
+}
+
+// PurgeDeletedUsers 清除超过保留期的已删除用户, 并发布 user.purged 事件
+func (s *UserService) PurgeDeletedUsers(ctx context.Context) error {
+	retention := biz.DefaultDeletedUserRetention
+	if s.passportc.DeletedUserRetention != nil && s.passportc.DeletedUserRetention.AsDuration() > 0 {
+		retention = s.passportc.DeletedUserRetention.AsDuration()
+	}
+
+	purged, err := s.usecase.PurgeDeletedUsers(ctx, time.Now().Add(-retention))
+	for _, uid := range purged {
+		s.log.Infof("user %d purged", uid)
+		s.applicationEventPublisher.Publish(ctx, "user.purged", event.NewMessage(event.NewUUID(), []byte(strconv.FormatInt(uid, 10))))
+	}
+	return err
+}
+
 func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserReply, error) {
 	user, err := s.usecase.GetUser(ctx, req.Uid)
 	if err != nil {
@@ -123,6 +169,7 @@ func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 			Username:  user.Username,
 			Email:     user.Email,
 			Nickname:  user.Nickname,
+			Avatar:    biz.AvatarURL(user.AvatarID),
 			Status:    pb.UserStatus(user.Status),
 			CreatedAt: timestamppb.New(user.CreatedAt),
 			UpdatedAt: timestamppb.New(user.UpdatedAt),
@@ -148,24 +195,50 @@ func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 
 func (s *UserService) ListUser(ctx context.Context, req *pb.ListUserRequest) (*pb.ListUserReply, error) {
 	pagination := protobuf.PageWrap(req.Pagination)
-	users, err := s.usecase.ListUser(ctx, pagination, &biz.UserQueryFilter{
-		Status:   int(req.Status),
-		Username: req.Username,
-	})
+	users, next, err := s.usecase.ListUser(ctx, pagination, &biz.UserQueryFilter{
+		Status:          int(req.Status),
+		Username:        req.Username,
+		Email:           req.Email,
+		Keyword:         req.Keyword,
+		RoleIDs:         req.RoleIds,
+		DepartmentID:    req.DepartmentId,
+		CreatedAfter:    toTime(req.CreatedAfter),
+		CreatedBefore:   toTime(req.CreatedBefore),
+		LastLoginAfter:  toTime(req.LastLoginAfter),
+		LastLoginBefore: toTime(req.LastLoginBefore),
+		OrderBy:         req.OrderBy,
+		Asc:             req.Asc,
+	}, req.Cursor)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.ListUserReply{
 		Pagination: pagination.Resp(),
 		Data:       lo.Map(users, toMap),
+		NextCursor: next,
+	}, nil
+}
+
+// SetAvatar 设置用户头像
+func (s *UserService) SetAvatar(ctx context.Context, req *pb.SetAvatarRequest) (*pb.SetAvatarReply, error) {
+	if err := s.fileUsecase.SetAvatar(ctx, req.Uid, req.FileId); err != nil {
+		return nil, err
+	}
+	return &pb.SetAvatarReply{
+		Avatar: biz.AvatarURL(req.FileId),
 	}, nil
 }
 
 func (s *UserService) BindRole(ctx context.Context, req *pb.BindRoleRequest) (*pb.BindRoleReply, error) {
-	if err := s.usecase.BindRole(ctx, req.Uid, req.RoleId); err != nil {
+	claims, _ := jwt.FromContext(ctx)
+
+	binding, err := s.usecase.BindRole(ctx, req.Uid, req.RoleId, time.Duration(req.Ttl)*time.Second, claims.UID)
+	if err != nil {
 		return nil, err
 	}
-	return &pb.BindRoleReply{}, nil
+	return &pb.BindRoleReply{
+		ExpiresAt: lo.Ternary(binding.ExpiresAt == nil, nil, timestamppb.New(lo.FromPtr(binding.ExpiresAt))),
+	}, nil
 }
 
 func (s *UserService) UnbindRole(ctx context.Context, req *pb.UnbindRoleRequest) (*pb.UnbindRoleReply, error) {
@@ -175,6 +248,20 @@ func (s *UserService) UnbindRole(ctx context.Context, req *pb.UnbindRoleRequest)
 	return &pb.UnbindRoleReply{}, nil
 }
 
+// SweepExpiredRoles 清理过期的角色授权, 并发布 user.role.expired 事件
+func (s *UserService) SweepExpiredRoles(ctx context.Context) error {
+	expired, err := s.usecase.SweepExpiredRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range expired {
+		s.log.Infof("user %d role %d binding expired", item.UserID, item.RoleID)
+		s.applicationEventPublisher.Publish(ctx, "user.role.expired", event.NewMessage(event.NewUUID(), event.Marshal(item)))
+	}
+	return nil
+}
+
 func (s *UserService) UpdateRole(ctx context.Context, userID int64, roleIDs []int64) (*pb.UpdateRoleReply, error) {
 	if err := s.usecase.UpdateRole(ctx, userID, roleIDs); err != nil {
 		return nil, err
@@ -182,16 +269,26 @@ func (s *UserService) UpdateRole(ctx context.Context, userID int64, roleIDs []in
 	return &pb.UpdateRoleReply{}, nil
 }
 
+// toTime 未设置的时间戳返回零值, 即不作为筛选条件
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
 func toMap(user *biz.UserInfo, _ int) *pb.UserInfo {
 	return &pb.UserInfo{
 		Uid:       user.UID,
 		Username:  user.Username,
 		Email:     user.Email,
 		Nickname:  user.Nickname,
+		Avatar:    biz.AvatarURL(user.AvatarID),
 		Status:    pb.UserStatus(user.Status),
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
 		LastLogin: lo.Ternary(user.LastLogin.IsZero(), nil, timestamppb.New(user.LastLogin)),
 		RoleIds:   user.RoleIDs,
+		DeletedAt: lo.Ternary(user.DeletedAt.Valid, timestamppb.New(user.DeletedAt.Time), nil),
 	}
 }
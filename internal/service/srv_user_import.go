@@ -0,0 +1,255 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/samber/lo"
+	"github.com/xuri/excelize/v2"
+
+	pb "github.com/omalloc/kratos-admin/api/console/administration"
+	"github.com/omalloc/kratos-admin/internal/biz"
+	"github.com/omalloc/kratos-admin/pkg/idgen"
+)
+
+var (
+	ErrUserImportFormat = errors.New(400, "USER_IMPORT_FORMAT", "无法解析导入文件")
+	ErrUserImportHeader = errors.New(400, "USER_IMPORT_HEADER", "导入文件缺少 username 列")
+)
+
+// userExportHeader 导出文件的表头, 导入时会忽略 uid, status, last_login 列
+var userExportHeader = []string{"uid", "username", "email", "nickname", "status", "roles", "last_login"}
+
+// ImportUsers 批量导入用户
+func (s *UserService) ImportUsers(ctx context.Context, req *pb.ImportUsersRequest) (*pb.ImportUsersReply, error) {
+	records, err := readUserImportFile(req.Format, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := toUserImportRows(records)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.usecase.ImportUsers(ctx, rows, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	if !req.DryRun {
+		s.log.Infof("imported %d users, %d rows rejected", len(result.Created), len(result.Errors))
+	}
+
+	return &pb.ImportUsersReply{
+		Total:   int32(result.Total),
+		Created: int32(len(result.Created)),
+		Uids: lo.Ternary(req.DryRun, nil, lo.Map(result.Created, func(item *biz.User, _ int) int64 {
+			return item.UID
+		})),
+		Errors: lo.Map(result.Errors, func(item *biz.UserImportError, _ int) *pb.ImportUserError {
+			e := errors.FromError(item.Err)
+			return &pb.ImportUserError{
+				Line:     int32(item.Line),
+				Username: item.Username,
+				Reason:   e.Reason,
+				Message:  e.Message,
+				Metadata: e.Metadata,
+			}
+		}),
+	}, nil
+}
+
+// ExportUsers 通过 gRPC 流导出用户
+func (s *UserService) ExportUsers(req *pb.ExportUsersRequest, stream pb.User_ExportUsersServer) error {
+	w := bufio.NewWriterSize(&exportStreamWriter{stream: stream}, 32<<10)
+	if err := s.exportUsers(stream.Context(), req, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// ExportUsersHTTP 导出用户的 HTTP 入口, 文件直接写入响应
+func (s *UserService) ExportUsersHTTP(ctx khttp.Context) error {
+	var in pb.ExportUsersRequest
+	if err := ctx.BindQuery(&in); err != nil {
+		return err
+	}
+
+	khttp.SetOperation(ctx, pb.User_ExportUsers_FullMethodName)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		in := req.(*pb.ExportUsersRequest)
+
+		w := ctx.Response()
+		if in.Format == pb.UserFileFormat_USER_FILE_FORMAT_XLSX {
+			w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+			w.Header().Set("Content-Disposition", `attachment; filename="users.xlsx"`)
+		} else {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="users.csv"`)
+		}
+		return nil, s.exportUsers(c, in, w)
+	})
+	_, err := h(ctx, &in)
+	return err
+}
+
+func (s *UserService) exportUsers(ctx context.Context, req *pb.ExportUsersRequest, w io.Writer) error {
+	filter := &biz.UserQueryFilter{
+		Status:       int(req.Status),
+		Username:     req.Username,
+		Keyword:      req.Keyword,
+		RoleIDs:      req.RoleIds,
+		DepartmentID: req.DepartmentId,
+	}
+	record := func(user *biz.UserInfo, roles []string) []string {
+		return []string{
+			strconv.FormatInt(user.UID, 10),
+			user.Username,
+			user.Email,
+			user.Nickname,
+			pb.UserStatus(user.Status).String(),
+			strings.Join(roles, ";"),
+			lo.Ternary(user.LastLogin.IsZero(), "", user.LastLogin.Format(time.DateTime)),
+		}
+	}
+
+	if req.Format == pb.UserFileFormat_USER_FILE_FORMAT_XLSX {
+		f := excelize.NewFile()
+		defer f.Close()
+
+		sheet := f.GetSheetName(0)
+		sw, err := f.NewStreamWriter(sheet)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow("A1", lo.ToAnySlice(userExportHeader)); err != nil {
+			return err
+		}
+		row := 1
+		err = s.usecase.ExportUsers(ctx, filter, func(user *biz.UserInfo, roles []string) error {
+			row++
+			cell, err := excelize.CoordinatesToCellName(1, row)
+			if err != nil {
+				return err
+			}
+			return sw.SetRow(cell, lo.ToAnySlice(record(user, roles)))
+		})
+		if err != nil {
+			return err
+		}
+		if err := sw.Flush(); err != nil {
+			return err
+		}
+		return f.Write(w)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(userExportHeader); err != nil {
+		return err
+	}
+	err := s.usecase.ExportUsers(ctx, filter, func(user *biz.UserInfo, roles []string) error {
+		return cw.Write(record(user, roles))
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readUserImportFile 读取导入文件的全部行, 第一行为表头
+func readUserImportFile(format pb.UserFileFormat, content []byte) ([][]string, error) {
+	if format == pb.UserFileFormat_USER_FILE_FORMAT_XLSX {
+		f, err := excelize.OpenReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, userImportFormatError(err)
+		}
+		defer f.Close()
+
+		records, err := f.GetRows(f.GetSheetName(0))
+		if err != nil {
+			return nil, userImportFormatError(err)
+		}
+		return records, nil
+	}
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, userImportFormatError(err)
+	}
+	return records, nil
+}
+
+func userImportFormatError(err error) error {
+	return ErrUserImportFormat.WithCause(err).WithMetadata(map[string]string{
+		"error": err.Error(),
+	})
+}
+
+// toUserImportRows 按表头解析数据行, 列顺序不限, 未知列忽略, 空行跳过
+func toUserImportRows(records [][]string) ([]*biz.UserImportRow, error) {
+	if len(records) <= 0 {
+		return nil, biz.ErrUserImportEmpty
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, ErrUserImportHeader
+	}
+	cell := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]*biz.UserImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if lo.EveryBy(record, func(item string) bool { return strings.TrimSpace(item) == "" }) {
+			continue
+		}
+
+		roles := strings.FieldsFunc(cell(record, "roles"), func(r rune) bool { return r == ';' || r == '|' })
+		rows = append(rows, &biz.UserImportRow{
+			Line: i + 2,
+			User: &biz.User{
+				UID:       idgen.NextId(),
+				Username:  cell(record, "username"),
+				Password:  cell(record, "password"),
+				Email:     cell(record, "email"),
+				Nickname:  cell(record, "nickname"),
+				Status:    int64(pb.UserStatus_NORMAL),
+				LastLogin: time.Now(),
+			},
+			Roles: lo.Compact(lo.Map(roles, func(item string, _ int) string { return strings.TrimSpace(item) })),
+		})
+	}
+	return rows, nil
+}
+
+// exportStreamWriter 将导出文件按块写入 gRPC 流
+type exportStreamWriter struct {
+	stream pb.User_ExportUsersServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.ExportUsersReply{Chunk: bytes.Clone(p)}); err != nil {
+		return 0, fmt.Errorf("send export chunk: %w", err)
+	}
+	return len(p), nil
+}
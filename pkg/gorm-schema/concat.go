@@ -21,58 +21,37 @@ type StringSlice[T ~int | int32 | int64 | uint | uint32 | uint64] []T
 
 // Scan implements schema.SerializerInterface.
 func (g *StringSlice[T]) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
-	switch v := dbValue.(type) {
-	case string:
-		*g = lo.Map(strings.Split(v, ","), func(item string, _ int) T {
-			vv, _ := strconv.ParseInt(item, 10, 64)
-			return T(vv)
-		})
-	case []byte:
-		// Handle byte array by converting to string first
-		strValue := string(v)
-		*g = lo.Map(strings.Split(strValue, ","), func(item string, _ int) T {
-			vv, _ := strconv.ParseInt(item, 10, 64)
-			return T(vv)
-		})
-	default:
+	values, err := parseIntSlice(dbValue)
+	if err != nil {
 		*g = []T{}
+		return nil
 	}
+	*g = lo.Map(values, func(item int64, _ int) T {
+		return T(item)
+	})
 	return nil
 }
 
 // Value implements schema.SerializerInterface.
 func (g *StringSlice[T]) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
 	return strings.Join(lo.Map([]T(*g), func(item T, _ int) string {
-		return strconv.Itoa(int(item))
+		return strconv.FormatInt(int64(item), 10)
 	}), ","), nil
 }
 
 // StringSliceSerializer 字符串切片序列化器
-// 将 "1,2,3" 转换为 []int64{1,2,3}
+// 将 "1,2,3" (GROUP_CONCAT / STRING_AGG) 或 "{1,2,3}" (postgres 数组) 转换为 []int64{1,2,3}
 type StringSliceSerializer struct{}
 
 func (StringSliceSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
 	fieldValue := reflect.New(field.FieldType)
 
 	if dbValue != nil {
-		switch v := dbValue.(type) {
-		case string:
-			intSlice := lo.Map(strings.Split(v, ","), func(item string, _ int) int64 {
-				vv, _ := strconv.ParseInt(item, 10, 64)
-				return vv
-			})
-			fieldValue.Elem().Set(reflect.ValueOf(intSlice))
-		case []byte:
-			// Handle byte array by converting to string first
-			strValue := string(v)
-			intSlice := lo.Map(strings.Split(strValue, ","), func(item string, _ int) int64 {
-				vv, _ := strconv.ParseInt(item, 10, 64)
-				return vv
-			})
-			fieldValue.Elem().Set(reflect.ValueOf(intSlice))
-		default:
-			return fmt.Errorf("failed to unmarshal IntSlice value: %#v", dbValue)
+		intSlice, err := parseIntSlice(dbValue)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal IntSlice value: %w", err)
 		}
+		fieldValue.Elem().Set(reflect.ValueOf(intSlice))
 	}
 
 	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
@@ -82,6 +61,63 @@ func (StringSliceSerializer) Scan(ctx context.Context, field *schema.Field, dst
 // 实现 Value 方法
 func (StringSliceSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
 	return strings.Join(lo.Map(fieldValue.([]int64), func(item int64, _ int) string {
-		return strconv.Itoa(int(item))
+		return strconv.FormatInt(item, 10)
 	}), ","), nil
 }
+
+// parseIntSlice 解析数据库返回的整数列表, 支持逗号分隔的文本、postgres 数组的文本形式以及驱动解码后的数组
+func parseIntSlice(dbValue any) ([]int64, error) {
+	switch v := dbValue.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return parseIntList(v)
+	case []byte:
+		return parseIntList(string(v))
+	case []int64:
+		return v, nil
+	case []any:
+		ret := make([]int64, 0, len(v))
+		for _, item := range v {
+			switch n := item.(type) {
+			case nil:
+			case int64:
+				ret = append(ret, n)
+			case int32:
+				ret = append(ret, int64(n))
+			case string:
+				vv, err := strconv.ParseInt(n, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, vv)
+			default:
+				return nil, fmt.Errorf("unsupported element %#v", item)
+			}
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %#v", dbValue)
+	}
+}
+
+func parseIntList(s string) ([]int64, error) {
+	s = strings.Trim(strings.TrimSpace(s), "{}")
+	if s == "" {
+		return []int64{}, nil
+	}
+
+	ret := make([]int64, 0, strings.Count(s, ",")+1)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || strings.EqualFold(item, "NULL") {
+			continue
+		}
+		vv, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, vv)
+	}
+	return ret, nil
+}
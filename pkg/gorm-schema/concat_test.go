@@ -0,0 +1,40 @@
+package gormschema
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseIntSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    []int64
+		wantErr bool
+	}{
+		{"nil", nil, nil, false},
+		{"group_concat", "1,2,3", []int64{1, 2, 3}, false},
+		{"group_concat bytes", []byte("4,5"), []int64{4, 5}, false},
+		{"string_agg with spaces", " 1, 2 ", []int64{1, 2}, false},
+		{"postgres array text", "{1,2,3}", []int64{1, 2, 3}, false},
+		{"postgres array with null", "{1,NULL,3}", []int64{1, 3}, false},
+		{"empty", "", []int64{}, false},
+		{"empty array", "{}", []int64{}, false},
+		{"decoded int64", []int64{7, 8}, []int64{7, 8}, false},
+		{"decoded any", []any{int64(1), int32(2), "3", nil}, []int64{1, 2, 3}, false},
+		{"invalid number", "1,x", nil, true},
+		{"invalid element", []any{1.5}, nil, true},
+		{"unsupported", 42, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIntSlice(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseIntSlice(%#v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseIntSlice(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
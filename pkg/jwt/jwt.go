@@ -45,7 +45,10 @@ var (
 type AppClaims struct {
 	jwt.RegisteredClaims
 
-	UID int64 `json:"uid"`
+	UID      int64 `json:"uid"`
+	TenantID int64 `json:"tenant_id,omitempty"`
+	// SuperAdmin 超级管理员, 可以跨租户操作
+	SuperAdmin bool `json:"super_admin,omitempty"`
 }
 
 // Option is jwt option.
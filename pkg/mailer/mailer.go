@@ -0,0 +1,72 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Message 邮件内容, Body 为纯文本
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTP 通过 SMTP 发送邮件, username 为空时不进行认证
+func NewSMTP(host string, port int, username, password, from string) Mailer {
+	m := &smtpMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+	}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *smtpMailer) Send(_ context.Context, msg *Message) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+
+	return smtp.SendMail(m.addr, m.auth, m.from, msg.To, buf.Bytes())
+}
+
+type logMailer struct {
+	log *log.Helper
+}
+
+// NewLog 仅打印邮件内容, 用于未配置 SMTP 的开发环境
+func NewLog(logger log.Logger) Mailer {
+	return &logMailer{log: log.NewHelper(logger)}
+}
+
+func (m *logMailer) Send(_ context.Context, msg *Message) error {
+	m.log.Infof("send mail to %v, subject: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
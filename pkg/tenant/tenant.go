@@ -0,0 +1,79 @@
+package tenant
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/omalloc/kratos-admin/pkg/jwt"
+)
+
+// PlatformID 平台数据的 tenant_id, 不属于任何租户; 目前只有菜单, 对所有租户共享.
+// 用户都属于具体的租户, 是否为超级管理员由用户的 super_admin 标记决定
+const PlatformID int64 = 0
+
+// HeaderKey 超级管理员通过该请求头指定要操作的租户
+const HeaderKey = "X-Tenant-ID"
+
+var ErrInvalidTenant = errors.BadRequest("INVALID_TENANT", "invalid tenant id")
+
+type tenantKey struct{}
+
+// NewContext put tenant id into context, 数据访问将被限定在该租户内
+func NewContext(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// FromContext extract tenant id from context, 返回 false 时不限定租户
+func FromContext(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	tenantID, ok := ctx.Value(tenantKey{}).(int64)
+	return tenantID, ok
+}
+
+// WithoutTenant 移除 context 中的租户, 数据访问不再限定租户; 调用方需自行按租户过滤结果
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, nil)
+}
+
+// IsSuperAdmin 当前登录用户是否为超级管理员
+func IsSuperAdmin(ctx context.Context) bool {
+	claims, ok := jwt.FromContext(ctx)
+	return ok && claims.SuperAdmin
+}
+
+// Server 根据登录用户的租户限定数据访问范围, 需要在 jwt.Server 之后执行.
+//
+// 普通用户始终限定在自己的租户内;
+// 超级管理员默认不限定租户, 可以通过 X-Tenant-ID 请求头进入指定租户.
+// 超级管理员新建租户内的数据 (用户、角色等) 时必须指定 X-Tenant-ID, 否则返回 ErrInvalidTenant.
+func Server() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			claims, ok := jwt.FromContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			if !claims.SuperAdmin {
+				return handler(NewContext(ctx, claims.TenantID), req)
+			}
+
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				if value := tr.RequestHeader().Get(HeaderKey); value != "" {
+					tenantID, err := strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return nil, ErrInvalidTenant
+					}
+					ctx = NewContext(ctx, tenantID)
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
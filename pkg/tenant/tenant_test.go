@@ -0,0 +1,80 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/omalloc/kratos-admin/pkg/jwt"
+)
+
+type testTransport struct {
+	header transport.Header
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return "" }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.header }
+func (tr *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    *jwt.AppClaims
+		header    string
+		want      int64
+		wantScope bool
+		wantErr   error
+	}{
+		{"anonymous", nil, "", 0, false, nil},
+		{"tenant user", &jwt.AppClaims{UID: 1, TenantID: 7}, "", 7, true, nil},
+		{"tenant user ignores header", &jwt.AppClaims{UID: 1, TenantID: 7}, "9", 7, true, nil},
+		// tenant_id 为 0 的用户不再是超级管理员
+		{"platform tenant user", &jwt.AppClaims{UID: 1}, "9", 0, true, nil},
+		{"super admin", &jwt.AppClaims{UID: 1, TenantID: 7, SuperAdmin: true}, "", 0, false, nil},
+		{"super admin enters tenant", &jwt.AppClaims{UID: 1, TenantID: 7, SuperAdmin: true}, "9", 9, true, nil},
+		{"super admin invalid header", &jwt.AppClaims{UID: 1, SuperAdmin: true}, "x", 0, false, ErrInvalidTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &testTransport{header: headerCarrier{}}
+			if tt.header != "" {
+				tr.header.Set(HeaderKey, tt.header)
+			}
+			ctx := transport.NewServerContext(context.Background(), tr)
+			if tt.claims != nil {
+				ctx = jwt.NewContext(ctx, tt.claims)
+			}
+
+			var (
+				got   int64
+				scope bool
+			)
+			_, err := Server()(func(ctx context.Context, req any) (any, error) {
+				got, scope = FromContext(ctx)
+				if IsSuperAdmin(ctx) != (tt.claims != nil && tt.claims.SuperAdmin) {
+					t.Error("IsSuperAdmin does not follow the claims")
+				}
+				return nil, nil
+			})(ctx, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (got != tt.want || scope != tt.wantScope) {
+				t.Fatalf("tenant = %d scoped = %v, want %d %v", got, scope, tt.want, tt.wantScope)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package tokener
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidInvitationToken = errors.New("invalid invitation token")
+
+// NewInvitationToken 生成一次性邀请令牌, 格式为 nonce.signature,
+// 返回令牌以及用于持久化的令牌摘要 (不保存令牌明文)
+func NewInvitationToken(secret string) (token string, digest string, err error) {
+	if secret == "" {
+		return "", "", errors.New("secret is required")
+	}
+
+	nonce := make([]byte, 24)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(nonce)
+	token = payload + "." + base64.RawURLEncoding.EncodeToString(sign(secret, payload))
+	return token, InvitationDigest(token), nil
+}
+
+// VerifyInvitationToken 校验令牌签名, 返回令牌摘要
+func VerifyInvitationToken(secret string, token string) (string, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidInvitationToken
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(buf, sign(secret, payload)) {
+		return "", ErrInvalidInvitationToken
+	}
+	return InvitationDigest(token), nil
+}
+
+// InvitationDigest 令牌摘要
+func InvitationDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func sign(secret string, payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -54,7 +54,7 @@ func NewTokener(opts ...JwtOption) AppToken {
 }
 
 // Generate implements AppToken.
-func (j *jwtToken) Generate(uid int64) (string, error) {
+func (j *jwtToken) Generate(uid int64, tenantID int64, superAdmin bool) (string, error) {
 	if j.opts.secret == "" {
 		return "", errors.New("secret is required")
 	}
@@ -70,7 +70,9 @@ func (j *jwtToken) Generate(uid int64) (string, error) {
 			ExpiresAt: jwtv5.NewNumericDate(iat.Add(j.opts.ttl)),
 			IssuedAt:  jwtv5.NewNumericDate(iat),
 		},
-		UID: uid,
+		UID:        uid,
+		TenantID:   tenantID,
+		SuperAdmin: superAdmin,
 	}
 
 	token := jwtv5.NewWithClaims(jwtv5.SigningMethodHS256, claims)
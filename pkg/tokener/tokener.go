@@ -3,6 +3,6 @@ package tokener
 import "github.com/omalloc/kratos-admin/pkg/jwt"
 
 type AppToken interface {
-	Generate(subjet int64) (string, error)
+	Generate(subjet int64, tenantID int64, superAdmin bool) (string, error)
 	Parse(tokenString string) (*jwt.AppClaims, error)
 }